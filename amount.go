@@ -0,0 +1,205 @@
+package syscoinrpc
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Amount represents an amount of SYS, stored as an integer number of satoshis
+// to never lose precision.
+type Amount int64
+
+const (
+	// Satoshi is the smallest amount, 1 satoshi.
+	Satoshi Amount = 1
+	// SYS is the amount of 1 SYS, 100000000 satoshis.
+	SYS Amount = 100000000
+
+	// amountDecimals is the number of decimals of an amount in SYS.
+	amountDecimals = 8
+)
+
+// ErrInvalidAmount is returned when parsing a malformed or out of range amount.
+var ErrInvalidAmount = errors.New("Invalid amount")
+
+// NewAmount returns the amount of the given value in SYS, rounded to the nearest satoshi.
+// Use ParseAmount to build exact amounts.
+func NewAmount(sys float64) (Amount, error) {
+	satoshis := math.Round(sys * float64(SYS))
+	if math.IsNaN(satoshis) || satoshis >= math.MaxInt64 || satoshis < math.MinInt64 {
+		return 0, ErrInvalidAmount
+	}
+	return Amount(satoshis), nil
+}
+
+// ParseAmount parses an exact decimal amount in SYS (e.g. "0.00010000"),
+// in the format of syscoind JSON numbers.
+//
+// It errors if the amount has more than 8 decimals or overflows.
+func ParseAmount(sys string) (Amount, error) {
+	negative := strings.HasPrefix(sys, "-")
+	sys = strings.TrimPrefix(sys, "-")
+
+	exponent := 0
+	if i := strings.IndexAny(sys, "eE"); i >= 0 {
+		var err error
+		exponent, err = strconv.Atoi(sys[i+1:])
+		if err != nil || exponent > 100 || exponent < -100 {
+			return 0, ErrInvalidAmount
+		}
+		sys = sys[:i]
+	}
+
+	units, decimals := sys, ""
+	if i := strings.IndexByte(sys, '.'); i >= 0 {
+		units, decimals = sys[:i], sys[i+1:]
+	}
+	digits := units + decimals
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return 0, ErrInvalidAmount
+	}
+
+	// Move the decimal point to get satoshis.
+	shift := exponent - len(decimals) + amountDecimals
+	digits = strings.TrimLeft(digits, "0")
+	for ; shift < 0; shift++ {
+		if digits == "" {
+			break
+		}
+		if digits[len(digits)-1] != '0' {
+			return 0, ErrInvalidAmount // More than 8 decimals.
+		}
+		digits = digits[:len(digits)-1]
+	}
+	if digits == "" {
+		return 0, nil
+	}
+	if len(digits)+shift > 19 {
+		return 0, ErrInvalidAmount
+	}
+	digits += strings.Repeat("0", shift)
+
+	value, err := strconv.ParseUint(digits, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidAmount
+	}
+	if negative {
+		if value > math.MaxInt64+1 {
+			return 0, ErrInvalidAmount
+		}
+		return Amount(-value), nil
+	}
+	if value > math.MaxInt64 {
+		return 0, ErrInvalidAmount
+	}
+	return Amount(value), nil
+}
+
+// ToSYS returns the amount in SYS, as a float.
+func (a Amount) ToSYS() float64 {
+	return float64(a) / float64(SYS)
+}
+
+// Satoshis returns the amount in satoshis.
+func (a Amount) Satoshis() int64 {
+	return int64(a)
+}
+
+// Add returns the sum of a and b.
+func (a Amount) Add(b Amount) Amount {
+	return a + b
+}
+
+// Sub returns the difference of a and b.
+func (a Amount) Sub(b Amount) Amount {
+	return a - b
+}
+
+// Mul returns the amount multiplied by n.
+func (a Amount) Mul(n int64) Amount {
+	return a * Amount(n)
+}
+
+// SumAmounts returns the sum of all the amounts.
+func SumAmounts(amounts ...Amount) Amount {
+	var sum Amount
+	for _, amount := range amounts {
+		sum += amount
+	}
+	return sum
+}
+
+// format returns the exact decimal representation of the amount in SYS,
+// with 8 decimals.
+func (a Amount) format() string {
+	sign := ""
+	// Work on uint64 to handle the minimum int64 too.
+	abs := uint64(a)
+	if a < 0 {
+		sign = "-"
+		abs = -abs
+	}
+	units := strconv.FormatUint(abs/uint64(SYS), 10)
+	decimals := strconv.FormatUint(abs%uint64(SYS), 10)
+	for len(decimals) < amountDecimals {
+		decimals = "0" + decimals
+	}
+	return sign + units + "." + decimals
+}
+
+// String returns the amount in SYS, with all its decimals (e.g. "0.00010000 SYS").
+func (a Amount) String() string {
+	return a.format() + " SYS"
+}
+
+// MarshalJSON encodes the amount as an exact JSON number in SYS, like syscoind does.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.format()), nil
+}
+
+// UnmarshalJSON decodes a JSON number in SYS without float rounding.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	amount, err := ParseAmount(string(data))
+	if err != nil {
+		return err
+	}
+	*a = amount
+	return nil
+}
+
+// SatoshiAmount is an Amount which syscoind reports as a JSON integer in satoshis
+// rather than as a JSON number in SYS, like the fees of `getblockstats`.
+type SatoshiAmount Amount
+
+// Amount returns the amount.
+func (a SatoshiAmount) Amount() Amount {
+	return Amount(a)
+}
+
+// String returns the amount in SYS, with all its decimals (e.g. "0.00010000 SYS").
+func (a SatoshiAmount) String() string {
+	return Amount(a).String()
+}
+
+// MarshalJSON encodes the amount as a JSON integer in satoshis.
+func (a SatoshiAmount) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(a), 10)), nil
+}
+
+// UnmarshalJSON decodes a JSON integer in satoshis.
+func (a *SatoshiAmount) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	value, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return ErrInvalidAmount
+	}
+	*a = SatoshiAmount(value)
+	return nil
+}
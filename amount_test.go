@@ -0,0 +1,101 @@
+package syscoinrpc_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+)
+
+func TestAmountInvalid(t *testing.T) {
+	for _, value := range []string{"", "-", ".", "abc", "1.2.3", "0x10", "1/3", "0.000000001", "1e-9", "92233720368.54775808", "1e100", "1e999", `"1"`} {
+		_, err := syscoinrpc.ParseAmount(value)
+		require.Equal(t, syscoinrpc.ErrInvalidAmount, err, "Must error on %q", value)
+	}
+
+	_, err := syscoinrpc.NewAmount(math.NaN())
+	require.Equal(t, syscoinrpc.ErrInvalidAmount, err, "Must error on NaN")
+	_, err = syscoinrpc.NewAmount(math.Inf(1))
+	require.Equal(t, syscoinrpc.ErrInvalidAmount, err, "Must error on infinity")
+
+	var amount syscoinrpc.Amount
+	require.Error(t, json.Unmarshal([]byte(`"0.1"`), &amount), "Must error on JSON strings")
+
+	var entry syscoinrpc.MempoolEntry
+	require.Error(t, json.Unmarshal([]byte(`{"descendantfees":0.0001}`), &entry), "Must error on fees not in satoshis")
+}
+
+func TestAmountOK(t *testing.T) {
+	tests := map[string]syscoinrpc.Amount{
+		"0":                     0,
+		"0.00000001":            1,
+		"0.00010000":            10000,
+		"-0.1":                  -10000000,
+		"1":                     syscoinrpc.SYS,
+		"12.34567890":           1234567890,
+		"1e-05":                 1000,
+		"1.5E2":                 15000000000,
+		"0.000000010000":        1,
+		"92233720368.54775807":  math.MaxInt64,
+		"-92233720368.54775808": math.MinInt64,
+	}
+	for value, expected := range tests {
+		amount, err := syscoinrpc.ParseAmount(value)
+		require.NoError(t, err, "Must parse %q", value)
+		require.Equal(t, expected, amount, "Must parse %q exactly", value)
+	}
+
+	amount, err := syscoinrpc.NewAmount(0.1)
+	require.NoError(t, err)
+	require.Equal(t, 10000000*syscoinrpc.Satoshi, amount)
+	require.Equal(t, 0.1, amount.ToSYS())
+	require.Equal(t, int64(10000000), amount.Satoshis())
+
+	// Summing 0.1 ten times is exact, unlike with floats.
+	var sum syscoinrpc.Amount
+	for i := 0; i < 10; i++ {
+		sum = sum.Add(amount)
+	}
+	require.Equal(t, syscoinrpc.SYS, sum)
+	require.Equal(t, syscoinrpc.SYS, syscoinrpc.SumAmounts(amount.Mul(4), amount.Mul(6)))
+	require.Equal(t, -amount, amount.Sub(amount.Mul(2)))
+
+	require.Equal(t, "0.10000000 SYS", amount.String())
+	require.Equal(t, "-0.00000001 SYS", (-syscoinrpc.Satoshi).String())
+	require.Equal(t, "-92233720368.54775808 SYS", syscoinrpc.Amount(math.MinInt64).String())
+
+	var txOut syscoinrpc.TxOut
+	require.NoError(t, json.Unmarshal([]byte(`{"value":20999999.97690000}`), &txOut))
+	require.Equal(t, syscoinrpc.Amount(2099999997690000), txOut.Value, "Must not lose precision")
+	encoded, err := json.Marshal(txOut.Value)
+	require.NoError(t, err)
+	require.Equal(t, "20999999.97690000", string(encoded), "Must marshal back exactly")
+
+	entryJSON := `{"fee":0.00010000,"modifiedfee":0.00020000,"descendantfees":10000,"ancestorfees":30000,"size":225}`
+	var entry syscoinrpc.MempoolEntry
+	require.NoError(t, json.Unmarshal([]byte(entryJSON), &entry))
+	require.Equal(t, syscoinrpc.Amount(10000), entry.Fee)
+	require.Equal(t, syscoinrpc.Amount(20000), entry.ModifiedFee)
+	require.Equal(t, syscoinrpc.SatoshiAmount(10000), entry.DescendantFees, "Must decode fees in satoshis")
+	require.Equal(t, syscoinrpc.SatoshiAmount(30000), entry.AncestorFees, "Must decode fees in satoshis")
+	require.Equal(t, uint64(225), entry.Size)
+
+	encoded, err = json.Marshal(entry)
+	require.NoError(t, err)
+	var decoded syscoinrpc.MempoolEntry
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	require.Equal(t, entry, decoded, "Must round trip")
+
+	var stats syscoinrpc.BlockStats
+	require.NoError(t, json.Unmarshal([]byte(`{"subsidy":5000000000,"totalfee":2260,"height":12}`), &stats))
+	require.Equal(t, syscoinrpc.SatoshiAmount(50*syscoinrpc.SYS), stats.Subsidy, "Must decode stats in satoshis")
+	require.Equal(t, syscoinrpc.SatoshiAmount(2260), stats.TotalFee)
+	require.Equal(t, uint64(12), stats.Height)
+
+	var auxBlock syscoinrpc.AuxBlock
+	require.NoError(t, json.Unmarshal([]byte(`{"coinbasevalue":5000000000,"target":"ffff7f"}`), &auxBlock))
+	require.Equal(t, syscoinrpc.SatoshiAmount(50*syscoinrpc.SYS), auxBlock.CoinbaseValue, "Must decode the coinbase value in satoshis")
+	require.Equal(t, "ffff7f", auxBlock.Target, "Must decode the target of older nodes")
+}
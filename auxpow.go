@@ -0,0 +1,227 @@
+package syscoinrpc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidAuxPow is returned when an AuxPoW is malformed or does not prove
+// the work on the merge mined block.
+var ErrInvalidAuxPow = errors.New("Invalid AuxPoW")
+
+// mergedMiningHeader is the magic prefix of the merged mining commitment
+// in the scriptSig of the parent coinbase.
+var mergedMiningHeader = []byte{0xfa, 0xbe, 'm', 'm'}
+
+// maxChainMerkleBranchLength is the maximum length of the merge mined chains merkle branch.
+const maxChainMerkleBranchLength = 30
+
+// AuxPowCommitment returns the merged mining commitment to include in the
+// scriptSig of the parent coinbase, when the block is the only merge mined
+// one: the merged mining header, followed by the block hash as the merkle
+// root of the merge mined chains, the merkle tree size (1) and nonce (0).
+//
+//     blockHash : The hash of the merge mined block, see AuxBlock.
+func AuxPowCommitment(blockHash Hash) []byte {
+	var commitment bytes.Buffer
+	commitment.Write(mergedMiningHeader)
+	commitment.Write(reversed(blockHash[:]))
+	binary.Write(&commitment, binary.LittleEndian, uint32(1))
+	binary.Write(&commitment, binary.LittleEndian, uint32(0))
+	return commitment.Bytes()
+}
+
+// NewAuxPow builds the AuxPoW proving the work of the parent block on the
+// merge mined block, when it is the only merge mined one (the chain merkle
+// branch is empty). It checks the AuxPoW with Verify.
+//
+//     blockHash    : The hash of the merge mined block, see AuxBlock.
+//     coinbase     : The serialized coinbase of the parent block, without witness,
+//                    including AuxPowCommitment(blockHash) in its scriptSig.
+//     merkleBranch : The merkle branch linking the coinbase to the merkle root of
+//                    the parent block, the hashes of its siblings from the bottom.
+//     parentHeader : The serialized 80 bytes header of the parent block.
+//     network      : The network of the merge mined block, used to encode the addresses
+//                    of the coinbase (MainNet, TestNet or RegTest).
+func NewAuxPow(blockHash Hash, coinbase []byte, merkleBranch []Hash, parentHeader []byte, network string) (*AuxPow, error) {
+	if len(parentHeader) != blockHeaderSize {
+		return nil, fmt.Errorf("%w: the parent header must be %d bytes, got %d", ErrInvalidAuxPow, blockHeaderSize, len(parentHeader))
+	}
+	tx, err := DecodeTransaction(coinbase, network)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Hash != tx.TxID {
+		return nil, fmt.Errorf("%w: the parent coinbase must be serialized without witness", ErrInvalidAuxPow)
+	}
+	tx.BlockHash = doubleSHA256(parentHeader)
+
+	auxPow := &AuxPow{
+		Tx:                *tx,
+		MerkleBranch:      append([]Hash{}, merkleBranch...),
+		ChainMerkleBranch: []Hash{},
+		ParentBlock:       hex.EncodeToString(parentHeader),
+	}
+	// The chain ID does not change the index of the only merge mined block.
+	err = auxPow.Verify(blockHash, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return auxPow, nil
+}
+
+// Serialize returns the serialized AuxPoW, as expected by SubmitAuxBlock
+// and as following the header of merge mined blocks.
+func (ap *AuxPow) Serialize() ([]byte, error) {
+	tx, err := hex.DecodeString(ap.Tx.Hex)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed coinbase: %v", ErrInvalidAuxPow, err)
+	}
+	parentHeader, err := hex.DecodeString(ap.ParentBlock)
+	if err != nil || len(parentHeader) != blockHeaderSize {
+		return nil, fmt.Errorf("%w: malformed parent header", ErrInvalidAuxPow)
+	}
+
+	var raw bytes.Buffer
+	raw.Write(tx)
+	raw.Write(ap.Tx.BlockHash[:])
+	writeHashes(&raw, ap.MerkleBranch)
+	binary.Write(&raw, binary.LittleEndian, uint32(ap.Index))
+	writeHashes(&raw, ap.ChainMerkleBranch)
+	binary.Write(&raw, binary.LittleEndian, uint32(ap.ChainIndex))
+	raw.Write(parentHeader)
+	return raw.Bytes(), nil
+}
+
+// SerializeHex is like Serialize but returns the hex encoded AuxPoW.
+func (ap *AuxPow) SerializeHex() (string, error) {
+	raw, err := ap.Serialize()
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw), nil
+}
+
+// Verify checks that the AuxPoW commits to the merge mined block, like the
+// node does: the coinbase must be linked to the parent header and commit to
+// the merge mined chains merkle root, linked to the block hash at the index
+// expected for the chain ID.
+//
+// It does not check the work of the parent header against the target of the block.
+//
+//     blockHash : The hash of the merge mined block.
+//     chainID   : The chain ID of the merge mined chain, see AuxBlock.
+func (ap *AuxPow) Verify(blockHash Hash, chainID uint32) error {
+	if ap.Index != 0 || len(ap.Tx.Vin) != 1 || !ap.Tx.Vin[0].IsCoinbase() {
+		return fmt.Errorf("%w: the parent transaction is not a coinbase", ErrInvalidAuxPow)
+	}
+	if len(ap.ChainMerkleBranch) > maxChainMerkleBranchLength {
+		return fmt.Errorf("%w: chain merkle branch too long", ErrInvalidAuxPow)
+	}
+	parentHeader, err := DecodeBlockHeaderHex(ap.ParentBlock)
+	if err != nil {
+		return fmt.Errorf("%w: malformed parent header", ErrInvalidAuxPow)
+	}
+	if merkleBranchRoot(ap.Tx.TxID, ap.MerkleBranch, ap.Index) != parentHeader.MerkleRoot {
+		return fmt.Errorf("%w: merkle root incorrect", ErrInvalidAuxPow)
+	}
+
+	script, err := hex.DecodeString(ap.Tx.Vin[0].Coinbase)
+	if err != nil {
+		return fmt.Errorf("%w: malformed coinbase", ErrInvalidAuxPow)
+	}
+	chainRoot := merkleBranchRoot(blockHash, ap.ChainMerkleBranch, ap.ChainIndex)
+	rootPos := bytes.Index(script, reversed(chainRoot[:]))
+	if rootPos < 0 {
+		return fmt.Errorf("%w: missing chain merkle root in parent coinbase", ErrInvalidAuxPow)
+	}
+	if headerPos := bytes.Index(script, mergedMiningHeader); headerPos >= 0 {
+		if bytes.Contains(script[headerPos+1:], mergedMiningHeader) {
+			return fmt.Errorf("%w: multiple merged mining headers in coinbase", ErrInvalidAuxPow)
+		}
+		if headerPos+len(mergedMiningHeader) != rootPos {
+			return fmt.Errorf("%w: merged mining header is not just before chain merkle root", ErrInvalidAuxPow)
+		}
+	} else if rootPos > 20 {
+		// Legacy commitments, without merged mining header.
+		return fmt.Errorf("%w: chain merkle root must start in the first 20 bytes of the parent coinbase", ErrInvalidAuxPow)
+	}
+
+	rest := script[rootPos+len(chainRoot):]
+	if len(rest) < 8 {
+		return fmt.Errorf("%w: missing chain merkle tree size and nonce in parent coinbase", ErrInvalidAuxPow)
+	}
+	size := binary.LittleEndian.Uint32(rest)
+	if size != 1<<uint(len(ap.ChainMerkleBranch)) {
+		return fmt.Errorf("%w: merkle branch size does not match parent coinbase", ErrInvalidAuxPow)
+	}
+	nonce := binary.LittleEndian.Uint32(rest[4:])
+	if ap.ChainIndex != uint64(expectedChainIndex(nonce, chainID, uint(len(ap.ChainMerkleBranch)))) {
+		return fmt.Errorf("%w: wrong chain index", ErrInvalidAuxPow)
+	}
+
+	return nil
+}
+
+// merkleBranchRoot returns the merkle root linked to the leaf by the branch,
+// the index being the position of the leaf in the tree.
+func merkleBranchRoot(leaf Hash, branch []Hash, index uint64) Hash {
+	hash := leaf
+	for _, sibling := range branch {
+		if index&1 != 0 {
+			hash = doubleSHA256(append(sibling[:], hash[:]...))
+		} else {
+			hash = doubleSHA256(append(hash[:], sibling[:]...))
+		}
+		index >>= 1
+	}
+	return hash
+}
+
+// expectedChainIndex returns the index of the chain in the merge mined chains
+// merkle tree of the given height, chosen by the nonce to avoid collisions.
+func expectedChainIndex(nonce uint32, chainID uint32, height uint) uint32 {
+	rand := nonce
+	rand = rand*1103515245 + 12345
+	rand += chainID
+	rand = rand*1103515245 + 12345
+	return rand % (1 << height)
+}
+
+// reversed returns a reversed copy of b.
+func reversed(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
+
+// writeHashes writes the count of the hashes followed by the hashes.
+func writeHashes(buf *bytes.Buffer, hashes []Hash) {
+	writeVarInt(buf, uint64(len(hashes)))
+	for _, hash := range hashes {
+		buf.Write(hash[:])
+	}
+}
+
+// writeVarInt writes n as a variable length integer, like the node does.
+func writeVarInt(buf *bytes.Buffer, n uint64) {
+	switch {
+	case n < 0xfd:
+		buf.WriteByte(byte(n))
+	case n <= 0xffff:
+		buf.WriteByte(0xfd)
+		binary.Write(buf, binary.LittleEndian, uint16(n))
+	case n <= 0xffffffff:
+		buf.WriteByte(0xfe)
+		binary.Write(buf, binary.LittleEndian, uint32(n))
+	default:
+		buf.WriteByte(0xff)
+		binary.Write(buf, binary.LittleEndian, n)
+	}
+}
@@ -0,0 +1,133 @@
+package syscoinrpc_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+)
+
+// testParentCoinbase returns a parent chain coinbase with the given scriptSig.
+func testParentCoinbase(scriptSig []byte) []byte {
+	coinbase := new(bytes.Buffer)
+	binary.Write(coinbase, binary.LittleEndian, uint32(1)) // version
+	coinbase.WriteByte(1)                                  // vin count
+	coinbase.Write(make([]byte, 32))
+	binary.Write(coinbase, binary.LittleEndian, uint32(0xffffffff))
+	coinbase.WriteByte(byte(len(scriptSig)))
+	coinbase.Write(scriptSig)
+	binary.Write(coinbase, binary.LittleEndian, uint32(0xffffffff))
+	coinbase.WriteByte(1) // vout count
+	binary.Write(coinbase, binary.LittleEndian, int64(625000000))
+	coinbase.Write([]byte{1, 0x51}) // OP_TRUE
+	coinbase.Write(make([]byte, 4)) // locktime
+	return coinbase.Bytes()
+}
+
+// testParentHeader returns a parent chain header with the given merkle root.
+func testParentHeader(merkleRoot syscoinrpc.Hash, nonce uint32) []byte {
+	header := new(bytes.Buffer)
+	binary.Write(header, binary.LittleEndian, uint32(0x20000000))
+	header.Write(make([]byte, 32))
+	header.Write(merkleRoot[:])
+	binary.Write(header, binary.LittleEndian, uint32(1600000000))
+	binary.Write(header, binary.LittleEndian, uint32(0x207fffff))
+	binary.Write(header, binary.LittleEndian, nonce)
+	return header.Bytes()
+}
+
+func TestNewAuxPowInvalid(t *testing.T) {
+	blockHash := testBlockHeader.Hash
+	scriptSig := append([]byte{3, 1, 2, 3}, syscoinrpc.AuxPowCommitment(blockHash)...)
+	coinbase := testParentCoinbase(scriptSig)
+	header := testParentHeader(doubleSHA256(coinbase), 0)
+
+	_, err := syscoinrpc.NewAuxPow(blockHash, coinbase, nil, header[:79], syscoinrpc.RegTest)
+	require.True(t, errors.Is(err, syscoinrpc.ErrInvalidAuxPow), "Must error on truncated parent headers, got %v", err)
+
+	_, err = syscoinrpc.NewAuxPow(blockHash, coinbase[:10], nil, header, syscoinrpc.RegTest)
+	require.True(t, errors.Is(err, syscoinrpc.ErrMalformedRawData), "Must error on malformed coinbases, got %v", err)
+
+	witnessTx, _ := testWitnessTx()
+	_, err = syscoinrpc.NewAuxPow(blockHash, witnessTx, nil, header, syscoinrpc.RegTest)
+	require.True(t, errors.Is(err, syscoinrpc.ErrInvalidAuxPow), "Must error on witness coinbases, got %v", err)
+
+	_, strippedTx := testWitnessTx()
+	_, err = syscoinrpc.NewAuxPow(blockHash, strippedTx, nil, testParentHeader(doubleSHA256(strippedTx), 0), syscoinrpc.RegTest)
+	require.EqualError(t, err, "Invalid AuxPoW: the parent transaction is not a coinbase")
+
+	_, err = syscoinrpc.NewAuxPow(blockHash, coinbase, []syscoinrpc.Hash{blockHash}, header, syscoinrpc.RegTest)
+	require.EqualError(t, err, "Invalid AuxPoW: merkle root incorrect")
+
+	_, err = syscoinrpc.NewAuxPow(testBlock.AuxPow.Tx.BlockHash, coinbase, nil, header, syscoinrpc.RegTest)
+	require.EqualError(t, err, "Invalid AuxPoW: missing chain merkle root in parent coinbase")
+
+	commitment := syscoinrpc.AuxPowCommitment(blockHash)
+	invalidScriptSigs := map[string][]byte{
+		"multiple merged mining headers in coinbase":                                append(append([]byte{}, commitment...), commitment[:4]...),
+		"merged mining header is not just before chain merkle root":                 append(append([]byte{}, commitment[:4]...), append([]byte{0}, commitment[4:]...)...),
+		"chain merkle root must start in the first 20 bytes of the parent coinbase": append(make([]byte, 21), commitment[4:]...),
+		"missing chain merkle tree size and nonce in parent coinbase":               commitment[:len(commitment)-1],
+		"merkle branch size does not match parent coinbase":                         append(append([]byte{}, commitment[:36]...), 2, 0, 0, 0, 0, 0, 0, 0),
+	}
+	for message, invalidScriptSig := range invalidScriptSigs {
+		invalidCoinbase := testParentCoinbase(invalidScriptSig)
+		_, err = syscoinrpc.NewAuxPow(blockHash, invalidCoinbase, nil, testParentHeader(doubleSHA256(invalidCoinbase), 0), syscoinrpc.RegTest)
+		require.EqualError(t, err, "Invalid AuxPoW: "+message)
+	}
+
+	auxPow, err := syscoinrpc.NewAuxPow(blockHash, coinbase, nil, header, syscoinrpc.RegTest)
+	require.NoError(t, err)
+	auxPow.ChainIndex = 1
+	require.EqualError(t, auxPow.Verify(blockHash, 0x1000), "Invalid AuxPoW: wrong chain index")
+	auxPow.ChainIndex = 0
+	auxPow.ChainMerkleBranch = make([]syscoinrpc.Hash, 31)
+	require.EqualError(t, auxPow.Verify(blockHash, 0x1000), "Invalid AuxPoW: chain merkle branch too long")
+
+	auxPow.ParentBlock = "00"
+	_, err = auxPow.Serialize()
+	require.True(t, errors.Is(err, syscoinrpc.ErrInvalidAuxPow), "Must error on malformed parent headers, got %v", err)
+	_, err = syscoinrpc.DecodeAuxPow(coinbase, syscoinrpc.RegTest)
+	require.True(t, errors.Is(err, syscoinrpc.ErrMalformedRawData), "Must error on truncated AuxPoW, got %v", err)
+	_, err = syscoinrpc.DecodeAuxPow(coinbase, "unknown")
+	require.True(t, errors.Is(err, syscoinrpc.ErrUnknownNetwork))
+}
+
+func TestNewAuxPowOK(t *testing.T) {
+	// The AuxPoW of a testnet block, with a legacy commitment without merged mining header.
+	expected := testBlock.AuxPow
+	expected.Tx.Hash = expected.Tx.TxID
+	expected.Tx.VSize = expected.Tx.Size
+	require.NoError(t, expected.Verify(testBlockHeader.Hash, 0x1000), "Must verify the AuxPoW as the node does")
+	raw, err := expected.Serialize()
+	require.NoError(t, err)
+	decoded, err := syscoinrpc.DecodeAuxPow(raw, syscoinrpc.TestNet)
+	require.NoError(t, err)
+	require.Equal(t, &expected, decoded, "Must serialize the AuxPoW as the node does")
+
+	// A parent block with two transactions after the coinbase.
+	blockHash := syscoinrpc.MustParseHash("a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90")
+	coinbase := testParentCoinbase(append([]byte{3, 1, 2, 3}, syscoinrpc.AuxPowCommitment(blockHash)...))
+	_, tx := testWitnessTx()
+	txIDs := []syscoinrpc.Hash{doubleSHA256(coinbase), doubleSHA256(tx), doubleSHA256(append(tx, 0))}
+	branch := []syscoinrpc.Hash{txIDs[1], doubleSHA256(append(txIDs[2][:], txIDs[2][:]...))}
+	header := testParentHeader(testMerkleRoot(txIDs), 42)
+
+	auxPow, err := syscoinrpc.NewAuxPow(blockHash, coinbase, branch, header, syscoinrpc.RegTest)
+	require.NoError(t, err, "Must build the AuxPoW")
+	require.Equal(t, hex.EncodeToString(coinbase), auxPow.Tx.Hex)
+	require.Equal(t, doubleSHA256(header), auxPow.Tx.BlockHash, "Must link the parent block")
+	require.Equal(t, branch, auxPow.MerkleBranch)
+	require.NoError(t, auxPow.Verify(blockHash, 0x1000))
+	require.Error(t, auxPow.Verify(testBlockHeader.Hash, 0x1000), "Must only prove the committed block")
+
+	rawHex, err := auxPow.SerializeHex()
+	require.NoError(t, err)
+	decoded, err = syscoinrpc.DecodeAuxPowHex(rawHex, syscoinrpc.RegTest)
+	require.NoError(t, err)
+	require.Equal(t, auxPow, decoded, "Must decode the serialized AuxPoW")
+}
@@ -0,0 +1,503 @@
+package syscoinrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+var (
+	// ErrBatchNotSent is returned by the result of a batched call
+	// when the batch has not been sent yet.
+	ErrBatchNotSent = errors.New("Batch has not been sent yet")
+	// ErrBatchNoResponse is returned by the result of a batched call
+	// when the node did not answer to that specific call.
+	ErrBatchNoResponse = errors.New("No response received for the batched call")
+)
+
+// Batch queues multiple RPC calls and sends them to the node
+// as a single JSON-RPC array request (see BatchTransport).
+//
+// Every queued call returns a typed handle whose Result method
+// is available once the batch has been sent. Errors returned by
+// the node are reported per call, independently.
+type Batch struct {
+	c          *Client          // The binded client, must not be nil.
+	calls      []*RPCCall       // The calls queued and not sent yet.
+	Blockchain *BlockchainBatch // The builder of `blockchain` calls.
+}
+
+// NewBatch creates a new empty batch binded to the client.
+func (c *Client) NewBatch() *Batch {
+	b := &Batch{c: c}
+	b.Blockchain = &BlockchainBatch{b}
+	return b
+}
+
+// Len returns the number of calls queued and not sent yet.
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+// queue adds a call to the batch.
+func (b *Batch) queue(method string, params ...interface{}) *RPCCall {
+	call := &RPCCall{
+		Method: method,
+		Params: params,
+		Err:    ErrBatchNotSent,
+	}
+	b.calls = append(b.calls, call)
+	return call
+}
+
+// Send sends all the queued calls in a single request.
+//
+// The returned error only concerns the whole request (e.g. network failure),
+// in that case it is also reported by every call of the batch.
+// Errors of the single calls are returned by their Result methods.
+//
+// Only the failures of the whole request are retried, according to the
+// retry policy of the client.
+func (b *Batch) Send() error {
+	return b.SendContext(context.Background())
+}
+
+// SendContext is like Send but uses the given context for the call.
+func (b *Batch) SendContext(ctx context.Context) error {
+	if len(b.calls) == 0 {
+		return nil
+	}
+
+	calls := b.calls
+	b.calls = nil
+
+	methods := make([]string, 0, len(calls))
+	for _, call := range calls {
+		methods = append(methods, call.Method)
+	}
+
+	err := b.c.retry(ctx, methods, func() error {
+		for _, call := range calls {
+			call.Result, call.Err = nil, ErrBatchNoResponse
+		}
+		return callBatch(ctx, b.c.transport, calls)
+	})
+	if err != nil {
+		for _, call := range calls {
+			call.Err = err
+		}
+		return err
+	}
+
+	for _, call := range calls {
+		call.Err = withMethod(call.Err, call.Method)
+	}
+
+	return nil
+}
+
+// unmarshalCall decodes the result of the call into v.
+func unmarshalCall(call *RPCCall, v interface{}) error {
+	if call.Err != nil {
+		return call.Err
+	}
+	return json.Unmarshal(call.Result, v)
+}
+
+// StringCall is a batched call resulting in a string.
+type StringCall struct{ call *RPCCall }
+
+// Result returns the result of the call.
+func (sc *StringCall) Result() (string, error) {
+	var res string
+	err := unmarshalCall(sc.call, &res)
+	if err != nil {
+		return "", err
+	}
+	return res, nil
+}
+
+// HashCall is a batched call resulting in a Hash.
+type HashCall struct{ call *RPCCall }
+
+// Result returns the result of the call.
+func (hc *HashCall) Result() (Hash, error) {
+	var res Hash
+	err := unmarshalCall(hc.call, &res)
+	if err != nil {
+		return Hash{}, err
+	}
+	return res, nil
+}
+
+// HashesCall is a batched call resulting in an array of hashes.
+type HashesCall struct{ call *RPCCall }
+
+// Result returns the result of the call.
+func (hc *HashesCall) Result() ([]Hash, error) {
+	var res []Hash
+	err := unmarshalCall(hc.call, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Uint64Call is a batched call resulting in an unsigned integer.
+type Uint64Call struct{ call *RPCCall }
+
+// Result returns the result of the call.
+func (uc *Uint64Call) Result() (uint64, error) {
+	var res uint64
+	err := unmarshalCall(uc.call, &res)
+	if err != nil {
+		return 0, err
+	}
+	return res, nil
+}
+
+// Float64Call is a batched call resulting in a floating point number.
+type Float64Call struct{ call *RPCCall }
+
+// Result returns the result of the call.
+func (fc *Float64Call) Result() (float64, error) {
+	var res float64
+	err := unmarshalCall(fc.call, &res)
+	if err != nil {
+		return -1, err
+	}
+	return res, nil
+}
+
+// BoolCall is a batched call resulting in a boolean.
+type BoolCall struct{ call *RPCCall }
+
+// Result returns the result of the call.
+func (bc *BoolCall) Result() (bool, error) {
+	var res bool
+	err := unmarshalCall(bc.call, &res)
+	if err != nil {
+		return false, err
+	}
+	return res, nil
+}
+
+// FullBlockCall is a batched call resulting in a FullBlock.
+type FullBlockCall struct{ call *RPCCall }
+
+// Result returns the result of the call.
+func (fc *FullBlockCall) Result() (*FullBlock, error) {
+	var block FullBlock
+	err := unmarshalCall(fc.call, &block)
+	if err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+// BlockWithTransactionsCall is a batched call resulting in a BlockWithTransactions.
+type BlockWithTransactionsCall struct{ call *RPCCall }
+
+// Result returns the result of the call.
+func (bc *BlockWithTransactionsCall) Result() (*BlockWithTransactions, error) {
+	var block BlockWithTransactions
+	err := unmarshalCall(bc.call, &block)
+	if err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+// FullBlockHeaderCall is a batched call resulting in a FullBlockHeader.
+type FullBlockHeaderCall struct{ call *RPCCall }
+
+// Result returns the result of the call.
+func (fc *FullBlockHeaderCall) Result() (*FullBlockHeader, error) {
+	var header FullBlockHeader
+	err := unmarshalCall(fc.call, &header)
+	if err != nil {
+		return nil, err
+	}
+	return &header, nil
+}
+
+// BlockchainInfoCall is a batched call resulting in a BlockchainInfo.
+type BlockchainInfoCall struct{ call *RPCCall }
+
+// Result returns the result of the call.
+func (bc *BlockchainInfoCall) Result() (*BlockchainInfo, error) {
+	var info BlockchainInfo
+	err := unmarshalCall(bc.call, &info)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// BlockStatsCall is a batched call resulting in a BlockStats.
+type BlockStatsCall struct{ call *RPCCall }
+
+// Result returns the result of the call.
+func (bc *BlockStatsCall) Result() (*BlockStats, error) {
+	var stats BlockStats
+	err := unmarshalCall(bc.call, &stats)
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// ChainTipsCall is a batched call resulting in an array of ChainTip.
+type ChainTipsCall struct{ call *RPCCall }
+
+// Result returns the result of the call.
+func (cc *ChainTipsCall) Result() ([]*ChainTip, error) {
+	var tips []*ChainTip
+	err := unmarshalCall(cc.call, &tips)
+	if err != nil {
+		return nil, err
+	}
+	return tips, nil
+}
+
+// ChainTxStatsCall is a batched call resulting in a ChainTxStats.
+type ChainTxStatsCall struct{ call *RPCCall }
+
+// Result returns the result of the call.
+func (cc *ChainTxStatsCall) Result() (*ChainTxStats, error) {
+	var stats ChainTxStats
+	err := unmarshalCall(cc.call, &stats)
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// MempoolEntryCall is a batched call resulting in a MempoolEntry.
+type MempoolEntryCall struct{ call *RPCCall }
+
+// Result returns the result of the call.
+func (mc *MempoolEntryCall) Result() (*MempoolEntry, error) {
+	var entry MempoolEntry
+	err := unmarshalCall(mc.call, &entry)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// MempoolEntriesCall is a batched call resulting in an array of MempoolEntry.
+type MempoolEntriesCall struct{ call *RPCCall }
+
+// Result returns the result of the call.
+func (mc *MempoolEntriesCall) Result() ([]*MempoolEntry, error) {
+	var entries []*MempoolEntry
+	err := unmarshalCall(mc.call, &entries)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// RawMempoolFullCall is a batched call resulting in a map [transactionID]MempoolEntry.
+type RawMempoolFullCall struct{ call *RPCCall }
+
+// Result returns the result of the call.
+func (rc *RawMempoolFullCall) Result() (map[Hash]*MempoolEntry, error) {
+	var rawpool map[Hash]*MempoolEntry
+	err := unmarshalCall(rc.call, &rawpool)
+	if err != nil {
+		return nil, err
+	}
+	return rawpool, nil
+}
+
+// MempoolInfoCall is a batched call resulting in a MempoolInfo.
+type MempoolInfoCall struct{ call *RPCCall }
+
+// Result returns the result of the call.
+func (mc *MempoolInfoCall) Result() (*MempoolInfo, error) {
+	var info MempoolInfo
+	err := unmarshalCall(mc.call, &info)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// TxOutCall is a batched call resulting in a TxOut.
+type TxOutCall struct{ call *RPCCall }
+
+// Result returns the result of the call.
+func (tc *TxOutCall) Result() (*TxOut, error) {
+	var out TxOut
+	err := unmarshalCall(tc.call, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// TxOutSetInfoCall is a batched call resulting in a TxOutSetInfo.
+type TxOutSetInfoCall struct{ call *RPCCall }
+
+// Result returns the result of the call.
+func (tc *TxOutSetInfoCall) Result() (*TxOutSetInfo, error) {
+	var info TxOutSetInfo
+	err := unmarshalCall(tc.call, &info)
+	if err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// BlockchainBatch queues `blockchain` calls into a batch.
+//
+// Its methods mirror the read only methods of BlockchainClient,
+// refer to them for the documentation of every call.
+type BlockchainBatch struct {
+	b *Batch // The binded batch, must not be nil.
+}
+
+// GetBestBlockHash queues a `getbestblockhash` call.
+func (bib *BlockchainBatch) GetBestBlockHash() *HashCall {
+	return &HashCall{bib.b.queue("getbestblockhash")}
+}
+
+// GetBlock queues a non verbose `getblock` call.
+func (bib *BlockchainBatch) GetBlock(blockHash Hash) *StringCall {
+	return &StringCall{bib.b.queue("getblock", blockHash, false)}
+}
+
+// GetFullBlock queues a verbose `getblock` call.
+func (bib *BlockchainBatch) GetFullBlock(blockHash Hash) *FullBlockCall {
+	return &FullBlockCall{bib.b.queue("getblock", blockHash, true)}
+}
+
+// GetBlockWithTransactions queues a `getblock` call with verbosity 2.
+func (bib *BlockchainBatch) GetBlockWithTransactions(blockHash Hash) *BlockWithTransactionsCall {
+	return &BlockWithTransactionsCall{bib.b.queue("getblock", blockHash, 2)}
+}
+
+// GetBlockchainInfo queues a `getblockchaininfo` call.
+func (bib *BlockchainBatch) GetBlockchainInfo() *BlockchainInfoCall {
+	return &BlockchainInfoCall{bib.b.queue("getblockchaininfo")}
+}
+
+// GetBlockCount queues a `getblockcount` call.
+func (bib *BlockchainBatch) GetBlockCount() *Uint64Call {
+	return &Uint64Call{bib.b.queue("getblockcount")}
+}
+
+// GetBlockHash queues a `getblockhash` call.
+func (bib *BlockchainBatch) GetBlockHash(height uint64) *HashCall {
+	return &HashCall{bib.b.queue("getblockhash", height)}
+}
+
+// GetBlockHeader queues a non verbose `getblockheader` call.
+func (bib *BlockchainBatch) GetBlockHeader(hash Hash) *StringCall {
+	return &StringCall{bib.b.queue("getblockheader", hash, false)}
+}
+
+// GetFullBlockHeader queues a verbose `getblockheader` call.
+func (bib *BlockchainBatch) GetFullBlockHeader(hash Hash) *FullBlockHeaderCall {
+	return &FullBlockHeaderCall{bib.b.queue("getblockheader", hash, true)}
+}
+
+// GetAllBlockStats queues a `getblockstats` call.
+func (bib *BlockchainBatch) GetAllBlockStats(blockHash Hash) *BlockStatsCall {
+	return &BlockStatsCall{bib.b.queue("getblockstats", blockHash)}
+}
+
+// GetChainTips queues a `getchaintips` call.
+func (bib *BlockchainBatch) GetChainTips() *ChainTipsCall {
+	return &ChainTipsCall{bib.b.queue("getchaintips")}
+}
+
+// GetChainTxStats queues a `getchaintxstats` call.
+func (bib *BlockchainBatch) GetChainTxStats(nBlocks uint64, fromHash Hash) *ChainTxStatsCall {
+	params := make([]interface{}, 0, 2)
+	if nBlocks > 0 {
+		params = append(params, nBlocks)
+	}
+	if !fromHash.IsZero() {
+		params = append(params, fromHash)
+	}
+
+	return &ChainTxStatsCall{bib.b.queue("getchaintxstats", params...)}
+}
+
+// GetDifficulty queues a `getdifficulty` call.
+func (bib *BlockchainBatch) GetDifficulty() *Float64Call {
+	return &Float64Call{bib.b.queue("getdifficulty")}
+}
+
+// GetMempoolAncestors queues a non verbose `getmempoolancestors` call.
+func (bib *BlockchainBatch) GetMempoolAncestors(txID Hash) *HashesCall {
+	return &HashesCall{bib.b.queue("getmempoolancestors", txID, false)}
+}
+
+// GetMempoolAncestorsFull queues a verbose `getmempoolancestors` call.
+func (bib *BlockchainBatch) GetMempoolAncestorsFull(txID Hash) *MempoolEntriesCall {
+	return &MempoolEntriesCall{bib.b.queue("getmempoolancestors", txID, true)}
+}
+
+// GetMempoolDescendants queues a non verbose `getmempooldescendants` call.
+func (bib *BlockchainBatch) GetMempoolDescendants(txID Hash) *HashesCall {
+	return &HashesCall{bib.b.queue("getmempooldescendants", txID, false)}
+}
+
+// GetMempoolDescendantsFull queues a verbose `getmempooldescendants` call.
+func (bib *BlockchainBatch) GetMempoolDescendantsFull(txID Hash) *MempoolEntriesCall {
+	return &MempoolEntriesCall{bib.b.queue("getmempooldescendants", txID, true)}
+}
+
+// GetMempoolEntry queues a `getmempoolentry` call.
+func (bib *BlockchainBatch) GetMempoolEntry(txID Hash) *MempoolEntryCall {
+	return &MempoolEntryCall{bib.b.queue("getmempoolentry", txID)}
+}
+
+// GetMempoolInfo queues a `getmempoolinfo` call.
+func (bib *BlockchainBatch) GetMempoolInfo() *MempoolInfoCall {
+	return &MempoolInfoCall{bib.b.queue("getmempoolinfo")}
+}
+
+// GetRawMempool queues a non verbose `getrawmempool` call.
+func (bib *BlockchainBatch) GetRawMempool() *HashesCall {
+	return &HashesCall{bib.b.queue("getrawmempool", false)}
+}
+
+// GetRawMempoolFull queues a verbose `getrawmempool` call.
+func (bib *BlockchainBatch) GetRawMempoolFull() *RawMempoolFullCall {
+	return &RawMempoolFullCall{bib.b.queue("getrawmempool", true)}
+}
+
+// GetTxOut queues a `gettxout` call.
+func (bib *BlockchainBatch) GetTxOut(txID Hash, n uint64, includeMempool bool) *TxOutCall {
+	return &TxOutCall{bib.b.queue("gettxout", txID, n, includeMempool)}
+}
+
+// GetTxOutProof queues a `gettxoutproof` call.
+func (bib *BlockchainBatch) GetTxOutProof(txIDs []Hash) *StringCall {
+	return &StringCall{bib.b.queue("gettxoutproof", txIDs)}
+}
+
+// GetTxOutProofInBlock queues a `gettxoutproof` call with the block hash.
+func (bib *BlockchainBatch) GetTxOutProofInBlock(txIDs []Hash, blockHash Hash) *StringCall {
+	return &StringCall{bib.b.queue("gettxoutproof", txIDs, blockHash)}
+}
+
+// GetTxOutSetInfo queues a `gettxoutsetinfo` call.
+func (bib *BlockchainBatch) GetTxOutSetInfo() *TxOutSetInfoCall {
+	return &TxOutSetInfoCall{bib.b.queue("gettxoutsetinfo")}
+}
+
+// VerifyChain queues a `verifychain` call.
+func (bib *BlockchainBatch) VerifyChain(checkLevel uint64, nBlocks uint64) *BoolCall {
+	return &BoolCall{bib.b.queue("verifychain", checkLevel, nBlocks)}
+}
+
+// VerifyTxOutProof queues a `verifytxoutproof` call.
+func (bib *BlockchainBatch) VerifyTxOutProof(proof string) *HashesCall {
+	return &HashesCall{bib.b.queue("verifytxoutproof", proof)}
+}
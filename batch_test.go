@@ -0,0 +1,81 @@
+package syscoinrpc_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+)
+
+func TestBatchInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "")
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	batch := cl.NewBatch()
+	count := batch.Blockchain.GetBlockCount()
+
+	_, err = count.Result()
+	require.Equal(t, syscoinrpc.ErrBatchNotSent, err, "Must error before sending the batch")
+
+	err = batch.Send()
+	require.Error(t, err, "Must error on any method with invalid URL")
+
+	_, err = count.Result()
+	require.Error(t, err, "Must report the batch error on every call")
+}
+
+func TestBatchOK(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var requests []struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+			ID     string            `json:"id"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&requests), "Must receive a JSON array")
+
+		responses := make([]map[string]interface{}, 0, len(requests))
+		// Answer in reverse order to check matching by ID.
+		for i := len(requests) - 1; i >= 0; i-- {
+			req := requests[i]
+			resp := map[string]interface{}{"id": req.ID, "result": nil, "error": nil}
+			switch req.Method {
+			case "getblockcount":
+				resp["result"] = 42
+			case "getblockhash":
+				resp["result"] = "9f362bce7390fb38dfa0f98c11fb9a5158aeb280f29c8f6cb5ef43d916173bf1"
+			default:
+				resp["error"] = map[string]interface{}{"code": -5, "message": "Block not found"}
+			}
+			responses = append(responses, resp)
+		}
+		json.NewEncoder(w).Encode(responses)
+	}))
+	defer srv.Close()
+
+	cl, err := syscoinrpc.NewClient(srv.URL, "", "")
+	require.NoError(t, err, "Must have no error on creation")
+
+	batch := cl.NewBatch()
+	count := batch.Blockchain.GetBlockCount()
+	hash := batch.Blockchain.GetBlockHash(1)
+	block := batch.Blockchain.GetFullBlock(syscoinrpc.Hash{})
+	require.Equal(t, 3, batch.Len(), "Must queue all the calls")
+
+	err = batch.Send()
+	require.NoError(t, err, "Send: must not error")
+	require.Equal(t, 0, batch.Len(), "Must empty the queue after sending")
+
+	n, err := count.Result()
+	require.NoError(t, err, "GetBlockCount: must not error")
+	require.Equal(t, uint64(42), n)
+
+	h, err := hash.Result()
+	require.NoError(t, err, "GetBlockHash: must not error")
+	require.Equal(t, syscoinrpc.MustParseHash("9f362bce7390fb38dfa0f98c11fb9a5158aeb280f29c8f6cb5ef43d916173bf1"), h)
+
+	_, err = block.Result()
+	require.EqualError(t, err, "Block not found", "GetFullBlock: must report its own error")
+}
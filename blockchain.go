@@ -1,6 +1,7 @@
 package syscoinrpc
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
 )
@@ -10,21 +11,26 @@ type BlockchainClient struct {
 	c *Client // The binded client, must not be nil.
 }
 
-func (bic *BlockchainClient) do(method string, params ...interface{}) (json.RawMessage, error) {
-	return bic.c.do(method, params...)
+func (bic *BlockchainClient) do(ctx context.Context, method string, params ...interface{}) (json.RawMessage, error) {
+	return bic.c.do(ctx, method, params...)
 }
 
 // GetBestBlockHash returns the hash of the best (tip) block in
 // the longest blockchain.
-func (bic *BlockchainClient) GetBestBlockHash() (string, error) {
-	res, err := bic.do("getbestblockhash")
+func (bic *BlockchainClient) GetBestBlockHash() (Hash, error) {
+	return bic.GetBestBlockHashContext(context.Background())
+}
+
+// GetBestBlockHashContext is like GetBestBlockHash but uses the given context for the call.
+func (bic *BlockchainClient) GetBestBlockHashContext(ctx context.Context) (Hash, error) {
+	res, err := bic.do(ctx, "getbestblockhash")
 	if err != nil {
-		return "", err
+		return Hash{}, err
 	}
-	var hash string
+	var hash Hash
 	err = json.Unmarshal(res, &hash)
 	if err != nil {
-		return "", err
+		return Hash{}, err
 	}
 
 	return hash, nil
@@ -32,56 +38,27 @@ func (bic *BlockchainClient) GetBestBlockHash() (string, error) {
 
 // AuxPow is the Auxiliary Proof of work data binded to the block.
 // It contains data like coinbase block reward transaction.
-// TODO: Document it better.
+//
+// A merge mined block is proven by the work of a parent block (e.g. a Bitcoin
+// block), whose coinbase commits to the root of a merkle tree of the merge
+// mined chains, containing the hash of the block.
 type AuxPow struct {
-	// Tx is the block reward transaction.
-	Tx AuxPowTx `json:"tx,required"`
-	// Index is the Transaction index in the block.
+	// Tx is the coinbase transaction of the parent block,
+	// its BlockHash is the hash of the parent block.
+	Tx Transaction `json:"tx,required"`
+	// Index is the Transaction index in the block, always 0 for the coinbase.
 	Index uint64 `json:"index,required"`
-	// ChainIndex is the index of the transaction in the chain.
+	// ChainIndex is the index of the block hash in the merge mined chains merkle tree.
 	ChainIndex uint64 `json:"chainindex,required"`
-	// TODO: Discover and document this type.
-	MerkleBranch []string `json:"merklebranch,required"`
-	// TODO: Discover and document this type.
-	ChainMerkleBranch []string `json:"chainmerklebranch,required"`
-	// TODO: Document this variable.
+	// MerkleBranch is the merkle branch linking Tx to the merkle root of the parent block.
+	MerkleBranch []Hash `json:"merklebranch,required"`
+	// ChainMerkleBranch is the merkle branch linking the block hash to
+	// the merge mined chains merkle root committed in Tx.
+	ChainMerkleBranch []Hash `json:"chainmerklebranch,required"`
+	// ParentBlock is the serialized header of the parent block, in hex.
 	ParentBlock string `json:"parentblock,required"`
 }
 
-// AuxPowTx represents a block reward transaction.
-type AuxPowTx struct {
-	// Hex is the Hex representation of the Tx.
-	Hex string `json:"hex,required"`
-	// TxID is the transaction ID.
-	TxID string `json:"txid,required"`
-	// Size is the transaction size.
-	Size uint64 `json:"size,required"`
-	// Version is the transaction version.
-	Version uint64 `json:"version,required"`
-	// LockTime is the time (expressed as UNIX Timestamp)
-	// when the transaction has been locked.
-	LockTime uint64 `json:"locktime,required"`
-	// Vin is the array of transaction vin objects.
-	Vin []VinObject `json:"vin,required"`
-	// Vout is the array of transaction vout objects.
-	Vout []VoutObject `json:"vout,required"`
-	// BlockHash is the block hash.
-	BlockHash string `json:"blockhash,required"`
-}
-
-// VinObject represents a vin (value input) object of a transaction.
-type VinObject struct {
-	// Coinbase is the coinbase.
-	Coinbase string `json:"coinbase,required"`
-	// Sequence is the sequence number of the vin object.
-	Sequence uint64 `json:"sequence,required"`
-}
-
-// VoutObject represents a vout (value output) object of a transaction.
-//     TODO: Document it better.
-type VoutObject struct {
-}
-
 // FullBlock represents full data of a block.
 //
 // It is a result from `getblock` verbose call.
@@ -90,7 +67,7 @@ type FullBlock struct {
 	// Size is the block size.
 	Size uint64 `json:"size,required"`
 	// Tx is the array of transaction IDs.
-	Tx []string `json:"tx,required"`
+	Tx []Hash `json:"tx,required"`
 	// AuxPow is the Auxiliary Proof of work data binded to the block.
 	// It contains data like coinbase block reward transaction.
 	// TODO: Document it better.
@@ -98,18 +75,35 @@ type FullBlock struct {
 }
 
 // GetBlock returns a string that is serialized, hex-encoded data for block 'hash'.
-func (bic *BlockchainClient) GetBlock(blockHash string) (string, error) {
-	response, err := bic.do("getblock", blockHash, false)
+// It can be decoded locally with DecodeBlockHex.
+func (bic *BlockchainClient) GetBlock(blockHash Hash) (string, error) {
+	return bic.GetBlockContext(context.Background(), blockHash)
+}
+
+// GetBlockContext is like GetBlock but uses the given context for the call.
+func (bic *BlockchainClient) GetBlockContext(ctx context.Context, blockHash Hash) (string, error) {
+	response, err := bic.do(ctx, "getblock", blockHash, false)
 	if err != nil {
 		return "", err
 	}
 
-	return string(response), nil
+	var block string
+	err = json.Unmarshal(response, &block)
+	if err != nil {
+		return "", err
+	}
+
+	return block, nil
 }
 
 // GetFullBlock returns an Object with information about block <hash>.
-func (bic *BlockchainClient) GetFullBlock(blockHash string) (*FullBlock, error) {
-	response, err := bic.do("getblock", blockHash, true)
+func (bic *BlockchainClient) GetFullBlock(blockHash Hash) (*FullBlock, error) {
+	return bic.GetFullBlockContext(context.Background(), blockHash)
+}
+
+// GetFullBlockContext is like GetFullBlock but uses the given context for the call.
+func (bic *BlockchainClient) GetFullBlockContext(ctx context.Context, blockHash Hash) (*FullBlock, error) {
+	response, err := bic.do(ctx, "getblock", blockHash, true)
 	if err != nil {
 		return nil, err
 	}
@@ -123,6 +117,41 @@ func (bic *BlockchainClient) GetFullBlock(blockHash string) (*FullBlock, error)
 	return &block, nil
 }
 
+// BlockWithTransactions represents full data of a block, with its transactions fully decoded.
+//
+// It is a result from `getblock` call with verbosity 2.
+type BlockWithTransactions struct {
+	*FullBlockHeader
+	// Size is the block size.
+	Size uint64 `json:"size,required"`
+	// Tx is the array of the decoded transactions.
+	Tx []*Transaction `json:"tx,required"`
+	// AuxPow is the Auxiliary Proof of work data binded to the block.
+	AuxPow AuxPow `json:"auxpow,required"`
+}
+
+// GetBlockWithTransactions returns an Object with information about block <hash>,
+// including every transaction fully decoded.
+func (bic *BlockchainClient) GetBlockWithTransactions(blockHash Hash) (*BlockWithTransactions, error) {
+	return bic.GetBlockWithTransactionsContext(context.Background(), blockHash)
+}
+
+// GetBlockWithTransactionsContext is like GetBlockWithTransactions but uses the given context for the call.
+func (bic *BlockchainClient) GetBlockWithTransactionsContext(ctx context.Context, blockHash Hash) (*BlockWithTransactions, error) {
+	response, err := bic.do(ctx, "getblock", blockHash, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	var block BlockWithTransactions
+	err = json.Unmarshal(response, &block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &block, nil
+}
+
 // BlockchainInfo represents the response of a `getblockchaininfo` call.
 type BlockchainInfo struct {
 	// Chain is the chain name.
@@ -132,7 +161,7 @@ type BlockchainInfo struct {
 	// Headers is the current number of headers that have been validated by the node.
 	Headers uint64 `json:"headers,required"`
 	// BestBlockHash is the hash of the currently best block.
-	BestBlockHash string `json:"bestblockhash,required"`
+	BestBlockHash Hash `json:"bestblockhash,required"`
 	// CurrentDifficulty is the current difficulty
 	CurrentDifficulty float64 `json:"difficulty,required"`
 	// MedianTime is the median time for the current best block.
@@ -190,7 +219,12 @@ type BIP9Softfork struct {
 
 // GetBlockchainInfo returns an object containing various state info regarding blockchain processing.
 func (bic *BlockchainClient) GetBlockchainInfo() (*BlockchainInfo, error) {
-	response, err := bic.do("getblockchaininfo")
+	return bic.GetBlockchainInfoContext(context.Background())
+}
+
+// GetBlockchainInfoContext is like GetBlockchainInfo but uses the given context for the call.
+func (bic *BlockchainClient) GetBlockchainInfoContext(ctx context.Context) (*BlockchainInfo, error) {
+	response, err := bic.do(ctx, "getblockchaininfo")
 	if err != nil {
 		return nil, err
 	}
@@ -207,7 +241,12 @@ func (bic *BlockchainClient) GetBlockchainInfo() (*BlockchainInfo, error) {
 // GetBlockCount returns the number of blocks in the longest blockchain.
 //     Returns 0 on error, with the error.
 func (bic *BlockchainClient) GetBlockCount() (uint64, error) {
-	response, err := bic.do("getblockcount")
+	return bic.GetBlockCountContext(context.Background())
+}
+
+// GetBlockCountContext is like GetBlockCount but uses the given context for the call.
+func (bic *BlockchainClient) GetBlockCountContext(ctx context.Context) (uint64, error) {
+	response, err := bic.do(ctx, "getblockcount")
 	if err != nil {
 		return 0, err
 	}
@@ -221,13 +260,24 @@ func (bic *BlockchainClient) GetBlockCount() (uint64, error) {
 }
 
 // GetBlockHash returns the hash of the block at the given height.
-func (bic *BlockchainClient) GetBlockHash(height uint64) (string, error) {
-	response, err := bic.do("getblockhash", height)
+func (bic *BlockchainClient) GetBlockHash(height uint64) (Hash, error) {
+	return bic.GetBlockHashContext(context.Background(), height)
+}
+
+// GetBlockHashContext is like GetBlockHash but uses the given context for the call.
+func (bic *BlockchainClient) GetBlockHashContext(ctx context.Context, height uint64) (Hash, error) {
+	response, err := bic.do(ctx, "getblockhash", height)
 	if err != nil {
-		return "", err
+		return Hash{}, err
+	}
+
+	var hash Hash
+	err = json.Unmarshal(response, &hash)
+	if err != nil {
+		return Hash{}, err
 	}
 
-	return string(response), nil
+	return hash, nil
 }
 
 // FullBlockHeader represents a full block header,
@@ -235,7 +285,7 @@ func (bic *BlockchainClient) GetBlockHash(height uint64) (string, error) {
 // `getblockheaders` call.
 type FullBlockHeader struct {
 	// Hash is the block hash (same as provided)
-	Hash string
+	Hash Hash `json:"hash,required"`
 	// Confirmations is the number of confirmations, or -1 if the block is not on the chain.
 	Confirmations int `json:"confirmations,required"`
 	// Height is the block height or index.
@@ -245,7 +295,7 @@ type FullBlockHeader struct {
 	// VersionHex is the block version formatted in hexadecimal.
 	VersionHex string `json:"versionHex,required"`
 	// MerkleRoot is the merkle root.
-	MerkleRoot string `json:"merkleroot,required"`
+	MerkleRoot Hash `json:"merkleroot,required"`
 	// Time is the block time in seconds since epoch (Jan 1 1970 GMT).
 	Time uint64 `json:"time,required"`
 	// MedianTime is the median block time in seconds since epoch (Jan 1 1970 GMT).
@@ -260,25 +310,42 @@ type FullBlockHeader struct {
 	// ChainWork is the expected number of hashes required to
 	// produce the chain up to this block (in hex).
 	ChainWork string `json:"chainwork,required"`
-	// PreviousBlockHash is the hash of the previous block.
-	PreviousBlockHash string `json:"previousblockhash,required"`
-	// NextBlockHash is the hash of the next block.
-	NextBlockHash string `json:"nextblockhash,required"`
+	// PreviousBlockHash is the hash of the previous block, zero for the genesis block.
+	PreviousBlockHash Hash `json:"previousblockhash,required"`
+	// NextBlockHash is the hash of the next block, zero for the tip.
+	NextBlockHash Hash `json:"nextblockhash,required"`
 }
 
 // GetBlockHeader returns a string that is serialized, hex-encoded data for block header 'hash'.
-func (bic *BlockchainClient) GetBlockHeader(hash string) (string, error) {
-	response, err := bic.do("getblockheader", hash, false)
+// It can be decoded locally with DecodeBlockHeaderHex.
+func (bic *BlockchainClient) GetBlockHeader(hash Hash) (string, error) {
+	return bic.GetBlockHeaderContext(context.Background(), hash)
+}
+
+// GetBlockHeaderContext is like GetBlockHeader but uses the given context for the call.
+func (bic *BlockchainClient) GetBlockHeaderContext(ctx context.Context, hash Hash) (string, error) {
+	response, err := bic.do(ctx, "getblockheader", hash, false)
 	if err != nil {
 		return "", err
 	}
 
-	return string(response), nil
+	var header string
+	err = json.Unmarshal(response, &header)
+	if err != nil {
+		return "", err
+	}
+
+	return header, nil
 }
 
 // GetFullBlockHeader returns an Object with information about block header <hash>.
-func (bic *BlockchainClient) GetFullBlockHeader(hash string) (*FullBlockHeader, error) {
-	response, err := bic.do("getblockheader", hash, true)
+func (bic *BlockchainClient) GetFullBlockHeader(hash Hash) (*FullBlockHeader, error) {
+	return bic.GetFullBlockHeaderContext(context.Background(), hash)
+}
+
+// GetFullBlockHeaderContext is like GetFullBlockHeader but uses the given context for the call.
+func (bic *BlockchainClient) GetFullBlockHeaderContext(ctx context.Context, hash Hash) (*FullBlockHeader, error) {
+	response, err := bic.do(ctx, "getblockheader", hash, true)
 	if err != nil {
 		return nil, err
 	}
@@ -295,13 +362,13 @@ func (bic *BlockchainClient) GetFullBlockHeader(hash string) (*FullBlockHeader,
 // BlockStats represents the statistics of a block.
 type BlockStats struct {
 	// AvgFee is the average fee in the block.
-	AvgFee uint64 `json:"avgfee,required"`
+	AvgFee SatoshiAmount `json:"avgfee,required"`
 	// AvgFeeRate is the average feerate (in satoshis per virtual byte)
 	AvgFeeRate uint64 `json:"avgfeerate,required"`
 	// AvgTxSize is the average transaction size.
 	AvgTxSize uint64 `json:"avgtxsize,required"`
 	// Blockhash is the block hash (to check for potential reorgs)
-	BlockHash string `json:"blockhash,required"`
+	BlockHash Hash `json:"blockhash,required"`
 	// FeeRatePercentiles is the array of feerates at the 10th, 25th,
 	// 50th, 75th, and 90th percentile weight unit (in satoshis per
 	// virtual byte)
@@ -311,19 +378,19 @@ type BlockStats struct {
 	// InputsCount is the number of inputs (excluding coinbase)
 	InputsCount uint64 `json:"ins,required"`
 	// MaxFee is the maximum fee in the block.
-	MaxFee uint64 `json:"maxfee,required"`
+	MaxFee SatoshiAmount `json:"maxfee,required"`
 	// MaxFeeRate is the maximum feerate (in satoshis per virtual byte)
 	MaxFeeRate uint64 `json:"maxfeerate,required"`
 	// MaxTxSize is the maximum transaction size.
 	MaxTxSize uint64 `json:"maxtxsize,required"`
 	// MedianFee is the truncated median fee in the block.
-	MedianFee float32 `json:"medianfee,required"`
+	MedianFee SatoshiAmount `json:"medianfee,required"`
 	// MedianTime is the block median time past.
 	MedianTime uint64 `json:"mediantime,required"`
 	// MedianTxSize is the truncated median transaction size
 	MedianTxSize uint64 `json:"mediantxsize,required"`
 	// MinFee is the minimum fee in the block.
-	MinFee uint64 `json:"minfee,required"`
+	MinFee SatoshiAmount `json:"minfee,required"`
 	// MinFeeRate is the minimum feerate (in satoshis per virtual byte)
 	MinFeeRate uint64 `json:"minfeerate,required"`
 	// MinTxSize is the minimum transaction size.
@@ -331,7 +398,7 @@ type BlockStats struct {
 	// OutputsCount is the number of outputs (excluding coinbase)
 	OutputsCount uint64 `json:"outs,required"`
 	// Subsidy is the block subsidy.
-	Subsidy uint64 `json:"subsidy,required"`
+	Subsidy SatoshiAmount `json:"subsidy,required"`
 	// SegwitTotalSize is the total size of all segwit transactions.
 	SegwitTotalSize uint64 `json:"swtotal_size,required"`
 	// SegwitTotalWeight is the total weight of all segwit transactions
@@ -343,14 +410,14 @@ type BlockStats struct {
 	Time uint64 `json:"time,required"`
 	// TotalOutputAmount is the total amount in all outputs (excluding
 	// coinbase and thus reward [ie subsidy + totalfee]).
-	TotalOutputAmount uint64 `json:"total_out,required"`
+	TotalOutputAmount SatoshiAmount `json:"total_out,required"`
 	// TotalSize is the total size of all non-coinbase transactions.
 	TotalSize uint64 `json:"total_size,required"`
 	// TotalWeight is the total weight of all non-coinbase transactions
 	// divided by segwit scale factor (4).
 	TotalWeight uint64 `json:"total_weight,required"`
 	// TotalFee is the fee total amount.
-	TotalFee uint64 `json:"totalfee,required"`
+	TotalFee SatoshiAmount `json:"totalfee,required"`
 	// TransactionsCount is the number of transactions (excluding coinbase).
 	TransactionsCount uint64 `json:"txs,required"`
 	// UTXOIncrease is the increase/decrease in the number of unspent outputs.
@@ -366,8 +433,13 @@ type BlockStats struct {
 // It won't work without -txindex for utxo_size_inc, *fee or *feerate stats.
 //
 //     blockHash : The hash of the block to get stats from.
-func (bic *BlockchainClient) GetAllBlockStats(blockHash string) (*BlockStats, error) {
-	response, err := bic.do("getblockstats", blockHash)
+func (bic *BlockchainClient) GetAllBlockStats(blockHash Hash) (*BlockStats, error) {
+	return bic.GetAllBlockStatsContext(context.Background(), blockHash)
+}
+
+// GetAllBlockStatsContext is like GetAllBlockStats but uses the given context for the call.
+func (bic *BlockchainClient) GetAllBlockStatsContext(ctx context.Context, blockHash Hash) (*BlockStats, error) {
+	response, err := bic.do(ctx, "getblockstats", blockHash)
 	if err != nil {
 		return nil, err
 	}
@@ -386,7 +458,7 @@ type ChainTip struct {
 	// Height is the height of the chain tip.
 	Height uint64 `json:"height,required"`
 	// Hash is the block hash of the tip.
-	Hash string `json:"hash,required"`
+	Hash Hash `json:"hash,required"`
 	// BranchLen is the length of the branch of the chain (0 for main chain).
 	BranchLen uint64 `json:"branchlen,required"`
 	// Status is the status of the chain of the tip ("active" for the main chain).
@@ -403,7 +475,12 @@ type ChainTip struct {
 // GetChainTips returns information about all known tips in the block tree,
 // including the main chain as well as orphaned branches.
 func (bic *BlockchainClient) GetChainTips() ([]*ChainTip, error) {
-	response, err := bic.do("getchaintips")
+	return bic.GetChainTipsContext(context.Background())
+}
+
+// GetChainTipsContext is like GetChainTips but uses the given context for the call.
+func (bic *BlockchainClient) GetChainTipsContext(ctx context.Context) ([]*ChainTip, error) {
+	response, err := bic.do(ctx, "getchaintips")
 	if err != nil {
 		return nil, err
 	}
@@ -426,7 +503,7 @@ type ChainTxStats struct {
 	// that point.
 	TransactionCount uint64 `json:"txcount,required"`
 	// WindowFinalBlockhash is the hash of the final block in the window.
-	WindowFinalBlockhash string `json:"window_final_block_hash,required"`
+	WindowFinalBlockhash Hash `json:"window_final_block_hash,required"`
 	// WindowBlockCount is the size of the window in number of blocks.
 	WindowBlockCount uint64 `json:"window_block_count,required"`
 	// WindowTransactionCount is the number of transactions in the window.
@@ -444,17 +521,22 @@ type ChainTxStats struct {
 // rate of transactions in the chain.
 //
 //     nBlocks  : size of the window in number of blocks (default: 0=one month)
-//     fromHash : the hash of the block that ends the window.
-func (bic *BlockchainClient) GetChainTxStats(nBlocks uint64, fromHash string) (*ChainTxStats, error) {
+//     fromHash : the hash of the block that ends the window (default: zero=the tip).
+func (bic *BlockchainClient) GetChainTxStats(nBlocks uint64, fromHash Hash) (*ChainTxStats, error) {
+	return bic.GetChainTxStatsContext(context.Background(), nBlocks, fromHash)
+}
+
+// GetChainTxStatsContext is like GetChainTxStats but uses the given context for the call.
+func (bic *BlockchainClient) GetChainTxStatsContext(ctx context.Context, nBlocks uint64, fromHash Hash) (*ChainTxStats, error) {
 	params := make([]interface{}, 0, 2)
 	if nBlocks > 0 {
 		params = append(params, nBlocks)
 	}
-	if fromHash != "" {
+	if !fromHash.IsZero() {
 		params = append(params, fromHash)
 	}
 
-	response, err := bic.do("getchaintxstats", params...)
+	response, err := bic.do(ctx, "getchaintxstats", params...)
 	if err != nil {
 		return nil, err
 	}
@@ -470,7 +552,12 @@ func (bic *BlockchainClient) GetChainTxStats(nBlocks uint64, fromHash string) (*
 
 // GetDifficulty returns the current difficulty.
 func (bic *BlockchainClient) GetDifficulty() (float64, error) {
-	response, err := bic.do("getdifficulty")
+	return bic.GetDifficultyContext(context.Background())
+}
+
+// GetDifficultyContext is like GetDifficulty but uses the given context for the call.
+func (bic *BlockchainClient) GetDifficultyContext(ctx context.Context) (float64, error) {
+	response, err := bic.do(ctx, "getdifficulty")
 	if err != nil {
 		return -1, err
 	}
@@ -487,10 +574,10 @@ func (bic *BlockchainClient) GetDifficulty() (float64, error) {
 type MempoolEntry struct {
 	// Size is the transaction size in bytes.
 	Size uint64 `json:"size,required"`
-	// Fee is the transaction fee in SYS.
-	Fee float64 `json:"fee,required"`
+	// Fee is the transaction fee.
+	Fee Amount `json:"fee,required"`
 	// ModifiedFee is the transaction fee with fee deltas used for mining priority.
-	ModifiedFee float64 `json:"modifiedfee,required"`
+	ModifiedFee Amount `json:"modifiedfee,required"`
 	// Time is the local time transaction entered pool in seconds since 1 Jan 1970 GMT.
 	Time uint64 `json:"time,required"`
 	// Height is the block height when the transaction entered the pool.
@@ -504,15 +591,15 @@ type MempoolEntry struct {
 	// DescendantSize is the size of in-mempool descendants (including this one).
 	DescendantSize uint64 `json:"descendantsize,required"`
 	// DescendantFees is the modified fees (see above) of in-mempool descendants (including this one).
-	DescendantFees float64 `json:"descendantfees,required"`
+	DescendantFees SatoshiAmount `json:"descendantfees,required"`
 	// DescendantCount is the number of in-mempool descendant transactions (including this one).
 	AncestorCount uint64 `json:"ancestorcount,required"`
 	// AncestorSize is the size of in-mempool ancestors (including this one).
 	AncestorSize uint64 `json:"ancestorsize,required"`
 	// AncestorFees is the modified fees (see above) of in-mempool ancestors (including this one).
-	AncestorFees float64 `json:"ancestorfees,required"`
+	AncestorFees SatoshiAmount `json:"ancestorfees,required"`
 	// DependingTransactions is the array of unconfirmed transactions used as inputs for this transaction
-	DependingTransactions []string `json:"depends,required"`
+	DependingTransactions []Hash `json:"depends,required"`
 	// InstantSend is true if this transaction was sent as an InstantSend one.
 	InstantSend bool `json:"instantsend,required"`
 	// InstantLock is true if this transaction was locked via InstantSend.
@@ -521,13 +608,18 @@ type MempoolEntry struct {
 
 // GetMempoolAncestors If txid is in the mempool, returns all in-mempool ancestors summarized data.
 //     txID : The transaction id (must be in mempool)
-func (bic *BlockchainClient) GetMempoolAncestors(txID string) ([]string, error) {
-	response, err := bic.do("getmempoolancestors", txID, false)
+func (bic *BlockchainClient) GetMempoolAncestors(txID Hash) ([]Hash, error) {
+	return bic.GetMempoolAncestorsContext(context.Background(), txID)
+}
+
+// GetMempoolAncestorsContext is like GetMempoolAncestors but uses the given context for the call.
+func (bic *BlockchainClient) GetMempoolAncestorsContext(ctx context.Context, txID Hash) ([]Hash, error) {
+	response, err := bic.do(ctx, "getmempoolancestors", txID, false)
 	if err != nil {
 		return nil, err
 	}
 
-	var ancestors []string
+	var ancestors []Hash
 	err = json.Unmarshal(response, &ancestors)
 	if err != nil {
 		return nil, err
@@ -538,8 +630,13 @@ func (bic *BlockchainClient) GetMempoolAncestors(txID string) ([]string, error)
 
 // GetMempoolAncestorsFull If txid is in the mempool, returns all in-mempool ancestors full data.
 //     txID : The transaction id (must be in mempool)
-func (bic *BlockchainClient) GetMempoolAncestorsFull(txID string) ([]*MempoolEntry, error) {
-	response, err := bic.do("getmempoolancestors", txID, true)
+func (bic *BlockchainClient) GetMempoolAncestorsFull(txID Hash) ([]*MempoolEntry, error) {
+	return bic.GetMempoolAncestorsFullContext(context.Background(), txID)
+}
+
+// GetMempoolAncestorsFullContext is like GetMempoolAncestorsFull but uses the given context for the call.
+func (bic *BlockchainClient) GetMempoolAncestorsFullContext(ctx context.Context, txID Hash) ([]*MempoolEntry, error) {
+	response, err := bic.do(ctx, "getmempoolancestors", txID, true)
 	if err != nil {
 		return nil, err
 	}
@@ -555,13 +652,18 @@ func (bic *BlockchainClient) GetMempoolAncestorsFull(txID string) ([]*MempoolEnt
 
 // GetMempoolDescendants If txid is in the mempool, returns all in-mempool Descendants summarized data.
 //     txID : The transaction id (must be in mempool)
-func (bic *BlockchainClient) GetMempoolDescendants(txID string) ([]string, error) {
-	response, err := bic.do("getmempooldescendants", txID, false)
+func (bic *BlockchainClient) GetMempoolDescendants(txID Hash) ([]Hash, error) {
+	return bic.GetMempoolDescendantsContext(context.Background(), txID)
+}
+
+// GetMempoolDescendantsContext is like GetMempoolDescendants but uses the given context for the call.
+func (bic *BlockchainClient) GetMempoolDescendantsContext(ctx context.Context, txID Hash) ([]Hash, error) {
+	response, err := bic.do(ctx, "getmempooldescendants", txID, false)
 	if err != nil {
 		return nil, err
 	}
 
-	var descendants []string
+	var descendants []Hash
 	err = json.Unmarshal(response, &descendants)
 	if err != nil {
 		return nil, err
@@ -572,8 +674,13 @@ func (bic *BlockchainClient) GetMempoolDescendants(txID string) ([]string, error
 
 // GetMempoolDescendantsFull If txid is in the mempool, returns all in-mempool Descendants full data.
 //     txID : The transaction id (must be in mempool)
-func (bic *BlockchainClient) GetMempoolDescendantsFull(txID string) ([]*MempoolEntry, error) {
-	response, err := bic.do("getmempooldescendants", txID, true)
+func (bic *BlockchainClient) GetMempoolDescendantsFull(txID Hash) ([]*MempoolEntry, error) {
+	return bic.GetMempoolDescendantsFullContext(context.Background(), txID)
+}
+
+// GetMempoolDescendantsFullContext is like GetMempoolDescendantsFull but uses the given context for the call.
+func (bic *BlockchainClient) GetMempoolDescendantsFullContext(ctx context.Context, txID Hash) ([]*MempoolEntry, error) {
+	response, err := bic.do(ctx, "getmempooldescendants", txID, true)
 	if err != nil {
 		return nil, err
 	}
@@ -589,8 +696,13 @@ func (bic *BlockchainClient) GetMempoolDescendantsFull(txID string) ([]*MempoolE
 
 // GetMempoolEntry returns full mempool data for given transaction.
 //     txID : The transaction id (must be in mempool).
-func (bic *BlockchainClient) GetMempoolEntry(txID string) (*MempoolEntry, error) {
-	response, err := bic.do("getmempoolentry", txID)
+func (bic *BlockchainClient) GetMempoolEntry(txID Hash) (*MempoolEntry, error) {
+	return bic.GetMempoolEntryContext(context.Background(), txID)
+}
+
+// GetMempoolEntryContext is like GetMempoolEntry but uses the given context for the call.
+func (bic *BlockchainClient) GetMempoolEntryContext(ctx context.Context, txID Hash) (*MempoolEntry, error) {
+	response, err := bic.do(ctx, "getmempoolentry", txID)
 	if err != nil {
 		return nil, err
 	}
@@ -615,12 +727,17 @@ type MempoolInfo struct {
 	// MaxMempool is the maximum memory usage for the mempool.
 	MaxMempool uint64 `json:"maxmempool,required"`
 	// MempoolMinFee is the minimum fee for tx to be accepted
-	MempoolMinFee float64 `json:"mempoolminfee,required"`
+	MempoolMinFee Amount `json:"mempoolminfee,required"`
 }
 
 // GetMempoolInfo returns details on the active state of the TX memory pool.
 func (bic *BlockchainClient) GetMempoolInfo() (*MempoolInfo, error) {
-	response, err := bic.do("getmempoolinfo")
+	return bic.GetMempoolInfoContext(context.Background())
+}
+
+// GetMempoolInfoContext is like GetMempoolInfo but uses the given context for the call.
+func (bic *BlockchainClient) GetMempoolInfoContext(ctx context.Context) (*MempoolInfo, error) {
+	response, err := bic.do(ctx, "getmempoolinfo")
 	if err != nil {
 		return nil, err
 	}
@@ -637,13 +754,18 @@ func (bic *BlockchainClient) GetMempoolInfo() (*MempoolInfo, error) {
 // GetRawMempool returns all transaction ids in memory pool as array of string transaction ids.
 //
 //     HINT: use `getmempoolentry` to fetch a specific transaction from the mempool.
-func (bic *BlockchainClient) GetRawMempool() ([]string, error) {
-	response, err := bic.do("getrawmempool", false)
+func (bic *BlockchainClient) GetRawMempool() ([]Hash, error) {
+	return bic.GetRawMempoolContext(context.Background())
+}
+
+// GetRawMempoolContext is like GetRawMempool but uses the given context for the call.
+func (bic *BlockchainClient) GetRawMempoolContext(ctx context.Context) ([]Hash, error) {
+	response, err := bic.do(ctx, "getrawmempool", false)
 	if err != nil {
 		return nil, err
 	}
 
-	var rawpool []string
+	var rawpool []Hash
 	err = json.Unmarshal(response, &rawpool)
 	if err != nil {
 		return nil, err
@@ -657,13 +779,18 @@ func (bic *BlockchainClient) GetRawMempool() ([]string, error) {
 //     HINT: use `getmempoolentry` to fetch a specific transaction from the mempool.
 //
 // Response type is a map [transactionID]MempoolEntry object.
-func (bic *BlockchainClient) GetRawMempoolFull() (map[string]*MempoolEntry, error) {
-	response, err := bic.do("getrawmempool", true)
+func (bic *BlockchainClient) GetRawMempoolFull() (map[Hash]*MempoolEntry, error) {
+	return bic.GetRawMempoolFullContext(context.Background())
+}
+
+// GetRawMempoolFullContext is like GetRawMempoolFull but uses the given context for the call.
+func (bic *BlockchainClient) GetRawMempoolFullContext(ctx context.Context) (map[Hash]*MempoolEntry, error) {
+	response, err := bic.do(ctx, "getrawmempool", true)
 	if err != nil {
 		return nil, err
 	}
 
-	var rawpool map[string]*MempoolEntry
+	var rawpool map[Hash]*MempoolEntry
 	err = json.Unmarshal(response, &rawpool)
 	if err != nil {
 		return nil, err
@@ -675,11 +802,11 @@ func (bic *BlockchainClient) GetRawMempoolFull() (map[string]*MempoolEntry, erro
 // TxOut represents a Transaction Output.
 type TxOut struct {
 	// BestBlock is the best block hash.
-	BestBlock string `json:"bestblock,required"`
+	BestBlock Hash `json:"bestblock,required"`
 	// Confirmations is the number of confirmations.
 	Confirmations uint64 `json:"confirmations,required"`
-	// Value is the transaction value in SYS.
-	Value float64 `json:"value,required"`
+	// Value is the transaction value.
+	Value Amount `json:"value,required"`
 	// ScriptPubKey is the PubKey script in the output.
 	ScriptPubKey ScriptPubKey `json:"scriptPubKey,required"`
 	// Version is the Output version.
@@ -693,7 +820,7 @@ type ScriptPubKey struct {
 	// Asm is the ASM code of the PubKey script.
 	Asm string `json:"asm,required"`
 	// Hex is the Hex of the PubKey script.
-	Hex string `json:"code,required"`
+	Hex string `json:"hex,required"`
 	// RequiredSignatures is the number of required signatures.
 	RequiredSignatures uint64 `json:"reqSigs,required"`
 	// Type is the type of the PubKey script (e.g. pubkeyhash).
@@ -703,8 +830,13 @@ type ScriptPubKey struct {
 }
 
 // GetTxOut returns details about an unspent transaction output.
-func (bic *BlockchainClient) GetTxOut(txID string, n uint64, includeMempool bool) (*TxOut, error) {
-	response, err := bic.do("gettxout", txID, n, includeMempool)
+func (bic *BlockchainClient) GetTxOut(txID Hash, n uint64, includeMempool bool) (*TxOut, error) {
+	return bic.GetTxOutContext(context.Background(), txID, n, includeMempool)
+}
+
+// GetTxOutContext is like GetTxOut but uses the given context for the call.
+func (bic *BlockchainClient) GetTxOutContext(ctx context.Context, txID Hash, n uint64, includeMempool bool) (*TxOut, error) {
+	response, err := bic.do(ctx, "gettxout", txID, n, includeMempool)
 	if err != nil {
 		return nil, err
 	}
@@ -719,6 +851,7 @@ func (bic *BlockchainClient) GetTxOut(txID string, n uint64, includeMempool bool
 }
 
 // GetTxOutProof returns a hex-encoded proof that "txid" was included in a block.
+// It can be parsed with ParseMerkleProofHex.
 //
 //     txIDs : An array of transaction hashes to filter.
 //
@@ -727,13 +860,24 @@ func (bic *BlockchainClient) GetTxOut(txID string, n uint64, includeMempool bool
 //            you need to maintain a transaction index, using the -txindex and -spentindex
 //            command line option or specify the block in which the transaction is included
 //            manually (by blockhash).
-func (bic *BlockchainClient) GetTxOutProof(txIDs []string) (string, error) {
-	proof, err := bic.do("gettxoutproof", txIDs)
+func (bic *BlockchainClient) GetTxOutProof(txIDs []Hash) (string, error) {
+	return bic.GetTxOutProofContext(context.Background(), txIDs)
+}
+
+// GetTxOutProofContext is like GetTxOutProof but uses the given context for the call.
+func (bic *BlockchainClient) GetTxOutProofContext(ctx context.Context, txIDs []Hash) (string, error) {
+	response, err := bic.do(ctx, "gettxoutproof", txIDs)
+	if err != nil {
+		return "", err
+	}
+
+	var proof string
+	err = json.Unmarshal(response, &proof)
 	if err != nil {
 		return "", err
 	}
 
-	return string(proof), nil
+	return proof, nil
 }
 
 // GetTxOutProofInBlock returns a hex-encoded proof that "txid" was included in the block
@@ -747,13 +891,24 @@ func (bic *BlockchainClient) GetTxOutProof(txIDs []string) (string, error) {
 //            you need to maintain a transaction index, using the -txindex command line
 //            option or specify the block in which the transaction is included manually
 //            (by blockhash).
-func (bic *BlockchainClient) GetTxOutProofInBlock(txIDs []string, blockHash string) (string, error) {
-	proof, err := bic.do("gettxoutproof", txIDs, blockHash)
+func (bic *BlockchainClient) GetTxOutProofInBlock(txIDs []Hash, blockHash Hash) (string, error) {
+	return bic.GetTxOutProofInBlockContext(context.Background(), txIDs, blockHash)
+}
+
+// GetTxOutProofInBlockContext is like GetTxOutProofInBlock but uses the given context for the call.
+func (bic *BlockchainClient) GetTxOutProofInBlockContext(ctx context.Context, txIDs []Hash, blockHash Hash) (string, error) {
+	response, err := bic.do(ctx, "gettxoutproof", txIDs, blockHash)
+	if err != nil {
+		return "", err
+	}
+
+	var proof string
+	err = json.Unmarshal(response, &proof)
 	if err != nil {
 		return "", err
 	}
 
-	return string(proof), nil
+	return proof, nil
 }
 
 // TxOutSetInfo represents statistics about the unspent transaction output set.
@@ -761,23 +916,28 @@ type TxOutSetInfo struct {
 	// Height is the current block height (index).
 	Height uint64 `json:"height,required,required"`
 	// BestBlockHash is the hash of the best block.
-	BestBlockHash string `json:"bestblock,required"`
+	BestBlockHash Hash `json:"bestblock,required"`
 	// TransactionCount is the number of unspent transactions.
 	TransactionCount uint64 `json:"transactions,required"`
 	// TxOutCount is the number of unspent transaction outputs.
 	TxOutCount uint64 `json:"txouts,required"`
 	// HashSerialized is the serialized hash of the unspent transaction set.
-	HashSerialized string `json:"hash_serialized,required"`
+	HashSerialized Hash `json:"hash_serialized,required"`
 	// DiskSize is the estimated size of the chainstate on disk.
 	DiskSize uint64 `json:"disk_size,required"`
-	// TotalAmount is the total unspent amount, in SYS.
-	TotalAmount float64 `json:"total_amount,required"`
+	// TotalAmount is the total unspent amount.
+	TotalAmount Amount `json:"total_amount,required"`
 }
 
 // GetTxOutSetInfo returns statistics about the unspent transaction output set.
 //     NOTE : This call may take some time.
 func (bic *BlockchainClient) GetTxOutSetInfo() (*TxOutSetInfo, error) {
-	response, err := bic.do("gettxoutsetinfo")
+	return bic.GetTxOutSetInfoContext(context.Background())
+}
+
+// GetTxOutSetInfoContext is like GetTxOutSetInfo but uses the given context for the call.
+func (bic *BlockchainClient) GetTxOutSetInfoContext(ctx context.Context) (*TxOutSetInfo, error) {
+	response, err := bic.do(ctx, "gettxoutsetinfo")
 	if err != nil {
 		return nil, err
 	}
@@ -796,8 +956,13 @@ func (bic *BlockchainClient) GetTxOutSetInfo() (*TxOutSetInfo, error) {
 // A later preciousblock call can override the effect of an earlier one.
 //
 // The effects of preciousblock are not retained across restarts.
-func (bic *BlockchainClient) PreciousBlock(blockHash string) error {
-	_, err := bic.do("preciousblock", blockHash)
+func (bic *BlockchainClient) PreciousBlock(blockHash Hash) error {
+	return bic.PreciousBlockContext(context.Background(), blockHash)
+}
+
+// PreciousBlockContext is like PreciousBlock but uses the given context for the call.
+func (bic *BlockchainClient) PreciousBlockContext(ctx context.Context, blockHash Hash) error {
+	_, err := bic.do(ctx, "preciousblock", blockHash)
 	return err
 }
 
@@ -810,7 +975,12 @@ func (bic *BlockchainClient) PreciousBlock(blockHash string) error {
 //                        older than the provided timestamp.
 //
 func (bic *BlockchainClient) PruneBlockchain(heightOrTimestamp uint64) (uint64, error) {
-	response, err := bic.do("pruneblockchain", heightOrTimestamp)
+	return bic.PruneBlockchainContext(context.Background(), heightOrTimestamp)
+}
+
+// PruneBlockchainContext is like PruneBlockchain but uses the given context for the call.
+func (bic *BlockchainClient) PruneBlockchainContext(ctx context.Context, heightOrTimestamp uint64) (uint64, error) {
+	response, err := bic.do(ctx, "pruneblockchain", heightOrTimestamp)
 	if err != nil {
 		return 0, err
 	}
@@ -825,7 +995,12 @@ func (bic *BlockchainClient) PruneBlockchain(heightOrTimestamp uint64) (uint64,
 
 // SaveMempool dumps the mempool to disk. It will fail until the previous dump is fully loaded.
 func (bic *BlockchainClient) SaveMempool() error {
-	_, err := bic.do("savemempool")
+	return bic.SaveMempoolContext(context.Background())
+}
+
+// SaveMempoolContext is like SaveMempool but uses the given context for the call.
+func (bic *BlockchainClient) SaveMempoolContext(ctx context.Context) error {
+	_, err := bic.do(ctx, "savemempool")
 	return err
 }
 
@@ -834,7 +1009,12 @@ func (bic *BlockchainClient) SaveMempool() error {
 //     checkLevel : optional, 0-4, default=4 - How thorough the block verification is.
 //     nBlocks    : optional, default=6, 0=all - The number of blocks to check.
 func (bic *BlockchainClient) VerifyChain(checkLevel uint64, nBlocks uint64) (bool, error) {
-	response, err := bic.do("verifychain", checkLevel, nBlocks)
+	return bic.VerifyChainContext(context.Background(), checkLevel, nBlocks)
+}
+
+// VerifyChainContext is like VerifyChain but uses the given context for the call.
+func (bic *BlockchainClient) VerifyChainContext(ctx context.Context, checkLevel uint64, nBlocks uint64) (bool, error) {
+	response, err := bic.do(ctx, "verifychain", checkLevel, nBlocks)
 	if err != nil {
 		return false, err
 	}
@@ -850,15 +1030,21 @@ func (bic *BlockchainClient) VerifyChain(checkLevel uint64, nBlocks uint64) (boo
 // VerifyTxOutProof verifies that a proof points to a transaction in a block,
 // returning the transaction it commits to and throwing an RPC error if the
 // block is not in our best chain.
+// See VerifyMerkleProof to verify a proof without trusting the node.
 //
 //     proof : The hex-encoded proof generated by `gettxoutproof`.
-func (bic *BlockchainClient) VerifyTxOutProof(proof string) ([]string, error) {
-	response, err := bic.do("verifytxoutproof", proof)
+func (bic *BlockchainClient) VerifyTxOutProof(proof string) ([]Hash, error) {
+	return bic.VerifyTxOutProofContext(context.Background(), proof)
+}
+
+// VerifyTxOutProofContext is like VerifyTxOutProof but uses the given context for the call.
+func (bic *BlockchainClient) VerifyTxOutProofContext(ctx context.Context, proof string) ([]Hash, error) {
+	response, err := bic.do(ctx, "verifytxoutproof", proof)
 	if err != nil {
 		return nil, err
 	}
 
-	var proofTxIDs []string
+	var proofTxIDs []Hash
 	err = json.Unmarshal(response, &proofTxIDs)
 	if err != nil {
 		return nil, err
@@ -2,47 +2,62 @@ package syscoinrpc_test
 
 import (
 	"encoding/json"
-	"net/http"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+	"github.com/thebotguys/golang-syscoin-rpc-client/syscoinrpctest"
 )
 
 const invalidURL = "http://invalid.url"
 
-func init() {
-	http.DefaultClient.Timeout = time.Second * 1 // for quick tests, can be changed for more reliability.
+// testTimeout is the timeout of every test client, for quick tests, can be changed for more reliability.
+var testTimeout = syscoinrpc.WithTimeout(time.Second * 1)
+
+// newFakeClient returns a client connected to a fake node with a few mined blocks,
+// for the tests which do not need the testnet data.
+func newFakeClient(t *testing.T) *syscoinrpc.Client {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	t.Cleanup(srv.Close)
+	srv.Generate(10)
+
+	cl, err := srv.Client(testTimeout)
+	require.NoError(t, err, "Must have no error on creation")
+	return cl
 }
 
 var (
 	testBlockHeader = syscoinrpc.FullBlockHeader{
-		Hash: "9f362bce7390fb38dfa0f98c11fb9a5158aeb280f29c8f6cb5ef43d916173bf1",
+		Hash: syscoinrpc.MustParseHash("9f362bce7390fb38dfa0f98c11fb9a5158aeb280f29c8f6cb5ef43d916173bf1"),
 		//Confirmations: ignored in this test
 		Height:            1,
 		Version:           805306624,
 		VersionHex:        "30000100",
-		MerkleRoot:        "ebc03853a2a7d1de194374a5729910e0df02b826ced4bf9d37fd4beb7df92f26",
+		MerkleRoot:        syscoinrpc.MustParseHash("ebc03853a2a7d1de194374a5729910e0df02b826ced4bf9d37fd4beb7df92f26"),
 		Time:              1525175468,
 		MedianTime:        1525175468,
 		Nonce:             0,
 		Bits:              "207fffff",
 		Difficulty:        4.656542373906925e-010,
 		ChainWork:         "0000000000000000000000000000000000000000000000000000000000100012",
-		PreviousBlockHash: "000006e5c08d6d2414435b294210266753b05a75f90e926dd5e6082306812622",
-		NextBlockHash:     "742d1aa459648259a5464df30654c2d4203d4a8c77f895cc31188745a2c41cc7",
+		PreviousBlockHash: syscoinrpc.MustParseHash("000006e5c08d6d2414435b294210266753b05a75f90e926dd5e6082306812622"),
+		NextBlockHash:     syscoinrpc.MustParseHash("742d1aa459648259a5464df30654c2d4203d4a8c77f895cc31188745a2c41cc7"),
 	}
 
+	// testProof is a `gettxoutproof` merkle block of a merge mined main net block.
+	testProof = "00010030592936b277849fde57969d468b07b70cb4e90a3642dd7de22558d6188fbbf911d3eeff309ed9e5839b0c0fbed4a11f4ab90cf1b0b5250e5c5a39317b7c666f0f02ef4d5cd15f06180000000001000000010000000000000000000000000000000000000000000000000000000000000000ffffffff6003ed8c081d2f5669614254432f4d696e656420627920666163696c6974793936332f2cfabe6d6db6e3813c648a3ec91b98691651f4459b5500a128e953ebfeb90416784eae644b040000000000000010902734086d644fc5b7e2da3e68be0000ffffffff021e142a4b000000001976a914536ffa992491508dca0354e52f32a3a7a679a53a88ac0000000000000000266a24aa21a9eda64137e63f795c26dbaba0895c2d4c39feac11d9cf0f823cc58004cd95d65e5c000000007b67455430797ac1b40e6a6af12421809a28d3c9c3a7870500000000000000000c4d9f4d83b526cb802e0600a684ef32ad46b31696b5d31320e4e85f7a3c9f99e25299c8fa44125c8746001b35a088593bbac70c593347dddb0ed1484b438c523cc7ae88bdd96bcf06cd99f387bf48722ed2379d993071d33ad8d17404b6fc7a92486be467df89169f14eb58d25867bdbda839e97c1100883f8184b77e52b00a76c934e183f37f3ea8660c9b8cdda80e0ee8249258e935412dd71783b2254da8457f4ce60c04f5365331a57cead102b7bb75342da15849305a061e45ace1ff1bdcb4c6bd86e9f22ea3163e2c515e470b8c89f3dd8b0605b58ec70bdbae5a8783aca6c7f55d2bef7dd37b60cdbb8fe7a8a438a91cb6ef9b39443f41ee68346f846372abf6f503aa77f7493b69b50b6f9b91113e59131840620b7ca8374535f66daeda0bbb54caf97046809f66d5ef5359d6a68b91bdee7c4679e80f4ea5a5cbd05a49c7fd842c774ef86d725f93f01d0cfb437c11bff1ae296dec4708eb6b654121776b065871c8a0a8f09877b9b91493d190a14589144544a800ecf16628e2338400000000027659ab8a2e71540487b9c2b83f04421c18af6cfe7de65e870b7b20e1b8b2243fa7500873ee5ded9ff99deeeb7514386b22e57901588db57a9528f3ba8d1cbfad0200000000000020e194e148a5720e845039884a5ecb7a5678a5f905093a2000000000000000000045cb026e55f692d58ff7700b797736713006379f1cdc4f7c515e7c279ef7c51634ef4d5c33d62f17aef92c6d0100000001d3eeff309ed9e5839b0c0fbed4a11f4ab90cf1b0b5250e5c5a39317b7c666f0f0101"
+
 	testBlock = syscoinrpc.FullBlock{
 		FullBlockHeader: &testBlockHeader,
 		Size:            393,
-		Tx:              []string{"ebc03853a2a7d1de194374a5729910e0df02b826ced4bf9d37fd4beb7df92f26"},
+		Tx:              []syscoinrpc.Hash{syscoinrpc.MustParseHash("ebc03853a2a7d1de194374a5729910e0df02b826ced4bf9d37fd4beb7df92f26")},
 		AuxPow: syscoinrpc.AuxPow{
-			Tx: syscoinrpc.AuxPowTx{
+			Tx: syscoinrpc.Transaction{
 				Hex:      "02000000010000000000000000000000000000000000000000000000000000000000000000ffffffff29289f362bce7390fb38dfa0f98c11fb9a5158aeb280f29c8f6cb5ef43d916173bf10100000000000000ffffffff0000000000",
-				TxID:     "d3d562dd548c71d2db1b7e6392bd958989b174181ff51f5d6e70b487f394d463",
+				TxID:     syscoinrpc.MustParseHash("d3d562dd548c71d2db1b7e6392bd958989b174181ff51f5d6e70b487f394d463"),
 				Size:     92,
 				Version:  2,
 				LockTime: 0,
@@ -53,17 +68,17 @@ var (
 					},
 				},
 				Vout:      []syscoinrpc.VoutObject{},
-				BlockHash: "bae49789e089f764a52fde5064c3257f3f07ed340dc6a7ed0748a62c29cd42d5",
+				BlockHash: syscoinrpc.MustParseHash("bae49789e089f764a52fde5064c3257f3f07ed340dc6a7ed0748a62c29cd42d5"),
 			},
-			MerkleBranch:      []string{},
-			ChainMerkleBranch: []string{},
+			MerkleBranch:      []syscoinrpc.Hash{},
+			ChainMerkleBranch: []syscoinrpc.Hash{},
 			ParentBlock:       "01000000000000000000000000000000000000000000000000000000000000000000000063d494f387b4706e5d1ff51f1874b1898995bd92637e1bdbd2718c54dd62d5d3000000000000000000000000",
 		},
 	}
 )
 
 func TestGetBestBlockHashInvalid(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(invalidURL, "", "")
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
 	_, err = cl.Blockchain.GetBestBlockHash()
@@ -71,20 +86,23 @@ func TestGetBestBlockHashInvalid(t *testing.T) {
 }
 
 func TestGetBlockInvalid(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(invalidURL, "", "")
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
-	testBlockHash := "9f362bce7390fb38dfa0f98c11fb9a5158aeb280f29c8f6cb5ef43d916173bf1"
+	testBlockHash := syscoinrpc.MustParseHash("9f362bce7390fb38dfa0f98c11fb9a5158aeb280f29c8f6cb5ef43d916173bf1")
 
 	_, err = cl.Blockchain.GetBlock(testBlockHash)
 	require.Error(t, err, "Must error on any method with invalid URL")
 
 	_, err = cl.Blockchain.GetFullBlock(testBlockHash)
 	require.Error(t, err, "Must error on any method with invalid URL")
+
+	_, err = cl.Blockchain.GetBlockWithTransactions(testBlockHash)
+	require.Error(t, err, "Must error on any method with invalid URL")
 }
 
 func TestGetBlockchainInfoInvalid(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(invalidURL, "", "")
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
 	_, err = cl.Blockchain.GetBlockchainInfo()
@@ -92,7 +110,7 @@ func TestGetBlockchainInfoInvalid(t *testing.T) {
 }
 
 func TestGetBlockCountInvalid(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(invalidURL, "", "")
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
 	_, err = cl.Blockchain.GetBlockCount()
@@ -100,7 +118,7 @@ func TestGetBlockCountInvalid(t *testing.T) {
 }
 
 func TestGetBlockHashInvalid(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(invalidURL, "", "")
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
 	testHeight := uint64(1)
@@ -110,10 +128,10 @@ func TestGetBlockHashInvalid(t *testing.T) {
 }
 
 func TestGetBlockHeaderInvalid(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(invalidURL, "", "")
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
-	testBlockHash := "9f362bce7390fb38dfa0f98c11fb9a5158aeb280f29c8f6cb5ef43d916173bf1"
+	testBlockHash := syscoinrpc.MustParseHash("9f362bce7390fb38dfa0f98c11fb9a5158aeb280f29c8f6cb5ef43d916173bf1")
 
 	_, err = cl.Blockchain.GetBlockHeader(testBlockHash)
 	require.Error(t, err, "Must error on any method with invalid URL")
@@ -123,15 +141,15 @@ func TestGetBlockHeaderInvalid(t *testing.T) {
 }
 
 func TestGetAllBlockStatsInvalid(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(invalidURL, "", "")
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
-	_, err = cl.Blockchain.GetAllBlockStats("")
+	_, err = cl.Blockchain.GetAllBlockStats(syscoinrpc.Hash{})
 	require.Error(t, err, "Must error on any method with invalid URL")
 }
 
 func TestGetChainTipsInvalid(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(invalidURL, "", "")
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
 	_, err = cl.Blockchain.GetChainTips()
@@ -139,15 +157,15 @@ func TestGetChainTipsInvalid(t *testing.T) {
 }
 
 func TestGetChainTxStatsInvalid(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(invalidURL, "", "")
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
-	_, err = cl.Blockchain.GetChainTxStats(0, "")
+	_, err = cl.Blockchain.GetChainTxStats(0, syscoinrpc.Hash{})
 	require.Error(t, err, "Must error on any method with invalid URL")
 }
 
 func TestGetDifficultyInvalid(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(invalidURL, "", "")
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
 	_, err = cl.Blockchain.GetDifficulty()
@@ -155,37 +173,37 @@ func TestGetDifficultyInvalid(t *testing.T) {
 }
 
 func TestGetMempoolAncestorsInvalid(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(invalidURL, "", "")
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
-	_, err = cl.Blockchain.GetMempoolAncestors("")
+	_, err = cl.Blockchain.GetMempoolAncestors(syscoinrpc.Hash{})
 	require.Error(t, err, "Must error on any method with invalid URL")
 
-	_, err = cl.Blockchain.GetMempoolAncestorsFull("")
+	_, err = cl.Blockchain.GetMempoolAncestorsFull(syscoinrpc.Hash{})
 	require.Error(t, err, "Must error on any method with invalid URL")
 }
 
 func TestGetMempoolDescendantsInvalid(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(invalidURL, "", "")
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
-	_, err = cl.Blockchain.GetMempoolDescendants("")
+	_, err = cl.Blockchain.GetMempoolDescendants(syscoinrpc.Hash{})
 	require.Error(t, err, "Must error on any method with invalid URL")
 
-	_, err = cl.Blockchain.GetMempoolDescendantsFull("")
+	_, err = cl.Blockchain.GetMempoolDescendantsFull(syscoinrpc.Hash{})
 	require.Error(t, err, "Must error on any method with invalid URL")
 }
 
 func TestGetMempoolEntryInvalid(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(invalidURL, "", "")
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
-	_, err = cl.Blockchain.GetMempoolEntry("")
+	_, err = cl.Blockchain.GetMempoolEntry(syscoinrpc.Hash{})
 	require.Error(t, err, "Must error on any method with invalid URL")
 }
 
 func TestGetMempoolInfoInvalid(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(invalidURL, "", "")
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
 	_, err = cl.Blockchain.GetMempoolInfo()
@@ -193,7 +211,7 @@ func TestGetMempoolInfoInvalid(t *testing.T) {
 }
 
 func TestGetRawMempoolInvalid(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(invalidURL, "", "")
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
 	_, err = cl.Blockchain.GetRawMempool()
@@ -204,26 +222,26 @@ func TestGetRawMempoolInvalid(t *testing.T) {
 }
 
 func TestGetTxOutInvalid(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(invalidURL, "", "")
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
-	_, err = cl.Blockchain.GetTxOut("", 0, false)
+	_, err = cl.Blockchain.GetTxOut(syscoinrpc.Hash{}, 0, false)
 	require.Error(t, err, "Must error on any method with invalid URL")
 }
 
 func TestGetTxOutProofInvalid(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(invalidURL, "", "")
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
-	_, err = cl.Blockchain.GetTxOutProof([]string{})
+	_, err = cl.Blockchain.GetTxOutProof([]syscoinrpc.Hash{})
 	require.Error(t, err, "Must error on any method with invalid URL")
 
-	_, err = cl.Blockchain.GetTxOutProofInBlock([]string{}, "")
+	_, err = cl.Blockchain.GetTxOutProofInBlock([]syscoinrpc.Hash{}, syscoinrpc.Hash{})
 	require.Error(t, err, "Must error on any method with invalid URL")
 }
 
 func TestGetTxOutSetInfoInvalid(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(invalidURL, "", "")
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
 	_, err = cl.Blockchain.GetTxOutSetInfo()
@@ -231,15 +249,15 @@ func TestGetTxOutSetInfoInvalid(t *testing.T) {
 }
 
 func TestPreciousBlockInvalid(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(invalidURL, "", "")
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
-	err = cl.Blockchain.PreciousBlock("")
+	err = cl.Blockchain.PreciousBlock(syscoinrpc.Hash{})
 	require.Error(t, err, "Must error on any method with invalid URL")
 }
 
 func TestPruneBlockchainInvalid(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(invalidURL, "", "")
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
 	_, err = cl.Blockchain.PruneBlockchain(uint64(1))
@@ -247,7 +265,7 @@ func TestPruneBlockchainInvalid(t *testing.T) {
 }
 
 func TestSaveMempoolInvalid(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(invalidURL, "", "")
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
 	err = cl.Blockchain.SaveMempool()
@@ -255,7 +273,7 @@ func TestSaveMempoolInvalid(t *testing.T) {
 }
 
 func TestVerifyChainInvalid(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(invalidURL, "", "")
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
 	_, err = cl.Blockchain.VerifyChain(0, 0)
@@ -263,7 +281,7 @@ func TestVerifyChainInvalid(t *testing.T) {
 }
 
 func TestVerifyTxOutProofInvalid(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(invalidURL, "", "")
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
 	_, err = cl.Blockchain.VerifyTxOutProof("")
@@ -271,8 +289,7 @@ func TestVerifyTxOutProofInvalid(t *testing.T) {
 }
 
 func TestGetBestBlockHashOK(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"))
-	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+	cl := newFakeClient(t)
 
 	hash, err := cl.Blockchain.GetBestBlockHash()
 	require.NoError(t, err, "Must not error on valid URL, check if the node is running")
@@ -281,39 +298,104 @@ func TestGetBestBlockHashOK(t *testing.T) {
 }
 
 func TestGetBlockOK(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"))
-	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+	cl := newCassetteClient(t, "getblock")
 
-	testBlockHash := "9f362bce7390fb38dfa0f98c11fb9a5158aeb280f29c8f6cb5ef43d916173bf1"
+	testBlockHash, err := cl.Blockchain.GetBlockHash(1)
+	require.NoError(t, err)
+	genesisHash, err := cl.Blockchain.GetBlockHash(0)
+	require.NoError(t, err)
 
 	block, err := cl.Blockchain.GetBlock(testBlockHash)
 	require.NoError(t, err, "GetBlock: Must not error on valid URL, check if the node is running")
+	require.NotEmpty(t, block)
 
 	t.Log("Test Block Hash:", block)
 
-	expectedBlock := testBlock
-
 	fullBlock, err := cl.Blockchain.GetFullBlock(testBlockHash)
 	require.NoError(t, err, "GetFullBlock: Must not error on valid URL, check if the node is running")
 
-	fullBlock.Confirmations = 0 // Removing confirmation because they change when we mine.
-	require.Equal(t, expectedBlock, *fullBlock, "Must be equal to test block")
+	require.Equal(t, testBlockHash, fullBlock.Hash)
+	require.Equal(t, uint64(1), fullBlock.Height)
+	require.Equal(t, genesisHash, fullBlock.PreviousBlockHash)
+	require.NotEmpty(t, fullBlock.Tx, "Must have the coinbase")
+}
+
+// testBlockWithTransactions is a synthetic verbosity 2 `getblock` call, of a block
+// with a segwit coinbase and a P2WPKH spending transaction. The hashes, sizes and
+// merkle root are computed from the transactions hex, but the signature is not valid.
+const testBlockWithTransactions = `{"method":"getblock","params":["2cf6de23e9b081083620ef71cf099893df000c1272b167a6f1e18e401ece7e34",2],"result":{
+"hash":"2cf6de23e9b081083620ef71cf099893df000c1272b167a6f1e18e401ece7e34","confirmations":1,"height":432,"version":536870912,"versionHex":"20000000",
+"merkleroot":"72880f5fe3740d4c44cc0244e2215114df182c1dd25189d3b5532f97b507a93c","time":1525201388,"mediantime":1525201088,"nonce":0,"bits":"207fffff",
+"difficulty":4.656542373906925e-10,"chainwork":"0000000000000000000000000000000000000000000000000000000000000362",
+"previousblockhash":"c363d9a6510540eec08b7fe5649c4d8d8d4514b28a23111ebac69f94ba740757","size":445,"tx":[
+{"txid":"c59b5847e47192201bec810d4b3f14ffec2fcd155d6716914bae92e07e3631f0","hash":"f6988626cf6da1a063f3cfd1ca77e3ead151d482c1ec9020c96786a313dfc5e8",
+ "version":2,"size":170,"vsize":143,"locktime":0,
+ "vin":[{"coinbase":"02b0010101","txinwitness":["0000000000000000000000000000000000000000000000000000000000000000"],"sequence":4294967295}],
+ "vout":[{"value":50.00002260,"n":0,"scriptPubKey":{"asm":"0 751e76e8199196d454941c45d1b3a323f1433bd6","hex":"0014751e76e8199196d454941c45d1b3a323f1433bd6","reqSigs":1,"type":"witness_v0_keyhash","addresses":["sys1qw508d6qejxtdg4y5r3zarvary0c5xw7kyhct58"]}},
+  {"value":0.00000000,"n":1,"scriptPubKey":{"asm":"OP_RETURN aa21a9ed5367e5d21c9f2bf8f43f14be234b98d110aee23cd72a81ea36203667f938ff35","hex":"6a24aa21a9ed5367e5d21c9f2bf8f43f14be234b98d110aee23cd72a81ea36203667f938ff35","type":"nulldata"}}],
+ "hex":"020000000001010000000000000000000000000000000000000000000000000000000000000000ffffffff0502b0010101ffffffff02d4fa052a01000000160014751e76e8199196d454941c45d1b3a323f1433bd60000000000000000266a24aa21a9ed5367e5d21c9f2bf8f43f14be234b98d110aee23cd72a81ea36203667f938ff350120000000000000000000000000000000000000000000000000000000000000000000000000"},
+{"txid":"4c1b80ba29546616ff361758de02e8c5d3fe8b75cc64be7217fcb4a14a764a9c","hash":"eb99bdb91a02410a9d126b68e6915e26f9cb6433e85e7a3d6da7e8ab77ae2a5f",
+ "version":2,"size":194,"vsize":113,"locktime":431,
+ "vin":[{"txid":"f6c5a491463ca641d398018876a217527abca395c4f73594a8c353cf40e32e90","vout":0,"scriptSig":{"asm":"","hex":""},
+  "txinwitness":["3044022027a1f1c0b1e3d5c7f9e1a3b5c7d9e1f3a5b7c9d1e3f5a7b9c1d3e5f7a9b1c3d502201a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f80901","0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"],"sequence":4294967294}],
+ "vout":[{"value":49.99997740,"n":0,"scriptPubKey":{"asm":"OP_DUP OP_HASH160 5b3c0e6a3b1f0e5d8a1c2b3d4e5f60718293a4b5 OP_EQUALVERIFY OP_CHECKSIG","hex":"76a9145b3c0e6a3b1f0e5d8a1c2b3d4e5f60718293a4b588ac","reqSigs":1,"type":"pubkeyhash","addresses":["SVcQU5pMNk34reSquReprgXwiv1yVcM2Es"]}}],
+ "hex":"02000000000101902ee340cf53c3a89435f7c495a3bc7a5217a276880198d341a63c4691a4c5f60000000000feffffff012ce9052a010000001976a9145b3c0e6a3b1f0e5d8a1c2b3d4e5f60718293a4b588ac02473044022027a1f1c0b1e3d5c7f9e1a3b5c7d9e1f3a5b7c9d1e3f5a7b9c1d3e5f7a9b1c3d502201a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f80901210279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798af010000"}]}}`
+
+func TestGetBlockWithTransactionsOK(t *testing.T) {
+	transport, err := syscoinrpc.NewReplayTransport(strings.NewReader(testBlockWithTransactions))
+	require.NoError(t, err, "Must read the recorded call")
+	cl, err := syscoinrpc.NewClientWithTransport(transport)
+	require.NoError(t, err, "Must have no error on creation")
+
+	block, err := cl.Blockchain.GetBlockWithTransactions(syscoinrpc.MustParseHash("2cf6de23e9b081083620ef71cf099893df000c1272b167a6f1e18e401ece7e34"))
+	require.NoError(t, err, "GetBlockWithTransactions: must not error")
+	require.Equal(t, uint64(432), block.Height)
+	require.Len(t, block.Tx, 2)
+
+	coinbase := block.Tx[0]
+	require.True(t, coinbase.Vin[0].IsCoinbase())
+	require.Equal(t, []string{"0000000000000000000000000000000000000000000000000000000000000000"}, coinbase.Vin[0].Witness)
+	require.Equal(t, syscoinrpc.Amount(5000002260), coinbase.Vout[0].Value)
+	require.Equal(t, "witness_v0_keyhash", coinbase.Vout[0].ScriptPubKey.Type)
+	require.Equal(t, uint32(1), coinbase.Vout[1].N)
+
+	spend := block.Tx[1]
+	require.NotEqual(t, spend.TxID, spend.Hash, "Witness transactions must have distinct hashes")
+	require.Equal(t, uint64(113), spend.VSize)
+	require.False(t, spend.Vin[0].IsCoinbase())
+	require.Equal(t, syscoinrpc.MustParseHash("f6c5a491463ca641d398018876a217527abca395c4f73594a8c353cf40e32e90"), spend.Vin[0].TxID)
+	require.Equal(t, uint32(0), spend.Vin[0].Vout)
+	require.NotNil(t, spend.Vin[0].ScriptSig)
+	require.Len(t, spend.Vin[0].Witness, 2)
+	require.Equal(t, uint64(4294967294), spend.Vin[0].Sequence)
+	require.Equal(t, syscoinrpc.Amount(4999997740), spend.Vout[0].Value)
+	require.Equal(t, "76a9145b3c0e6a3b1f0e5d8a1c2b3d4e5f60718293a4b588ac", spend.Vout[0].ScriptPubKey.Hex)
+
+	for _, tx := range block.Tx {
+		decoded, err := syscoinrpc.DecodeTransactionHex(tx.Hex, syscoinrpc.MainNet)
+		require.NoError(t, err, "DecodeTransactionHex: must not error")
+		require.Equal(t, tx.TxID, decoded.TxID, "The txid must match the hex")
+		require.Equal(t, tx.Hash, decoded.Hash, "The hash must match the hex")
+		require.Equal(t, tx.VSize, decoded.VSize, "The vsize must match the hex")
+	}
+	merkleRoot := doubleSHA256(append(coinbase.TxID.Bytes(), spend.TxID.Bytes()...))
+	require.Equal(t, merkleRoot, block.MerkleRoot, "The merkle root must match the txids")
 }
 
 func TestGetBlockchainInfoOK(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"))
-	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+	cl := newCassetteClient(t, "getblockchaininfo")
 
 	info, err := cl.Blockchain.GetBlockchainInfo()
 	require.NoError(t, err, "Must not error on valid URL, check if the node is running")
+	require.NotEmpty(t, info.Chain)
+	require.False(t, info.BestBlockHash.IsZero())
 
 	infoJSON, _ := json.Marshal(info)
 	t.Log(string(infoJSON))
 }
 
 func TestGetBlockCountOK(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"))
-	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+	cl := newFakeClient(t)
 
 	count, err := cl.Blockchain.GetBlockCount()
 	require.NoError(t, err, "Must not error on valid URL, check if the node is running")
@@ -322,8 +404,7 @@ func TestGetBlockCountOK(t *testing.T) {
 }
 
 func TestGetBlockHashOK(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"))
-	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+	cl := newFakeClient(t)
 
 	testHeight := uint64(1)
 
@@ -334,40 +415,45 @@ func TestGetBlockHashOK(t *testing.T) {
 }
 
 func TestGetBlockHeaderOK(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"))
-	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+	cl := newFakeClient(t)
 
-	testBlockHash := "9f362bce7390fb38dfa0f98c11fb9a5158aeb280f29c8f6cb5ef43d916173bf1"
+	testBlockHash, err := cl.Blockchain.GetBlockHash(1)
+	require.NoError(t, err)
 
 	block, err := cl.Blockchain.GetBlockHeader(testBlockHash)
 	require.NoError(t, err, "GetBlockHeader: Must not error on valid URL, check if the node is running")
 
 	t.Log("Test Block Header:", block)
 
-	expectedBlockHeader := testBlockHeader
+	expectedBlockHeader, err := syscoinrpc.DecodeBlockHeaderHex(block)
+	require.NoError(t, err)
 
 	fullBlockHeader, err := cl.Blockchain.GetFullBlockHeader(testBlockHash)
 	require.NoError(t, err, "GetFullBlockHeader: Must not error on valid URL, check if the node is running")
 
-	fullBlockHeader.Confirmations = 0
-	require.Equal(t, expectedBlockHeader, *fullBlockHeader, "Must be equal to test block header")
+	require.Equal(t, expectedBlockHeader.Hash, fullBlockHeader.Hash, "Must be equal to test block header")
+	require.Equal(t, expectedBlockHeader.MerkleRoot, fullBlockHeader.MerkleRoot, "Must be equal to test block header")
+	require.Equal(t, uint64(1), fullBlockHeader.Height)
+	require.Equal(t, "0000000000000000000000000000000000000000000000000000000000000004", fullBlockHeader.ChainWork, "Must keep the chainwork big-endian")
 }
 
 func TestGetAllBlockStatsOK(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"))
-	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+	cl := newFakeClient(t)
 
-	hash := "dead1f156254f1d723b043a0b7ff2cbc4735c87b1852b5d829f85e8f8796f773"
+	hash, err := cl.Blockchain.GetBlockHash(1)
+	require.NoError(t, err)
 
 	stats, err := cl.Blockchain.GetAllBlockStats(hash)
 	require.NoError(t, err, "GetAllBlockStats: must not error")
+	require.Equal(t, hash, stats.BlockHash)
+	require.Equal(t, syscoinrpc.SatoshiAmount(syscoinrpctest.BlockSubsidy), stats.Subsidy)
+	require.Zero(t, stats.TransactionsCount, "Must exclude the coinbase")
 
 	t.Log("GetAllBlockStats:", stats)
 }
 
 func TestGetChainTipsOK(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"))
-	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+	cl := newFakeClient(t)
 
 	tips, err := cl.Blockchain.GetChainTips()
 	require.NoError(t, err, "GetChainTips: Must not error on valid URL, check if the node is running")
@@ -376,18 +462,18 @@ func TestGetChainTipsOK(t *testing.T) {
 }
 
 func TestGetChainTxStatsOK(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"))
-	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+	cl := newFakeClient(t)
 
-	stats, err := cl.Blockchain.GetChainTxStats(0, "")
+	stats, err := cl.Blockchain.GetChainTxStats(0, syscoinrpc.Hash{})
 	require.NoError(t, err, "GetChainTxStats: Must not error on valid URL, check if the node is running")
+	require.Equal(t, uint64(11), stats.TransactionCount, "Must count the coinbase of every block")
+	require.Equal(t, uint64(9), stats.WindowBlockCount)
 
 	t.Log("ChainTxStats:", stats)
 }
 
 func TestGetDifficultyOK(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"))
-	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+	cl := newFakeClient(t)
 
 	difficulty, err := cl.Blockchain.GetDifficulty()
 	require.NoError(t, err, "GetBlockHeader: Must not error on valid URL, check if the node is running")
@@ -396,10 +482,10 @@ func TestGetDifficultyOK(t *testing.T) {
 }
 
 func TestGetMempoolAncestorsOK(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"))
+	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"), testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
-	txID := "d3d562dd548c71d2db1b7e6392bd958989b174181ff51f5d6e70b487f394d463"
+	txID := syscoinrpc.MustParseHash("d3d562dd548c71d2db1b7e6392bd958989b174181ff51f5d6e70b487f394d463")
 
 	_, err = cl.Blockchain.GetMempoolAncestors(txID)
 	require.Error(t, err, "GetMempoolAncestors : must error with \"Transaction not in mempool\"")
@@ -409,10 +495,10 @@ func TestGetMempoolAncestorsOK(t *testing.T) {
 }
 
 func TestGetMempoolDescendantsOK(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"))
+	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"), testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
-	txID := "d3d562dd548c71d2db1b7e6392bd958989b174181ff51f5d6e70b487f394d463"
+	txID := syscoinrpc.MustParseHash("d3d562dd548c71d2db1b7e6392bd958989b174181ff51f5d6e70b487f394d463")
 
 	_, err = cl.Blockchain.GetMempoolDescendants(txID)
 	require.Error(t, err, "GetMempoolDescendants : must error with \"Transaction not in mempool\"")
@@ -422,26 +508,24 @@ func TestGetMempoolDescendantsOK(t *testing.T) {
 }
 
 func TestGetMempoolEntryOK(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"))
+	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"), testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
-	txID := "d3d562dd548c71d2db1b7e6392bd958989b174181ff51f5d6e70b487f394d463"
+	txID := syscoinrpc.MustParseHash("d3d562dd548c71d2db1b7e6392bd958989b174181ff51f5d6e70b487f394d463")
 
 	_, err = cl.Blockchain.GetMempoolEntry(txID)
 	require.Error(t, err, "GetMempoolEntry : must error with \"Transaction not in mempool\"")
 }
 
 func TestGetMempoolInfoOK(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"))
-	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+	cl := newFakeClient(t)
 
-	_, err = cl.Blockchain.GetMempoolInfo()
+	_, err := cl.Blockchain.GetMempoolInfo()
 	require.NoError(t, err, "GetMempoolEntry : must not error")
 }
 
 func TestGetRawMempoolOK(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"))
-	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+	cl := newCassetteClient(t, "getrawmempool")
 
 	rawPool, err := cl.Blockchain.GetRawMempool()
 	require.NoError(t, err, "GetRawMempool : must not error")
@@ -450,30 +534,42 @@ func TestGetRawMempoolOK(t *testing.T) {
 
 	rawPoolFull, err := cl.Blockchain.GetRawMempoolFull()
 	require.NoError(t, err, "GetRawMempoolFull : must not error")
+	for _, entry := range rawPoolFull {
+		require.NotZero(t, entry.Size)
+	}
 
 	t.Log("GetRawMempoolFull :", rawPoolFull)
 }
 
 func TestGetTxOutOK(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"))
-	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+	cl := newFakeClient(t)
+
+	hash, err := cl.Blockchain.GetBlockHash(1)
+	require.NoError(t, err)
+	block, err := cl.Blockchain.GetFullBlock(hash)
+	require.NoError(t, err)
 
-	txID := "0437cd7f8525ceed2324359c2d0ba26006d92d856a9c20fa0241106ee5a597c9"
+	txID := block.Tx[0]
 	n := uint64(0)
 	includeMempool := true
 
 	out, err := cl.Blockchain.GetTxOut(txID, n, includeMempool)
 	require.NoError(t, err, "GetTxOut : must not error")
+	require.Equal(t, syscoinrpc.Amount(syscoinrpctest.BlockSubsidy), out.Value)
+	require.Equal(t, uint64(10), out.Confirmations)
+	require.True(t, out.Coinbase)
 
 	t.Log("GetTxOut :", out)
 }
 
 func TestGetTxOutProofOK(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"))
-	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+	cl := newFakeClient(t)
 
-	txIDs := []string{"0f6f667c7b31395a5c0e25b5b0f10cb94a1fa1d4be0f0c9b83e5d99e30ffeed3"}
-	blockHash := "9299180cfe417ee3c6bcc96bd706de154a7cc92eaff662b56178c2b5ff0fdfe2"
+	blockHash, err := cl.Blockchain.GetBlockHash(1)
+	require.NoError(t, err)
+	block, err := cl.Blockchain.GetFullBlock(blockHash)
+	require.NoError(t, err)
+	txIDs := block.Tx
 
 	proofs, err := cl.Blockchain.GetTxOutProof(txIDs)
 	require.NoError(t, err, "GetTxOutProof: must not error")
@@ -487,29 +583,30 @@ func TestGetTxOutProofOK(t *testing.T) {
 }
 
 func TestGetTxOutSetInfoOK(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"))
-	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+	cl := newFakeClient(t)
 
 	info, err := cl.Blockchain.GetTxOutSetInfo()
 	require.NoError(t, err, "GetTxOutSetInfo: must not error")
+	require.Equal(t, uint64(10), info.Height)
+	require.Equal(t, uint64(11), info.TxOutCount, "Must count the coinbase output of every block")
 
 	t.Log("GetTxOutSetInfo :", info)
 }
 
 func TestPreciousBlockOK(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"))
+	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"), testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
 	t.Skip("This call would alter the node, so for this tests is skipped, remove the skip instruction to do it anyway")
 
-	testBlockHash := "9f362bce7390fb38dfa0f98c11fb9a5158aeb280f29c8f6cb5ef43d916173bf1"
+	testBlockHash := syscoinrpc.MustParseHash("9f362bce7390fb38dfa0f98c11fb9a5158aeb280f29c8f6cb5ef43d916173bf1")
 
 	err = cl.Blockchain.PreciousBlock(testBlockHash)
 	require.NoError(t, err, "PreciousBlock: must not error")
 }
 
 func TestPruneBlockchainOK(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"))
+	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"), testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
 	t.Skip("This call would alter the node, so for this tests is skipped, remove the skip instruction to do it anyway")
@@ -525,7 +622,7 @@ func TestPruneBlockchainOK(t *testing.T) {
 }
 
 func TestSaveMempoolOK(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"))
+	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"), testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
 	t.Skip("This call would alter the node, so for this tests is skipped, remove the skip instruction to do it anyway")
@@ -535,23 +632,28 @@ func TestSaveMempoolOK(t *testing.T) {
 }
 
 func TestVerifyChainOK(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"))
-	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+	cl := newFakeClient(t)
 
 	verified, err := cl.Blockchain.VerifyChain(4, 6)
 	require.NoError(t, err, "VerifyChain: must not error")
+	require.True(t, verified)
 
 	t.Log("VerifyChain :", verified)
 }
 
 func TestVerifyTxOutProofOK(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"))
-	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+	cl := newFakeClient(t)
 
-	testProof := "00010030592936b277849fde57969d468b07b70cb4e90a3642dd7de22558d6188fbbf911d3eeff309ed9e5839b0c0fbed4a11f4ab90cf1b0b5250e5c5a39317b7c666f0f02ef4d5cd15f06180000000001000000010000000000000000000000000000000000000000000000000000000000000000ffffffff6003ed8c081d2f5669614254432f4d696e656420627920666163696c6974793936332f2cfabe6d6db6e3813c648a3ec91b98691651f4459b5500a128e953ebfeb90416784eae644b040000000000000010902734086d644fc5b7e2da3e68be0000ffffffff021e142a4b000000001976a914536ffa992491508dca0354e52f32a3a7a679a53a88ac0000000000000000266a24aa21a9eda64137e63f795c26dbaba0895c2d4c39feac11d9cf0f823cc58004cd95d65e5c000000007b67455430797ac1b40e6a6af12421809a28d3c9c3a7870500000000000000000c4d9f4d83b526cb802e0600a684ef32ad46b31696b5d31320e4e85f7a3c9f99e25299c8fa44125c8746001b35a088593bbac70c593347dddb0ed1484b438c523cc7ae88bdd96bcf06cd99f387bf48722ed2379d993071d33ad8d17404b6fc7a92486be467df89169f14eb58d25867bdbda839e97c1100883f8184b77e52b00a76c934e183f37f3ea8660c9b8cdda80e0ee8249258e935412dd71783b2254da8457f4ce60c04f5365331a57cead102b7bb75342da15849305a061e45ace1ff1bdcb4c6bd86e9f22ea3163e2c515e470b8c89f3dd8b0605b58ec70bdbae5a8783aca6c7f55d2bef7dd37b60cdbb8fe7a8a438a91cb6ef9b39443f41ee68346f846372abf6f503aa77f7493b69b50b6f9b91113e59131840620b7ca8374535f66daeda0bbb54caf97046809f66d5ef5359d6a68b91bdee7c4679e80f4ea5a5cbd05a49c7fd842c774ef86d725f93f01d0cfb437c11bff1ae296dec4708eb6b654121776b065871c8a0a8f09877b9b91493d190a14589144544a800ecf16628e2338400000000027659ab8a2e71540487b9c2b83f04421c18af6cfe7de65e870b7b20e1b8b2243fa7500873ee5ded9ff99deeeb7514386b22e57901588db57a9528f3ba8d1cbfad0200000000000020e194e148a5720e845039884a5ecb7a5678a5f905093a2000000000000000000045cb026e55f692d58ff7700b797736713006379f1cdc4f7c515e7c279ef7c51634ef4d5c33d62f17aef92c6d0100000001d3eeff309ed9e5839b0c0fbed4a11f4ab90cf1b0b5250e5c5a39317b7c666f0f0101"
+	blockHash, err := cl.Blockchain.GetBlockHash(1)
+	require.NoError(t, err)
+	block, err := cl.Blockchain.GetFullBlock(blockHash)
+	require.NoError(t, err)
+	proof, err := cl.Blockchain.GetTxOutProof(block.Tx)
+	require.NoError(t, err)
 
-	proofs, err := cl.Blockchain.VerifyTxOutProof(testProof)
+	proofs, err := cl.Blockchain.VerifyTxOutProof(proof)
 	require.NoError(t, err, "VerifyTxOutProof : must not error")
+	require.Equal(t, block.Tx, proofs)
 
 	t.Log("VerifyTxOutProof :", proofs)
 }
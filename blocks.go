@@ -0,0 +1,168 @@
+package syscoinrpc
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrInvalidBlockRange is returned by a BlockIterator when the first height of
+// the range is greater than the last one.
+var ErrInvalidBlockRange = errors.New("Invalid block range: from must not be greater than to")
+
+// BlockIterator iterates over the blocks of a range of heights, in height order,
+// fetching the following blocks concurrently while the current one is processed.
+//
+// It is used like a bufio.Scanner:
+//
+//     it := client.Blockchain.Blocks(ctx, 0, 1000, 8)
+//     defer it.Close()
+//     for it.Next() {
+//         block := it.Block()
+//         // Process the block.
+//     }
+//     if err := it.Err(); err != nil {
+//         // Handle the error
+//     }
+//
+// A BlockIterator must not be used from multiple goroutines.
+type BlockIterator struct {
+	ctx     context.Context       // The context of the calls.
+	cancel  context.CancelFunc    // Cancels ctx, stopping the prefetch.
+	slots   chan struct{}         // The semaphore bounding the blocks fetched and not yet delivered.
+	pending chan chan blockResult // The results of the started fetches, in height order.
+	next    uint64                // The height of the next block to deliver.
+	to      uint64                // The height of the last block.
+	done    bool                  // True once the last block has been delivered.
+	block   *FullBlock            // The current block.
+	err     error                 // The error which stopped the iteration.
+	closed  bool                  // True once the iteration is over.
+}
+
+// blockResult is the result of the fetch of a block.
+type blockResult struct {
+	block *FullBlock
+	err   error
+}
+
+// Blocks returns an iterator over the blocks from height `from` to height `to`, both
+// included, fetching up to `parallelism` blocks concurrently.
+//
+// The iteration stops on the first error, which is then returned by Err, or when
+// ctx is cancelled. The heights are resolved to hashes when the blocks are fetched,
+// so a reorganization during the iteration may yield blocks of both branches.
+//
+//     ctx         : The context of the calls, cancelling it stops the iteration.
+//     from        : The height of the first block.
+//     to          : The height of the last block.
+//     parallelism : The maximum number of blocks fetched concurrently, values below 1
+//                   fetch one block at a time.
+func (bic *BlockchainClient) Blocks(ctx context.Context, from uint64, to uint64, parallelism int) *BlockIterator {
+	ctx, cancel := context.WithCancel(ctx)
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	it := &BlockIterator{
+		ctx:     ctx,
+		cancel:  cancel,
+		slots:   make(chan struct{}, parallelism),
+		pending: make(chan chan blockResult, parallelism),
+		next:    from,
+		to:      to,
+	}
+	if from > to {
+		it.err = ErrInvalidBlockRange
+		it.Close()
+		return it
+	}
+
+	go func() {
+		defer close(it.pending)
+		for height := from; ; height++ {
+			select {
+			case it.slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			result := make(chan blockResult, 1)
+			it.pending <- result // Never blocks, slots bounds the pending results.
+			go func(height uint64) {
+				block, err := bic.fetchBlock(ctx, height)
+				result <- blockResult{block: block, err: err}
+			}(height)
+
+			if height == to {
+				return
+			}
+		}
+	}()
+
+	return it
+}
+
+// fetchBlock returns the block at the given height of the active chain.
+func (bic *BlockchainClient) fetchBlock(ctx context.Context, height uint64) (*FullBlock, error) {
+	hash, err := bic.GetBlockHashContext(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return bic.GetFullBlockContext(ctx, hash)
+}
+
+// Next advances the iterator to the next block, which is then available through Block.
+// It returns false when the iteration is over, because all the blocks have been
+// delivered, an error occurred or the context has been cancelled.
+func (it *BlockIterator) Next() bool {
+	if it.closed {
+		return false
+	}
+	if it.done {
+		it.Close()
+		return false
+	}
+
+	result, ok := <-it.pending
+	if !ok {
+		// The prefetch stopped early, because ctx has been cancelled.
+		it.err = it.ctx.Err()
+		it.Close()
+		return false
+	}
+	r := <-result
+	<-it.slots
+	if r.err != nil {
+		it.err = r.err
+		if ctxErr := it.ctx.Err(); ctxErr != nil {
+			it.err = ctxErr
+		}
+		it.Close()
+		return false
+	}
+
+	it.block = r.block
+	// Compare before incrementing, next overflows after the height math.MaxUint64.
+	it.done = it.next == it.to
+	it.next++
+	return true
+}
+
+// Block returns the current block, nil before the first call to Next
+// and after the iteration is over.
+func (it *BlockIterator) Block() *FullBlock {
+	if it.closed {
+		return nil
+	}
+	return it.block
+}
+
+// Err returns the error which stopped the iteration, nil if the iteration is not over,
+// is over because all the blocks have been delivered or has been stopped by Close.
+func (it *BlockIterator) Err() error {
+	return it.err
+}
+
+// Close stops the iteration and the prefetch of the following blocks.
+// It is safe to call it multiple times.
+func (it *BlockIterator) Close() {
+	it.closed = true
+	it.cancel()
+}
@@ -0,0 +1,112 @@
+package syscoinrpc_test
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+	"github.com/thebotguys/golang-syscoin-rpc-client/syscoinrpctest"
+)
+
+// concurrencyTransport records the maximum number of concurrent requests.
+type concurrencyTransport struct {
+	mu      sync.Mutex
+	current int
+	max     int
+}
+
+func (ct *concurrencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ct.mu.Lock()
+	ct.current++
+	if ct.current > ct.max {
+		ct.max = ct.current
+	}
+	ct.mu.Unlock()
+	defer func() {
+		ct.mu.Lock()
+		ct.current--
+		ct.mu.Unlock()
+	}()
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestBlocksInvalid(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	t.Cleanup(srv.Close)
+	srv.Generate(10)
+	cl, err := srv.Client(testTimeout)
+	require.NoError(t, err)
+
+	it := cl.Blockchain.Blocks(context.Background(), 5, 4, 2)
+	require.False(t, it.Next(), "Must not iterate over an invalid range")
+	require.Equal(t, syscoinrpc.ErrInvalidBlockRange, it.Err())
+
+	it = cl.Blockchain.Blocks(context.Background(), 8, 12, 2)
+	var heights []uint64
+	for it.Next() {
+		heights = append(heights, it.Block().Height)
+	}
+	require.Equal(t, []uint64{8, 9, 10}, heights, "Must deliver the blocks before the error")
+	require.True(t, syscoinrpc.IsRPCError(it.Err(), syscoinrpc.RPCInvalidParameter), "Must stop on the first error")
+	require.False(t, it.Next(), "Must stay stopped")
+
+	it = cl.Blockchain.Blocks(context.Background(), 0, math.MaxUint64, 2)
+	heights = nil
+	for it.Next() {
+		heights = append(heights, it.Block().Height)
+	}
+	require.Len(t, heights, 11, "Must iterate over the range of all the heights")
+	require.True(t, syscoinrpc.IsRPCError(it.Err(), syscoinrpc.RPCInvalidParameter), "Must stop after the tip")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it = cl.Blockchain.Blocks(ctx, 0, 10, 3)
+	require.True(t, it.Next())
+	cancel()
+	for it.Next() {
+	}
+	require.Equal(t, context.Canceled, it.Err(), "Must stop on cancellation")
+
+	srv.SetError("getblock", syscoinrpc.RPCMiscError, "Block not available (pruned data)")
+	it = cl.Blockchain.Blocks(context.Background(), 0, 10, 3)
+	require.False(t, it.Next())
+	require.True(t, syscoinrpc.IsRPCError(it.Err(), syscoinrpc.RPCMiscError))
+}
+
+func TestBlocksOK(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	t.Cleanup(srv.Close)
+	hashes := srv.Generate(30)
+
+	transport := &concurrencyTransport{}
+	cl, err := srv.Client(testTimeout, syscoinrpc.WithHTTPClient(&http.Client{Transport: transport}))
+	require.NoError(t, err)
+
+	it := cl.Blockchain.Blocks(context.Background(), 1, 30, 4)
+	defer it.Close()
+	require.Nil(t, it.Block(), "Must have no block before Next")
+	for height := uint64(1); height <= 30; height++ {
+		require.True(t, it.Next(), "Must deliver block %d", height)
+		require.Equal(t, height, it.Block().Height, "Must deliver the blocks in height order")
+		require.Equal(t, hashes[height-1], it.Block().Hash)
+	}
+	require.False(t, it.Next(), "Must stop after the last block")
+	require.NoError(t, it.Err())
+	require.Nil(t, it.Block())
+	require.LessOrEqual(t, transport.max, 4, "Must fetch at most parallelism blocks concurrently")
+
+	it = cl.Blockchain.Blocks(context.Background(), 0, 0, 0)
+	require.True(t, it.Next(), "Must iterate over single block ranges")
+	require.Equal(t, uint64(0), it.Block().Height)
+	require.False(t, it.Next())
+	require.NoError(t, it.Err())
+
+	it = cl.Blockchain.Blocks(context.Background(), 0, 30, 2)
+	require.True(t, it.Next())
+	it.Close()
+	require.False(t, it.Next(), "Must stop when closed")
+	require.NoError(t, it.Err())
+}
@@ -0,0 +1,223 @@
+package syscoinrpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// ErrNoRecordedCall is returned by replay transports for calls missing from the cassette.
+var ErrNoRecordedCall = errors.New("No recorded response for the call")
+
+// cassetteEntry is a recorded call, one JSON line of a cassette.
+type cassetteEntry struct {
+	// Method is the name of the called method.
+	Method string `json:"method"`
+	// Params are the params of the call.
+	Params json.RawMessage `json:"params"`
+	// Result is the raw JSON result, if the call succeeded.
+	Result json.RawMessage `json:"result,omitempty"`
+	// Error is the error returned by the node, if the call failed.
+	Error *RPCError `json:"error,omitempty"`
+}
+
+// cassetteKey returns the key identifying the calls with the given method and params.
+func cassetteKey(method string, params json.RawMessage) string {
+	return method + " " + string(params)
+}
+
+// marshalParams returns the canonical JSON of the params, used to match the calls.
+//
+// The params are encoded then decoded and encoded again, so the keys of structs
+// and maps are sorted alike, whether the params are Go values or recorded JSON.
+func marshalParams(params []interface{}) (json.RawMessage, error) {
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	return canonicalParams(encoded)
+}
+
+// canonicalParams returns the canonical JSON of the encoded params, with
+// the object keys sorted and the numbers kept as written.
+func canonicalParams(encoded json.RawMessage) (json.RawMessage, error) {
+	var params []interface{}
+	paramsDec := json.NewDecoder(bytes.NewReader(encoded))
+	paramsDec.UseNumber() // Keep the numbers as written.
+	err := paramsDec.Decode(&params)
+	if err != nil {
+		return nil, err
+	}
+	if len(params) == 0 {
+		return json.RawMessage("[]"), nil
+	}
+	return json.Marshal(params)
+}
+
+// WithRecorder makes the client write every call it performs, with its raw
+// response, to w as JSON lines, ready to be replayed with NewReplayTransport.
+//
+// Only successful calls and errors returned by the node are recorded,
+// connection and HTTP errors are not.
+func WithRecorder(w io.Writer) ClientOption {
+	return func(opts *clientOptions) error {
+		if w == nil {
+			return ErrInvalidOption
+		}
+		opts.recorder = w
+		return nil
+	}
+}
+
+// recordingTransport records the calls performed through another transport.
+type recordingTransport struct {
+	next Transport  // The transport performing the calls.
+	mu   sync.Mutex // The lock of w, to write whole lines.
+	w    io.Writer  // The cassette the calls are written to.
+}
+
+// Call performs the call through the wrapped transport and records it.
+func (t *recordingTransport) Call(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+	result, err := t.next.Call(ctx, method, params)
+	t.record(method, params, result, err)
+	return result, err
+}
+
+// CallBatch performs the calls through the wrapped transport and records them.
+func (t *recordingTransport) CallBatch(ctx context.Context, calls []*RPCCall) error {
+	err := callBatch(ctx, t.next, calls)
+	if err != nil {
+		return err
+	}
+	for _, call := range calls {
+		t.record(call.Method, call.Params, call.Result, call.Err)
+	}
+	return nil
+}
+
+func (t *recordingTransport) record(method string, params []interface{}, result json.RawMessage, err error) {
+	entry := cassetteEntry{Method: method, Result: result}
+	if err != nil {
+		var rpcErr *RPCError
+		if !errors.As(err, &rpcErr) {
+			return
+		}
+		entry.Result = nil
+		entry.Error = &RPCError{Code: rpcErr.Code, Message: rpcErr.Message}
+	}
+
+	var marshalErr error
+	entry.Params, marshalErr = marshalParams(params)
+	if marshalErr != nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	json.NewEncoder(t.w).Encode(entry)
+}
+
+// ReplayTransport is a Transport answering the calls with the responses
+// of a cassette written by a client created with WithRecorder.
+//
+// Calls are matched by method and params. When the same call was recorded
+// multiple times, the responses are served in the recorded order, the last
+// one being repeated once the others are exhausted.
+type ReplayTransport struct {
+	mu      sync.Mutex                 // The lock of served.
+	entries map[string][]cassetteEntry // The recorded responses, by call key.
+	served  map[string]int             // The number of responses served, by call key.
+}
+
+// NewReplayTransport reads the cassette from r and returns a transport replaying it.
+func NewReplayTransport(r io.Reader) (*ReplayTransport, error) {
+	t := &ReplayTransport{
+		entries: make(map[string][]cassetteEntry),
+		served:  make(map[string]int),
+	}
+
+	dec := json.NewDecoder(bufio.NewReader(r))
+	for {
+		var entry cassetteEntry
+		err := dec.Decode(&entry)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Normalize the params, cassettes can be edited by hand.
+		if len(entry.Params) == 0 {
+			entry.Params = json.RawMessage("[]")
+		}
+		entry.Params, err = canonicalParams(entry.Params)
+		if err != nil {
+			return nil, err
+		}
+
+		key := cassetteKey(entry.Method, entry.Params)
+		t.entries[key] = append(t.entries[key], entry)
+	}
+
+	return t, nil
+}
+
+// Call answers the call with its recorded response.
+func (t *ReplayTransport) Call(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	encodedParams, err := marshalParams(params)
+	if err != nil {
+		return nil, err
+	}
+	key := cassetteKey(method, encodedParams)
+
+	t.mu.Lock()
+	entries := t.entries[key]
+	if len(entries) == 0 {
+		t.mu.Unlock()
+		return nil, fmt.Errorf("%w: %s %s", ErrNoRecordedCall, method, encodedParams)
+	}
+	i := t.served[key]
+	if i >= len(entries) {
+		i = len(entries) - 1
+	} else {
+		t.served[key]++
+	}
+	entry := entries[i]
+	t.mu.Unlock()
+
+	if entry.Error != nil {
+		return nil, &RPCError{Code: entry.Error.Code, Message: entry.Error.Message}
+	}
+	return append(json.RawMessage(nil), entry.Result...), nil
+}
+
+// NewClientFromCassette creates a new client object replaying the cassette file
+// at cassettePath, without connecting to any node.
+//
+//     cassettePath : The path of the file written by a client created with WithRecorder.
+//     options      : Optional settings, see ClientOption.
+func NewClientFromCassette(cassettePath string, options ...ClientOption) (*Client, error) {
+	f, err := os.Open(cassettePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	transport, err := NewReplayTransport(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", cassettePath, err)
+	}
+
+	return NewClientWithTransport(transport, options...)
+}
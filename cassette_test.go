@@ -0,0 +1,133 @@
+package syscoinrpc_test
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+	"github.com/thebotguys/golang-syscoin-rpc-client/syscoinrpctest"
+)
+
+// recordCassettes makes the tests using newCassetteClient record their cassette
+// from the node at LocalNodeURL, instead of replaying it.
+var recordCassettes = flag.Bool("record", false, "record the testdata cassettes from the local node")
+
+// newCassetteClient returns a client replaying the cassette testdata/<name>.jsonl.
+// With -record, the client calls the node at LocalNodeURL, authenticating with
+// RPC_USER and RPC_PASSWORD, and records the cassette again.
+func newCassetteClient(t *testing.T, name string) *syscoinrpc.Client {
+	cassettePath := filepath.Join("testdata", name+".jsonl")
+	if !*recordCassettes {
+		cl, err := syscoinrpc.NewClientFromCassette(cassettePath)
+		require.NoError(t, err, "Must read the cassette, record it with -record")
+		return cl
+	}
+
+	f, err := os.Create(cassettePath)
+	require.NoError(t, err, "Must create the cassette")
+	t.Cleanup(func() { f.Close() })
+
+	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"), testTimeout, syscoinrpc.WithRecorder(f))
+	require.NoError(t, err, "Must have no error on creation")
+	return cl
+}
+
+func TestCassetteInvalid(t *testing.T) {
+	_, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, "", "", syscoinrpc.WithRecorder(nil))
+	require.Equal(t, syscoinrpc.ErrInvalidOption, err, "Must error on nil recorder")
+
+	_, err = syscoinrpc.NewClientFromCassette(filepath.Join(t.TempDir(), "missing.jsonl"))
+	require.Error(t, err, "Must error on missing cassette")
+
+	_, err = syscoinrpc.NewReplayTransport(strings.NewReader(`{"method":"getblockcount",`))
+	require.Error(t, err, "Must error on malformed cassette")
+
+	transport, err := syscoinrpc.NewReplayTransport(strings.NewReader(`{"method":"getblockcount","params":[],"result":1}`))
+	require.NoError(t, err, "Must have no error on valid cassette")
+
+	cl, err := syscoinrpc.NewClientWithTransport(transport)
+	require.NoError(t, err, "Must have no error on creation")
+
+	_, err = cl.Blockchain.GetBestBlockHash()
+	require.True(t, errors.Is(err, syscoinrpc.ErrNoRecordedCall), "Must error on calls missing from the cassette")
+
+	_, err = cl.Blockchain.GetBlockHash(2)
+	require.True(t, errors.Is(err, syscoinrpc.ErrNoRecordedCall), "Must match the params too")
+}
+
+func TestCassetteOK(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	defer srv.Close()
+	srv.Generate(3)
+
+	var cassette bytes.Buffer
+	cl, err := srv.Client(syscoinrpc.WithRecorder(&cassette))
+	require.NoError(t, err, "Must have no error on creation")
+
+	count, err := cl.Blockchain.GetBlockCount()
+	require.NoError(t, err, "GetBlockCount: must not error")
+	hash, err := cl.Blockchain.GetBlockHash(2)
+	require.NoError(t, err, "GetBlockHash: must not error")
+	block, err := cl.Blockchain.GetFullBlock(srv.BlockHash(2))
+	require.NoError(t, err, "GetFullBlock: must not error")
+	_, err = cl.Blockchain.GetBlockHash(100)
+	require.Error(t, err, "GetBlockHash: must error out of range")
+	templateRequest := &syscoinrpc.BlockTemplateRequest{
+		Mode:         "template",
+		Capabilities: []string{"longpoll"},
+		Rules:        []string{"segwit"},
+	}
+	template, err := cl.Mining.GetBlockTemplate(templateRequest)
+	require.NoError(t, err, "GetBlockTemplate: must not error")
+
+	srv.Generate(1)
+	count2, err := cl.Blockchain.GetBlockCount()
+	require.NoError(t, err, "GetBlockCount: must not error")
+
+	batch := cl.NewBatch()
+	batchHash := batch.Blockchain.GetBlockHash(1)
+	require.NoError(t, batch.Send(), "Batch: must not error")
+
+	// Replay the cassette, from a file.
+	cassettePath := filepath.Join(t.TempDir(), "cassette.jsonl")
+	writeTestFile(t, cassettePath, cassette.String())
+	replay, err := syscoinrpc.NewClientFromCassette(cassettePath)
+	require.NoError(t, err, "Must have no error on creation")
+
+	replayedCount, err := replay.Blockchain.GetBlockCount()
+	require.NoError(t, err, "GetBlockCount: must be replayed")
+	require.Equal(t, count, replayedCount)
+	replayedCount, err = replay.Blockchain.GetBlockCount()
+	require.NoError(t, err, "GetBlockCount: must be replayed")
+	require.Equal(t, count2, replayedCount, "Must replay the responses in order")
+	replayedCount, err = replay.Blockchain.GetBlockCount()
+	require.NoError(t, err, "GetBlockCount: must be replayed")
+	require.Equal(t, count2, replayedCount, "Must repeat the last response")
+
+	replayedHash, err := replay.Blockchain.GetBlockHash(2)
+	require.NoError(t, err, "GetBlockHash: must be replayed")
+	require.Equal(t, hash, replayedHash)
+
+	replayedBlock, err := replay.Blockchain.GetFullBlock(srv.BlockHash(2))
+	require.NoError(t, err, "GetFullBlock: must be replayed")
+	require.Equal(t, block, replayedBlock)
+
+	replayedTemplate, err := replay.Mining.GetBlockTemplate(templateRequest)
+	require.NoError(t, err, "GetBlockTemplate: must match struct params")
+	require.Equal(t, template, replayedTemplate)
+
+	_, err = replay.Blockchain.GetBlockHash(100)
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCInvalidParameter), "Must replay the node errors")
+
+	replayedBatchHash, err := replay.Blockchain.GetBlockHash(1)
+	require.NoError(t, err, "GetBlockHash: must replay batched calls")
+	batchHashValue, err := batchHash.Result()
+	require.NoError(t, err)
+	require.Equal(t, batchHashValue, replayedBatchHash)
+}
@@ -1,38 +1,103 @@
 // Package syscoinrpc contains the implementation of a syscoin JSON-RPC client.
 package syscoinrpc
 
-import (
-	"net/http"
-)
+import "errors"
 
 const (
 	// LocalNodeURL represents a valid testnet node URL.
 	LocalNodeURL string = "http://127.0.0.1:8370"
 )
 
-// Client represents a syscoin JSON-RPC over HTTP client.
+var (
+	// ErrInvalidURL is returned when creating a client with a malformed node URL.
+	ErrInvalidURL = errors.New("Invalid node URL: must be an absolute http or https URL")
+	// ErrNilTransport is returned when creating a client without transport.
+	ErrNilTransport = errors.New("Transport must not be nil")
+)
+
+// Client represents a syscoin JSON-RPC client.
 type Client struct {
-	url        string            // The url of the node to connect to.
-	user       string            // The RPC Username.
-	pass       string            // The RPC Password.
-	httpClient *http.Client      // The JSON-RPC over HTTP sub client.
-	Blockchain *BlockchainClient // The client of `blockchain` calls.
-	Control    *ControlClient    // The client of `control` calls.
-	Generating *GeneratingClient // The client of `generating` calls.
+	transport   Transport             // The transport performing the calls.
+	retryPolicy *RetryPolicy          // The retry policy, nil to never retry.
+	Blockchain  *BlockchainClient     // The client of `blockchain` calls.
+	Control     *ControlClient        // The client of `control` calls.
+	Generating  *GeneratingClient     // The client of `generating` calls.
+	Mining      *MiningClient         // The client of `mining` calls.
+	Network     *NetworkClient        // The client of `network` calls.
+	RawTx       *RawTransactionClient // The client of `rawtransactions` calls.
+	Util        *UtilClient           // The client of `util` calls.
 }
 
-// NewClient creates a new client object.
-func NewClient(url string, rpcUser string, rpcPassword string) (*Client, error) {
-	cl := &Client{
-		url:        url,
+// NewClient creates a new client object, speaking JSON-RPC over HTTP with the node.
+//
+// To authenticate with the cookie file of the node, see NewClientFromCookie.
+//
+//     nodeURL     : The absolute URL of the node (e.g. http://127.0.0.1:8370).
+//     rpcUser     : The RPC Username.
+//     rpcPassword : The RPC Password.
+//     options     : Optional settings, see ClientOption.
+func NewClient(nodeURL string, rpcUser string, rpcPassword string, options ...ClientOption) (*Client, error) {
+	return newHTTPClient(nodeURL, rpcUser, rpcPassword, "", options)
+}
+
+// NewClientWithTransport creates a new client object performing
+// the calls through the given transport.
+//
+// Options configuring the HTTP connection (e.g. WithTimeout) are ignored.
+func NewClientWithTransport(transport Transport, options ...ClientOption) (*Client, error) {
+	if transport == nil {
+		return nil, ErrNilTransport
+	}
+
+	opts, err := applyOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return newClient(transport, opts), nil
+}
+
+// newHTTPClient creates a new client object with the default HTTP transport.
+func newHTTPClient(nodeURL string, rpcUser string, rpcPassword string, cookiePath string, options []ClientOption) (*Client, error) {
+	err := checkNodeURL(nodeURL)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := applyOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := &httpTransport{
+		url:        nodeURL,
 		user:       rpcUser,
 		pass:       rpcPassword,
-		httpClient: http.DefaultClient,
+		cookiePath: cookiePath,
+		httpClient: opts.buildHTTPClient(),
+		headers:    opts.headers,
+	}
+
+	return newClient(transport, opts), nil
+}
+
+func newClient(transport Transport, opts *clientOptions) *Client {
+	if opts.recorder != nil {
+		transport = &recordingTransport{next: transport, w: opts.recorder}
+	}
+
+	cl := &Client{
+		transport:   transport,
+		retryPolicy: opts.retryPolicy,
 	}
 
 	cl.Blockchain = &BlockchainClient{cl}
 	cl.Control = &ControlClient{cl}
 	cl.Generating = &GeneratingClient{cl}
+	cl.Mining = &MiningClient{cl}
+	cl.Network = &NetworkClient{cl}
+	cl.RawTx = &RawTransactionClient{cl}
+	cl.Util = &UtilClient{cl}
 
-	return cl, nil
+	return cl
 }
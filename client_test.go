@@ -0,0 +1,101 @@
+package syscoinrpc_test
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+)
+
+func TestContextCancelled(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	cl, err := syscoinrpc.NewClient(srv.URL, "", "")
+	require.NoError(t, err, "Must have no error on creation")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	_, err = cl.Blockchain.GetBlockCountContext(ctx)
+	require.Equal(t, context.Canceled, err, "Must return the context error when cancelled")
+}
+
+func TestContextDeadline(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	cl, err := syscoinrpc.NewClient(srv.URL, "", "")
+	require.NoError(t, err, "Must have no error on creation")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = cl.Blockchain.VerifyChainContext(ctx, 4, 0)
+	require.Equal(t, context.DeadlineExceeded, err, "Must return the context error on deadline")
+}
+
+func TestNewClientInvalid(t *testing.T) {
+	_, err := syscoinrpc.NewClient("127.0.0.1:8370", "", "")
+	require.Equal(t, syscoinrpc.ErrInvalidURL, err, "Must error on URL without scheme")
+
+	_, err = syscoinrpc.NewClient("ftp://127.0.0.1:8370", "", "")
+	require.Equal(t, syscoinrpc.ErrInvalidURL, err, "Must error on non http URL")
+
+	_, err = syscoinrpc.NewClient("http://", "", "")
+	require.Equal(t, syscoinrpc.ErrInvalidURL, err, "Must error on URL without host")
+
+	_, err = syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, "", "", syscoinrpc.WithTimeout(0))
+	require.Equal(t, syscoinrpc.ErrInvalidOption, err, "Must error on invalid options")
+
+	_, err = syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, "", "", syscoinrpc.WithHTTPClient(nil))
+	require.Equal(t, syscoinrpc.ErrInvalidOption, err, "Must error on invalid options")
+
+	_, err = syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, "", "",
+		syscoinrpc.WithHTTPClient(&http.Client{Transport: &concurrencyTransport{}}),
+		syscoinrpc.WithTLSConfig(&tls.Config{}),
+	)
+	require.Equal(t, syscoinrpc.ErrInvalidOption, err, "Must error on TLS config with a custom transport")
+}
+
+func TestNewClientOptionsOK(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "syscoinrpc-test", r.UserAgent(), "Must send the user agent")
+		require.Equal(t, "value", r.Header.Get("X-Custom"), "Must send the custom header")
+		fmt.Fprint(w, `{"result":12,"error":null,"id":"1"}`)
+	}))
+	defer srv.Close()
+
+	httpClient := &http.Client{}
+	cl, err := syscoinrpc.NewClient(srv.URL, "", "",
+		syscoinrpc.WithHTTPClient(httpClient),
+		syscoinrpc.WithTimeout(time.Second),
+		syscoinrpc.WithTLSConfig(&tls.Config{}),
+		syscoinrpc.WithUserAgent("syscoinrpc-test"),
+		syscoinrpc.WithHeader("X-Custom", "value"),
+	)
+	require.NoError(t, err, "Must have no error on creation")
+	require.Zero(t, httpClient.Timeout, "Must not alter the given HTTP client")
+	require.Zero(t, http.DefaultClient.Timeout, "Must not alter the default HTTP client")
+
+	count, err := cl.Blockchain.GetBlockCount()
+	require.NoError(t, err, "GetBlockCount: must not error")
+	require.Equal(t, uint64(12), count)
+}
@@ -0,0 +1,296 @@
+package syscoinrpc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Network names, as used in the sections of the syscoin.conf file.
+const (
+	// MainNet is the main network.
+	MainNet = "main"
+	// TestNet is the test network.
+	TestNet = "test"
+	// RegTest is the regression test network.
+	RegTest = "regtest"
+)
+
+// DefaultRPCPorts are the default RPC ports of the node for every network.
+var DefaultRPCPorts = map[string]uint16{
+	MainNet: 8370,
+	TestNet: 18370,
+	RegTest: 18443,
+}
+
+// networkDataDirs are the sub directories of the datadir where each network
+// stores its data, including the cookie file.
+var networkDataDirs = map[string]string{
+	MainNet: "",
+	TestNet: "testnet3",
+	RegTest: "regtest",
+}
+
+// networkOnlyOptions are the options which, when set outside of
+// a network section, only apply to the main network.
+var networkOnlyOptions = map[string]bool{
+	"rpcport": true,
+	"rpcbind": true,
+	"port":    true,
+	"bind":    true,
+	"addnode": true,
+	"connect": true,
+}
+
+// ErrConfigSyntax is returned when a line of the configuration file is malformed.
+var ErrConfigSyntax = errors.New("Invalid syscoin.conf syntax")
+
+// Config represents the RPC connection settings read from a syscoin.conf file.
+type Config struct {
+	// Network is the selected network, one of MainNet, TestNet or RegTest.
+	Network string
+	// DataDir is the data directory of the node.
+	DataDir string
+	// RPCUser is the RPC Username (`rpcuser`), empty if not set.
+	RPCUser string
+	// RPCPassword is the RPC Password (`rpcpassword`), empty if not set.
+	RPCPassword string
+	// RPCConnect is the host of the node (`rpcconnect`, default 127.0.0.1).
+	RPCConnect string
+	// RPCPort is the RPC port of the node (`rpcport`, default depends on the network).
+	RPCPort uint16
+	// RPCCookieFile is the path of the cookie file (`rpccookiefile`,
+	// default `.cookie` in the network data directory).
+	RPCCookieFile string
+	// ZMQEndpoints are the ZMQ endpoints of the node by topic (`zmqpub<topic>`),
+	// as expected by SubscriberConfig, empty if none is set.
+	ZMQEndpoints map[string]string
+}
+
+// URL returns the URL of the node RPC server.
+func (cfg *Config) URL() string {
+	host := cfg.RPCConnect
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		return "http://" + host
+	}
+	return "http://" + net.JoinHostPort(host, strconv.FormatUint(uint64(cfg.RPCPort), 10))
+}
+
+// confSettings are the raw settings of a configuration file,
+// grouped by section ("" for the top level one) and option name.
+type confSettings map[string]map[string][]string
+
+func (s confSettings) add(section string, key string, value string) {
+	if s[section] == nil {
+		s[section] = make(map[string][]string)
+	}
+	s[section][key] = append(s[section][key], value)
+}
+
+// get returns the value of the option for the network.
+//
+// The network section wins over the top level one, and in every section
+// the first value wins, like the node does.
+func (s confSettings) get(network string, key string) (string, bool) {
+	if values := s[network][key]; len(values) > 0 {
+		return values[0], true
+	}
+	if network != MainNet && networkOnlyOptions[key] {
+		return "", false
+	}
+	if values := s[""][key]; len(values) > 0 {
+		return values[0], true
+	}
+	return "", false
+}
+
+// ParseConfigFile reads the RPC connection settings from a syscoin.conf file.
+//
+// It supports network sections ([main], [test], [regtest]), network prefixed
+// options (e.g. `regtest.rpcport=`), the `testnet` and `regtest` network
+// selectors and `includeconf` (relative paths are resolved from the datadir).
+//
+// The datadir is the `datadir` option if set, else the directory of the file.
+func ParseConfigFile(confPath string) (*Config, error) {
+	settings := make(confSettings)
+	err := parseConfFile(confPath, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	dataDir := filepath.Dir(confPath)
+	if value, ok := settings.get(MainNet, "datadir"); ok {
+		dataDir = value
+	}
+
+	network, err := selectNetwork(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	// includeconf is read from the main file only, included files
+	// cannot include other files.
+	var includes []string
+	includes = append(includes, settings[""]["includeconf"]...)
+	includes = append(includes, settings[network]["includeconf"]...)
+	for _, include := range includes {
+		if !filepath.IsAbs(include) {
+			include = filepath.Join(dataDir, include)
+		}
+		included := make(confSettings)
+		err = parseConfFile(include, included)
+		if err != nil {
+			return nil, err
+		}
+		for section, options := range included {
+			for key, values := range options {
+				if key == "includeconf" {
+					continue
+				}
+				for _, value := range values {
+					settings.add(section, key, value)
+				}
+			}
+		}
+	}
+
+	cfg := &Config{
+		Network:    network,
+		DataDir:    dataDir,
+		RPCConnect: "127.0.0.1",
+		RPCPort:    DefaultRPCPorts[network],
+	}
+	cfg.RPCUser, _ = settings.get(network, "rpcuser")
+	cfg.RPCPassword, _ = settings.get(network, "rpcpassword")
+	if value, ok := settings.get(network, "rpcconnect"); ok && value != "" {
+		cfg.RPCConnect = value
+	}
+	if value, ok := settings.get(network, "rpcport"); ok {
+		port, err := strconv.ParseUint(value, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("%s: rpcport %q: %v", confPath, value, err)
+		}
+		cfg.RPCPort = uint16(port)
+	}
+	cfg.RPCCookieFile = filepath.Join(dataDir, networkDataDirs[network], ".cookie")
+	if value, ok := settings.get(network, "rpccookiefile"); ok && value != "" {
+		cfg.RPCCookieFile = value
+		if !filepath.IsAbs(value) {
+			cfg.RPCCookieFile = filepath.Join(dataDir, networkDataDirs[network], value)
+		}
+	}
+
+	cfg.ZMQEndpoints = make(map[string]string)
+	for _, topic := range []string{TopicHashBlock, TopicHashTx, TopicRawBlock, TopicRawTx} {
+		if value, ok := settings.get(network, "zmqpub"+topic); ok && value != "" {
+			cfg.ZMQEndpoints[topic] = value
+		}
+	}
+
+	return cfg, nil
+}
+
+// selectNetwork returns the network selected by the `testnet` and `regtest` options.
+func selectNetwork(settings confSettings) (string, error) {
+	network := MainNet
+	for _, candidate := range []string{TestNet, RegTest} {
+		key := candidate
+		if candidate == TestNet {
+			key = "testnet"
+		}
+		value, ok := settings.get(MainNet, key)
+		if !ok || !parseConfBool(value) {
+			continue
+		}
+		if network != MainNet {
+			return "", errors.New("Invalid syscoin.conf: testnet and regtest cannot be both set")
+		}
+		network = candidate
+	}
+	return network, nil
+}
+
+// parseConfBool parses a boolean option like the node does,
+// an empty value means true.
+func parseConfBool(value string) bool {
+	if value == "" {
+		return true
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return false
+	}
+	return n != 0
+}
+
+// parseConfFile reads the options of the configuration file into settings.
+func parseConfFile(confPath string, settings confSettings) error {
+	file, err := os.Open(confPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	section := ""
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+
+		i := strings.IndexByte(line, '=')
+		if i < 0 {
+			return fmt.Errorf("%s:%d: %w: %q", confPath, lineNumber, ErrConfigSyntax, line)
+		}
+		key := strings.TrimSpace(line[:i])
+		value := strings.TrimSpace(line[i+1:])
+		key = strings.TrimPrefix(key, "-")
+
+		keySection := section
+		if j := strings.IndexByte(key, '.'); j >= 0 {
+			keySection, key = key[:j], key[j+1:]
+		}
+		if key == "" {
+			return fmt.Errorf("%s:%d: %w: %q", confPath, lineNumber, ErrConfigSyntax, line)
+		}
+
+		settings.add(keySection, key, value)
+	}
+
+	return scanner.Err()
+}
+
+// NewClientFromConfig creates a new client object from the settings of a syscoin.conf file.
+//
+// When rpcuser and rpcpassword are not set, the client authenticates
+// with the cookie file of the node, see NewClientFromCookie.
+//
+//     confPath : The path of the syscoin.conf file.
+//     options  : Optional settings, see ClientOption.
+func NewClientFromConfig(confPath string, options ...ClientOption) (*Client, error) {
+	cfg, err := ParseConfigFile(confPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.RPCUser != "" && cfg.RPCPassword != "" {
+		return NewClient(cfg.URL(), cfg.RPCUser, cfg.RPCPassword, options...)
+	}
+
+	return NewClientFromCookie(cfg.URL(), cfg.RPCCookieFile, options...)
+}
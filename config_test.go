@@ -0,0 +1,115 @@
+package syscoinrpc_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+)
+
+func writeTestFile(t *testing.T, path string, content string) {
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+}
+
+func TestParseConfigFileInvalid(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := syscoinrpc.ParseConfigFile(filepath.Join(dir, "missing.conf"))
+	require.Error(t, err, "Must error on missing file")
+
+	confPath := filepath.Join(dir, "syscoin.conf")
+	writeTestFile(t, confPath, "rpcuser=user\nthis is not valid\n")
+	_, err = syscoinrpc.ParseConfigFile(confPath)
+	require.True(t, errors.Is(err, syscoinrpc.ErrConfigSyntax), "Must error on invalid syntax, got %v", err)
+
+	writeTestFile(t, confPath, "testnet=1\nregtest=1\n")
+	_, err = syscoinrpc.ParseConfigFile(confPath)
+	require.Error(t, err, "Must error when selecting two networks")
+
+	writeTestFile(t, confPath, "rpcport=notaport\n")
+	_, err = syscoinrpc.ParseConfigFile(confPath)
+	require.Error(t, err, "Must error on invalid port")
+}
+
+func TestParseConfigFileOK(t *testing.T) {
+	dir := t.TempDir()
+	confPath := filepath.Join(dir, "syscoin.conf")
+
+	writeTestFile(t, confPath, "rpcuser=user\nrpcpassword=pass\n")
+	cfg, err := syscoinrpc.ParseConfigFile(confPath)
+	require.NoError(t, err, "Must parse a plain file")
+	require.Equal(t, syscoinrpc.MainNet, cfg.Network)
+	require.Equal(t, "user", cfg.RPCUser)
+	require.Equal(t, "pass", cfg.RPCPassword)
+	require.Equal(t, "http://127.0.0.1:8370", cfg.URL())
+	require.Equal(t, filepath.Join(dir, ".cookie"), cfg.RPCCookieFile)
+	require.Empty(t, cfg.ZMQEndpoints)
+
+	writeTestFile(t, filepath.Join(dir, "extra.conf"), "[regtest]\nrpcpassword=included\n")
+	writeTestFile(t, confPath, strings.Join([]string{
+		"# Node configuration",
+		"regtest=1",
+		"rpcuser=user # trailing comment",
+		"rpcport=1234 # main only",
+		"rpcconnect=10.0.0.1",
+		"includeconf=extra.conf",
+		"[test]",
+		"rpcport=5678",
+		"[regtest]",
+		"rpcuser=reguser",
+		"zmqpubhashblock=tcp://127.0.0.1:28332",
+		"zmqpubrawtx=tcp://127.0.0.1:28333",
+	}, "\n"))
+	cfg, err = syscoinrpc.ParseConfigFile(confPath)
+	require.NoError(t, err, "Must parse sections and includes")
+	require.Equal(t, syscoinrpc.RegTest, cfg.Network)
+	require.Equal(t, "reguser", cfg.RPCUser, "Network section must win")
+	require.Equal(t, "included", cfg.RPCPassword, "Must read included files")
+	require.Equal(t, "http://10.0.0.1:18443", cfg.URL(), "Top level rpcport applies to main only")
+	require.Equal(t, filepath.Join(dir, "regtest", ".cookie"), cfg.RPCCookieFile)
+	require.Equal(t, map[string]string{
+		syscoinrpc.TopicHashBlock: "tcp://127.0.0.1:28332",
+		syscoinrpc.TopicRawTx:     "tcp://127.0.0.1:28333",
+	}, cfg.ZMQEndpoints)
+
+	writeTestFile(t, confPath, "testnet=1\ntest.rpcport=5678\n")
+	cfg, err = syscoinrpc.ParseConfigFile(confPath)
+	require.NoError(t, err, "Must parse network prefixed options")
+	require.Equal(t, syscoinrpc.TestNet, cfg.Network)
+	require.Equal(t, uint16(5678), cfg.RPCPort)
+	require.Equal(t, filepath.Join(dir, "testnet3", ".cookie"), cfg.RPCCookieFile)
+}
+
+func TestNewClientFromConfigOK(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		fmt.Fprintf(w, `{"result":"%s:%s","error":null,"id":"1"}`, user, pass)
+	}))
+	defer srv.Close()
+
+	host := strings.TrimPrefix(srv.URL, "http://")
+	dir := t.TempDir()
+	confPath := filepath.Join(dir, "syscoin.conf")
+
+	writeTestFile(t, confPath, "rpcconnect="+host+"\nrpcuser=user\nrpcpassword=pass\n")
+	cl, err := syscoinrpc.NewClientFromConfig(confPath)
+	require.NoError(t, err, "Must create the client with credentials")
+	help, err := cl.Control.GetHelp("")
+	require.NoError(t, err)
+	require.Equal(t, `"user:pass"`, help, "Must authenticate with rpcuser and rpcpassword")
+
+	writeTestFile(t, confPath, "rpcconnect="+host+"\n")
+	writeTestFile(t, filepath.Join(dir, ".cookie"), "__cookie__:token")
+	cl, err = syscoinrpc.NewClientFromConfig(confPath)
+	require.NoError(t, err, "Must fall back to the cookie file")
+	help, err = cl.Control.GetHelp("")
+	require.NoError(t, err)
+	require.Equal(t, `"__cookie__:token"`, help, "Must authenticate with the cookie")
+}
@@ -1,6 +1,7 @@
 package syscoinrpc
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"strconv"
@@ -11,13 +12,18 @@ type ControlClient struct {
 	c *Client // The binded client, must not be nil.
 }
 
-func (cc *ControlClient) do(method string, params ...interface{}) (json.RawMessage, error) {
-	return cc.c.do(method, params...)
+func (cc *ControlClient) do(ctx context.Context, method string, params ...interface{}) (json.RawMessage, error) {
+	return cc.c.do(ctx, method, params...)
 }
 
 // GetHelp returns the help text for the specified command.
 func (cc *ControlClient) GetHelp(commandName string) (string, error) {
-	response, err := cc.do("help", commandName)
+	return cc.GetHelpContext(context.Background(), commandName)
+}
+
+// GetHelpContext is like GetHelp but uses the given context for the call.
+func (cc *ControlClient) GetHelpContext(ctx context.Context, commandName string) (string, error) {
+	response, err := cc.do(ctx, "help", commandName)
 	if err != nil {
 		return "", err
 	}
@@ -50,7 +56,12 @@ type LockedMemoryInfo struct {
 
 // GetMemoryInfo return general information about memory usage.
 func (cc *ControlClient) GetMemoryInfo() (*MemoryInfo, error) {
-	response, err := cc.do("getmemoryinfo")
+	return cc.GetMemoryInfoContext(context.Background())
+}
+
+// GetMemoryInfoContext is like GetMemoryInfo but uses the given context for the call.
+func (cc *ControlClient) GetMemoryInfoContext(ctx context.Context) (*MemoryInfo, error) {
+	response, err := cc.do(ctx, "getmemoryinfo")
 	if err != nil {
 		return nil, err
 	}
@@ -84,6 +95,11 @@ var ErrLoggingFilters = errors.New("Must define include AND exclude fields, or n
 //     "all",  "1" : represent all logging categories.
 //     "none", "0" : even if other logging categories are specified, ignore all of them.
 func (cc *ControlClient) Logging(include []string, exclude []string) (map[string]bool, error) {
+	return cc.LoggingContext(context.Background(), include, exclude)
+}
+
+// LoggingContext is like Logging but uses the given context for the call.
+func (cc *ControlClient) LoggingContext(ctx context.Context, include []string, exclude []string) (map[string]bool, error) {
 	params := make([]interface{}, 0, 2)
 	if include != nil {
 		params = append(params, include)
@@ -94,7 +110,7 @@ func (cc *ControlClient) Logging(include []string, exclude []string) (map[string
 		return nil, ErrLoggingFilters
 	}
 
-	response, err := cc.do("logging", params...)
+	response, err := cc.do(ctx, "logging", params...)
 	if err != nil {
 		return nil, err
 	}
@@ -110,13 +126,23 @@ func (cc *ControlClient) Logging(include []string, exclude []string) (map[string
 
 // StopServer stops the running syscoin server node.
 func (cc *ControlClient) StopServer() error {
-	_, err := cc.do("stop")
+	return cc.StopServerContext(context.Background())
+}
+
+// StopServerContext is like StopServer but uses the given context for the call.
+func (cc *ControlClient) StopServerContext(ctx context.Context) error {
+	_, err := cc.do(ctx, "stop")
 	return err
 }
 
 // GetUptime returns the total uptime of the server.
 func (cc *ControlClient) GetUptime() (uint64, error) {
-	response, err := cc.do("uptime")
+	return cc.GetUptimeContext(context.Background())
+}
+
+// GetUptimeContext is like GetUptime but uses the given context for the call.
+func (cc *ControlClient) GetUptimeContext(ctx context.Context) (uint64, error) {
+	response, err := cc.do(ctx, "uptime")
 	if err != nil {
 		return 0, err
 	}
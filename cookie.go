@@ -0,0 +1,62 @@
+package syscoinrpc
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+)
+
+// CookieUser is the RPC Username written by the node in the cookie file.
+const CookieUser = "__cookie__"
+
+// ErrInvalidCookie is returned when the cookie file is not in the
+// `__cookie__:<token>` format.
+var ErrInvalidCookie = errors.New("Invalid cookie file: expected __cookie__:<token>")
+
+// NewClientFromCookie creates a new client object authenticating with the
+// `.cookie` file the node writes in its datadir when no rpcuser/rpcpassword
+// is set.
+//
+// When the node rejects the credentials (e.g. it has been restarted and the
+// cookie rotated) the file is read again and the call is retried once.
+//
+//     nodeURL    : The absolute URL of the node (e.g. http://127.0.0.1:8370).
+//     cookiePath : The path of the cookie file (e.g. ~/.syscoin/.cookie).
+//     options    : Optional settings, see ClientOption.
+func NewClientFromCookie(nodeURL string, cookiePath string, options ...ClientOption) (*Client, error) {
+	user, pass, err := readCookie(cookiePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return newHTTPClient(nodeURL, user, pass, cookiePath, options)
+}
+
+// readCookie reads the RPC Username and Password from the cookie file.
+func readCookie(cookiePath string) (string, string, error) {
+	content, err := ioutil.ReadFile(cookiePath)
+	if err != nil {
+		return "", "", err
+	}
+
+	parts := strings.SplitN(strings.TrimSpace(string(content)), ":", 2)
+	if len(parts) != 2 || parts[0] != CookieUser || parts[1] == "" {
+		return "", "", ErrInvalidCookie
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// reloadCookie reads the cookie file again and updates the transport credentials.
+func (t *httpTransport) reloadCookie() error {
+	user, pass, err := readCookie(t.cookiePath)
+	if err != nil {
+		return err
+	}
+
+	t.authMu.Lock()
+	t.user, t.pass = user, pass
+	t.authMu.Unlock()
+
+	return nil
+}
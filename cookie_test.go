@@ -0,0 +1,71 @@
+package syscoinrpc_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+)
+
+func TestNewClientFromCookieInvalid(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := syscoinrpc.NewClientFromCookie(syscoinrpc.LocalNodeURL, filepath.Join(dir, ".cookie"))
+	require.Error(t, err, "Must error on missing cookie file")
+
+	cookiePath := filepath.Join(dir, ".cookie")
+	require.NoError(t, ioutil.WriteFile(cookiePath, []byte("user:password"), 0600))
+
+	_, err = syscoinrpc.NewClientFromCookie(syscoinrpc.LocalNodeURL, cookiePath)
+	require.Equal(t, syscoinrpc.ErrInvalidCookie, err, "Must error on invalid cookie file")
+}
+
+func TestNewClientFromCookieOK(t *testing.T) {
+	var token atomic.Value
+	token.Store("first")
+	var requests int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != syscoinrpc.CookieUser || pass != token.Load().(string) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"result":7,"error":null,"id":"1"}`)
+	}))
+	defer srv.Close()
+
+	cookiePath := filepath.Join(t.TempDir(), ".cookie")
+	require.NoError(t, ioutil.WriteFile(cookiePath, []byte("__cookie__:first\n"), 0600))
+
+	cl, err := syscoinrpc.NewClientFromCookie(srv.URL, cookiePath)
+	require.NoError(t, err, "Must have no error on creation")
+
+	_, err = cl.Blockchain.GetBlockCount()
+	require.NoError(t, err, "GetBlockCount: must authenticate with the cookie")
+
+	// Simulate a node restart, rotating the cookie.
+	token.Store("second")
+	require.NoError(t, ioutil.WriteFile(cookiePath, []byte("__cookie__:second\n"), 0600))
+	atomic.StoreInt32(&requests, 0)
+
+	count, err := cl.Blockchain.GetBlockCount()
+	require.NoError(t, err, "GetBlockCount: must reload the cookie and retry")
+	require.Equal(t, uint64(7), count)
+	require.Equal(t, int32(2), atomic.LoadInt32(&requests), "Must retry only once")
+
+	// The node rejects the credentials, the cookie is still stale.
+	token.Store("third")
+	atomic.StoreInt32(&requests, 0)
+
+	_, err = cl.Blockchain.GetBlockCount()
+	require.True(t, syscoinrpc.IsUnauthorized(err), "Must report the authentication failure")
+	require.Equal(t, int32(2), atomic.LoadInt32(&requests), "Must retry only once")
+}
@@ -0,0 +1,510 @@
+package syscoinrpc
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrMalformedRawData is returned when decoding a truncated or malformed
+// serialized block or transaction.
+var ErrMalformedRawData = errors.New("Malformed serialized block or transaction")
+
+// blockVersionAuxPow is the flag of the block version set on merge mined
+// blocks, whose header is followed by the AuxPoW data.
+const blockVersionAuxPow = 1 << 8
+
+// blockHeaderSize is the size of a serialized block header.
+const blockHeaderSize = 80
+
+// DecodeTransaction decodes a serialized transaction, as returned by a non verbose
+// `getrawtransaction` call, into the same struct returned by verbose calls.
+//
+// Transactions without inputs look like segwit ones, they are decoded without
+// witness when the segwit decoding fails, like the node does.
+//
+//     rawTx   : The serialized transaction.
+//     network : The network of the transaction, used to encode the addresses
+//               (MainNet, TestNet or RegTest).
+func DecodeTransaction(rawTx []byte, network string) (*Transaction, error) {
+	params, ok := networkAddressParams[network]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownNetwork, network)
+	}
+
+	r := &wireReader{data: rawTx}
+	tx := r.transaction(params)
+	r.end()
+	if r.err != nil {
+		legacy := &wireReader{data: rawTx, noWitness: true}
+		legacyTx := legacy.transaction(params)
+		legacy.end()
+		if legacy.err != nil {
+			return nil, r.err
+		}
+		return legacyTx, nil
+	}
+
+	return tx, nil
+}
+
+// DecodeTransactionHex is like DecodeTransaction but takes the hex encoded transaction.
+func DecodeTransactionHex(rawTxHex string, network string) (*Transaction, error) {
+	rawTx, err := hex.DecodeString(rawTxHex)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeTransaction(rawTx, network)
+}
+
+// DecodeBlock decodes a serialized block, as returned by GetBlock, into the same
+// struct returned by GetBlockWithTransactions, including the AuxPoW data of
+// merge mined blocks.
+//
+// The fields that depend on the chain state and not on the block itself
+// (Confirmations, Height, MedianTime, ChainWork and NextBlockHash) are left zero.
+//
+//     rawBlock : The serialized block.
+//     network  : The network of the block, used to encode the addresses
+//                (MainNet, TestNet or RegTest).
+func DecodeBlock(rawBlock []byte, network string) (*BlockWithTransactions, error) {
+	params, ok := networkAddressParams[network]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownNetwork, network)
+	}
+
+	r := &wireReader{data: rawBlock}
+	header := r.blockHeader()
+	block := &BlockWithTransactions{
+		FullBlockHeader: header,
+		Size:            uint64(len(rawBlock)),
+	}
+	if r.err == nil && header.Version&blockVersionAuxPow != 0 {
+		block.AuxPow = r.auxPow(params)
+	}
+	count := r.count()
+	for i := uint64(0); i < count && r.err == nil; i++ {
+		block.Tx = append(block.Tx, r.transaction(params))
+	}
+	r.end()
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return block, nil
+}
+
+// DecodeBlockHex is like DecodeBlock but takes the hex encoded block.
+func DecodeBlockHex(rawBlockHex string, network string) (*BlockWithTransactions, error) {
+	rawBlock, err := hex.DecodeString(rawBlockHex)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeBlock(rawBlock, network)
+}
+
+// DecodeBlockHeader decodes a serialized block header, as returned by
+// GetBlockHeader. The fields that depend on the chain state are left zero.
+func DecodeBlockHeader(rawHeader []byte) (*FullBlockHeader, error) {
+	r := &wireReader{data: rawHeader}
+	header := r.blockHeader()
+	r.end()
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return header, nil
+}
+
+// DecodeBlockHeaderHex is like DecodeBlockHeader but takes the hex encoded header.
+func DecodeBlockHeaderHex(rawHeaderHex string) (*FullBlockHeader, error) {
+	rawHeader, err := hex.DecodeString(rawHeaderHex)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeBlockHeader(rawHeader)
+}
+
+// DecodeBlockHeaders decodes a stream of serialized block headers, as returned by
+// RESTClient.GetBlockHeadersBinary. The AuxPoW data following the headers of merge
+// mined blocks is skipped. The fields that depend on the chain state are left zero.
+func DecodeBlockHeaders(rawHeaders []byte) ([]*FullBlockHeader, error) {
+	r := &wireReader{data: rawHeaders}
+	headers := []*FullBlockHeader{}
+	for r.err == nil && r.pos < len(r.data) {
+		header := r.blockHeader()
+		if r.err == nil && header.Version&blockVersionAuxPow != 0 {
+			r.auxPow(networkAddressParams[MainNet])
+		}
+		headers = append(headers, header)
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return headers, nil
+}
+
+// DecodeBlockHeadersHex is like DecodeBlockHeaders but takes the hex encoded stream.
+func DecodeBlockHeadersHex(rawHeadersHex string) ([]*FullBlockHeader, error) {
+	rawHeaders, err := hex.DecodeString(rawHeadersHex)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeBlockHeaders(rawHeaders)
+}
+
+// DecodeAuxPow decodes a serialized AuxPoW, as returned by AuxPow.Serialize
+// and following the header of merge mined blocks.
+//
+//     rawAuxPow : The serialized AuxPoW.
+//     network   : The network of the merge mined block, used to encode the addresses
+//                 of the coinbase (MainNet, TestNet or RegTest).
+func DecodeAuxPow(rawAuxPow []byte, network string) (*AuxPow, error) {
+	params, ok := networkAddressParams[network]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownNetwork, network)
+	}
+
+	r := &wireReader{data: rawAuxPow}
+	auxPow := r.auxPow(params)
+	r.end()
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return &auxPow, nil
+}
+
+// DecodeAuxPowHex is like DecodeAuxPow but takes the hex encoded AuxPoW.
+func DecodeAuxPowHex(rawAuxPowHex string, network string) (*AuxPow, error) {
+	rawAuxPow, err := hex.DecodeString(rawAuxPowHex)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeAuxPow(rawAuxPow, network)
+}
+
+// DecodeScript decodes a script, like a `decodescript` call does.
+//
+//     script  : The script, e.g. an output script or a redeem script.
+//     network : The network of the script, used to encode the addresses
+//               (MainNet, TestNet or RegTest).
+func DecodeScript(script []byte, network string) (*DecodedScript, error) {
+	params, ok := networkAddressParams[network]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownNetwork, network)
+	}
+	return decodeScript(script, params), nil
+}
+
+// DecodeScriptHex is like DecodeScript but takes the hex encoded script.
+func DecodeScriptHex(scriptHex string, network string) (*DecodedScript, error) {
+	script, err := hex.DecodeString(scriptHex)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeScript(script, network)
+}
+
+// DecodeUTXOSet decodes a serialized `getutxos` response, as returned by
+// RESTClient.GetUTXOsBinary, into the same UTXOSet as RESTClient.GetUTXOs,
+// except that the Bitmap is padded with '0' to a multiple of 8 outpoints.
+func DecodeUTXOSet(rawSet []byte, network string) (*UTXOSet, error) {
+	params, ok := networkAddressParams[network]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownNetwork, network)
+	}
+
+	r := &wireReader{data: rawSet}
+	set := &UTXOSet{
+		ChainHeight:  uint64(r.uint32()),
+		ChainTipHash: r.hash(),
+		UTXOs:        []*UTXO{},
+	}
+	bitmap := r.varBytes()
+	count := r.count()
+	for i := uint64(0); i < count && r.err == nil; i++ {
+		r.uint32() // Unused transaction version.
+		utxo := &UTXO{Height: uint64(r.uint32())}
+		out := r.output(0, params)
+		utxo.Value = out.Value
+		utxo.ScriptPubKey = out.ScriptPubKey
+		set.UTXOs = append(set.UTXOs, utxo)
+	}
+	r.end()
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	// The bitmap has a bit per queried outpoint, least significant bit first.
+	var bits strings.Builder
+	for i := 0; i < len(bitmap)*8; i++ {
+		if bitmap[i/8]&(1<<uint(i%8)) != 0 {
+			bits.WriteByte('1')
+		} else {
+			bits.WriteByte('0')
+		}
+	}
+	set.Bitmap = bits.String()
+
+	return set, nil
+}
+
+// wireReader reads the wire format of blocks and transactions.
+//
+// The first error is kept in err, and makes every following read a no-op
+// returning zero values, so that errors can be checked once at the end.
+type wireReader struct {
+	data      []byte
+	pos       int
+	err       error
+	noWitness bool // True to read transactions without the segwit marker and flags.
+}
+
+func (r *wireReader) fail(format string, args ...interface{}) {
+	if r.err == nil {
+		r.err = fmt.Errorf("%w: "+format, append([]interface{}{ErrMalformedRawData}, args...)...)
+	}
+}
+
+// bytes returns the next n bytes, without copying them.
+func (r *wireReader) bytes(n uint64) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n > uint64(len(r.data)-r.pos) {
+		r.fail("unexpected end of data at offset %d", r.pos)
+		return nil
+	}
+	b := r.data[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+	return b
+}
+
+func (r *wireReader) uint8() uint8 {
+	if b := r.bytes(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *wireReader) uint32() uint32 {
+	if b := r.bytes(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *wireReader) uint64() uint64 {
+	if b := r.bytes(8); b != nil {
+		return binary.LittleEndian.Uint64(b)
+	}
+	return 0
+}
+
+func (r *wireReader) hash() Hash {
+	var h Hash
+	copy(h[:], r.bytes(HashSize))
+	return h
+}
+
+// varInt reads a compact size integer.
+func (r *wireReader) varInt() uint64 {
+	switch prefix := r.uint8(); prefix {
+	case 0xfd:
+		if b := r.bytes(2); b != nil {
+			return uint64(binary.LittleEndian.Uint16(b))
+		}
+		return 0
+	case 0xfe:
+		return uint64(r.uint32())
+	case 0xff:
+		return r.uint64()
+	default:
+		return uint64(prefix)
+	}
+}
+
+// count reads the number of elements of a vector, checking that it fits
+// in the remaining data since every element takes at least one byte.
+func (r *wireReader) count() uint64 {
+	n := r.varInt()
+	if r.err == nil && n > uint64(len(r.data)-r.pos) {
+		r.fail("vector size %d at offset %d exceeds the data size", n, r.pos)
+		return 0
+	}
+	return n
+}
+
+func (r *wireReader) varBytes() []byte {
+	return r.bytes(r.count())
+}
+
+func (r *wireReader) hashes() []Hash {
+	n := r.count()
+	hashes := make([]Hash, 0, n)
+	for i := uint64(0); i < n && r.err == nil; i++ {
+		hashes = append(hashes, r.hash())
+	}
+	return hashes
+}
+
+// end checks that all the data has been read.
+func (r *wireReader) end() {
+	if r.err == nil && r.pos != len(r.data) {
+		r.fail("%d trailing bytes", len(r.data)-r.pos)
+	}
+}
+
+func (r *wireReader) blockHeader() *FullBlockHeader {
+	raw := r.bytes(blockHeaderSize)
+	if raw == nil {
+		return nil
+	}
+
+	header := &FullBlockHeader{Hash: doubleSHA256(raw)}
+	hr := &wireReader{data: raw}
+	version := hr.uint32()
+	header.Version = uint64(version)
+	header.VersionHex = fmt.Sprintf("%08x", version)
+	header.PreviousBlockHash = hr.hash()
+	header.MerkleRoot = hr.hash()
+	header.Time = uint64(hr.uint32())
+	bits := hr.uint32()
+	header.Bits = fmt.Sprintf("%08x", bits)
+	header.Difficulty = difficulty(bits)
+	header.Nonce = uint64(hr.uint32())
+	return header
+}
+
+// auxPow reads the AuxPoW data following the header of merge mined blocks.
+func (r *wireReader) auxPow(params addressParams) AuxPow {
+	var auxPow AuxPow
+	if tx := r.transaction(params); tx != nil {
+		auxPow.Tx = *tx
+	}
+	auxPow.Tx.BlockHash = r.hash()
+	auxPow.MerkleBranch = r.hashes()
+	auxPow.Index = uint64(r.uint32())
+	auxPow.ChainMerkleBranch = r.hashes()
+	auxPow.ChainIndex = uint64(r.uint32())
+	auxPow.ParentBlock = hex.EncodeToString(r.bytes(blockHeaderSize))
+	return auxPow
+}
+
+func (r *wireReader) transaction(params addressParams) *Transaction {
+	start := r.pos
+	tx := &Transaction{Version: uint64(r.uint32())}
+
+	// Segwit transactions have an empty vin followed by a non zero flags byte.
+	var flags uint8
+	bodyStart := r.pos
+	count := r.count()
+	if count == 0 && r.err == nil && !r.noWitness {
+		flags = r.uint8()
+		if flags != 0 {
+			bodyStart = r.pos
+			count = r.count()
+		}
+	}
+	tx.Vin = make([]VinObject, 0, count)
+	for i := uint64(0); i < count && r.err == nil; i++ {
+		tx.Vin = append(tx.Vin, r.input())
+	}
+	tx.Vout = []VoutObject{}
+	if count > 0 || flags != 0 || r.noWitness {
+		count = r.count()
+		tx.Vout = make([]VoutObject, 0, count)
+		for i := uint64(0); i < count && r.err == nil; i++ {
+			tx.Vout = append(tx.Vout, r.output(uint32(i), params))
+		}
+	}
+	bodyEnd := r.pos
+
+	if flags&1 != 0 {
+		flags ^= 1
+		hasWitness := false
+		for i := range tx.Vin {
+			n := r.count()
+			for j := uint64(0); j < n && r.err == nil; j++ {
+				tx.Vin[i].Witness = append(tx.Vin[i].Witness, hex.EncodeToString(r.varBytes()))
+				hasWitness = true
+			}
+		}
+		if !hasWitness {
+			r.fail("superfluous witness record")
+		}
+	}
+	if flags != 0 {
+		r.fail("unknown transaction optional data")
+	}
+	lockTimeStart := r.pos
+	tx.LockTime = uint64(r.uint32())
+	if r.err != nil {
+		return nil
+	}
+
+	raw := r.data[start:r.pos]
+	stripped := make([]byte, 0, 4+bodyEnd-bodyStart+4)
+	stripped = append(stripped, r.data[start:start+4]...)
+	stripped = append(stripped, r.data[bodyStart:bodyEnd]...)
+	stripped = append(stripped, r.data[lockTimeStart:r.pos]...)
+
+	tx.Hex = hex.EncodeToString(raw)
+	tx.TxID = doubleSHA256(stripped)
+	tx.Hash = doubleSHA256(raw)
+	tx.Size = uint64(len(raw))
+	weight := uint64(len(stripped))*3 + uint64(len(raw))
+	tx.VSize = (weight + 3) / 4
+
+	if len(tx.Vin) == 1 && tx.Vin[0].TxID.IsZero() && tx.Vin[0].Vout == math.MaxUint32 {
+		tx.Vin[0].Coinbase = tx.Vin[0].ScriptSig.Hex
+		tx.Vin[0].TxID = Hash{}
+		tx.Vin[0].Vout = 0
+		tx.Vin[0].ScriptSig = nil
+	}
+	return tx
+}
+
+func (r *wireReader) input() VinObject {
+	vin := VinObject{
+		TxID: r.hash(),
+		Vout: r.uint32(),
+	}
+	script := r.varBytes()
+	vin.ScriptSig = &ScriptSig{
+		Asm: scriptToAsm(script, true),
+		Hex: hex.EncodeToString(script),
+	}
+	vin.Sequence = uint64(r.uint32())
+	return vin
+}
+
+func (r *wireReader) output(n uint32, params addressParams) VoutObject {
+	vout := VoutObject{
+		Value: Amount(r.uint64()),
+		N:     n,
+	}
+	vout.ScriptPubKey = decodeScriptPubKey(r.varBytes(), params)
+	return vout
+}
+
+// difficulty returns the difficulty of the compact target bits, like the node does.
+func difficulty(bits uint32) float64 {
+	shift := int(bits>>24) & 0xff
+	diff := float64(0x0000ffff) / float64(bits&0x00ffffff)
+	for ; shift < 29; shift++ {
+		diff *= 256.0
+	}
+	for ; shift > 29; shift-- {
+		diff /= 256.0
+	}
+	// The node prints doubles with 16 significant digits.
+	diff, _ = strconv.ParseFloat(strconv.FormatFloat(diff, 'g', 16, 64), 64)
+	return diff
+}
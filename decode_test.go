@@ -0,0 +1,283 @@
+package syscoinrpc_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+)
+
+// testWitnessTx builds a segwit transaction spending a P2WPKH output to a
+// P2PKH output, and returns it with its serialization without the witness.
+func testWitnessTx() (raw []byte, stripped []byte) {
+	body := new(bytes.Buffer)
+	body.WriteByte(1)                          // vin count
+	body.Write(bytes.Repeat([]byte{0x11}, 32)) // prevout hash
+	binary.Write(body, binary.LittleEndian, uint32(1))
+	body.WriteByte(0) // empty scriptSig
+	binary.Write(body, binary.LittleEndian, uint32(0xfffffffe))
+	body.WriteByte(2) // vout count
+	binary.Write(body, binary.LittleEndian, int64(150000000))
+	body.Write(append([]byte{0x19, 0x76, 0xa9, 0x14}, append(bytes.Repeat([]byte{0x22}, 20), 0x88, 0xac)...))
+	binary.Write(body, binary.LittleEndian, int64(0))
+	body.Write(append([]byte{0x16, 0x00, 0x14}, bytes.Repeat([]byte{0x33}, 20)...))
+
+	witness := append([]byte{2, 3, 0xaa, 0xbb, 0xcc, 33, 0x02}, bytes.Repeat([]byte{0x44}, 32)...)
+	lockTime := []byte{0x65, 0, 0, 0}
+	version := []byte{2, 0, 0, 0}
+
+	raw = append(append(append(append(append([]byte{}, version...), 0, 1), body.Bytes()...), witness...), lockTime...)
+	stripped = append(append(append([]byte{}, version...), body.Bytes()...), lockTime...)
+	return raw, stripped
+}
+
+func doubleSHA256(data []byte) syscoinrpc.Hash {
+	first := sha256.Sum256(data)
+	return sha256.Sum256(first[:])
+}
+
+func TestDecodeTransactionInvalid(t *testing.T) {
+	raw, _ := testWitnessTx()
+
+	_, err := syscoinrpc.DecodeTransaction(raw, "bitcoin")
+	require.True(t, errors.Is(err, syscoinrpc.ErrUnknownNetwork), "Must error on unknown networks")
+
+	_, err = syscoinrpc.DecodeTransaction(raw[:len(raw)-1], syscoinrpc.RegTest)
+	require.True(t, errors.Is(err, syscoinrpc.ErrMalformedRawData), "Must error on truncated transactions")
+
+	_, err = syscoinrpc.DecodeTransaction(append(raw, 0), syscoinrpc.RegTest)
+	require.True(t, errors.Is(err, syscoinrpc.ErrMalformedRawData), "Must error on trailing data")
+
+	witnessStart := len(raw) - 4 - 39
+	superfluous := append(append(append([]byte{}, raw[:witnessStart]...), 0), raw[len(raw)-4:]...)
+	_, err = syscoinrpc.DecodeTransaction(superfluous, syscoinrpc.RegTest)
+	require.True(t, errors.Is(err, syscoinrpc.ErrMalformedRawData), "Must error on empty witness data")
+
+	huge := []byte{1, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	_, err = syscoinrpc.DecodeTransaction(huge, syscoinrpc.RegTest)
+	require.True(t, errors.Is(err, syscoinrpc.ErrMalformedRawData), "Must error on oversized vectors")
+
+	_, err = syscoinrpc.DecodeTransactionHex("zz", syscoinrpc.RegTest)
+	require.Error(t, err, "Must error on malformed hex")
+}
+
+func TestDecodeTransactionOK(t *testing.T) {
+	expected := testBlock.AuxPow.Tx
+	expected.BlockHash = syscoinrpc.Hash{}
+	expected.Hash = expected.TxID
+	expected.VSize = expected.Size
+
+	tx, err := syscoinrpc.DecodeTransactionHex(expected.Hex, syscoinrpc.TestNet)
+	require.NoError(t, err, "Must decode the AuxPoW coinbase returned by the node")
+	require.Equal(t, expected, *tx, "Must decode as the node does")
+
+	raw, stripped := testWitnessTx()
+	tx, err = syscoinrpc.DecodeTransaction(raw, syscoinrpc.RegTest)
+	require.NoError(t, err, "Must decode witness transactions")
+	require.Equal(t, doubleSHA256(stripped), tx.TxID, "TxID must not commit to the witness")
+	require.Equal(t, doubleSHA256(raw), tx.Hash, "Hash must commit to the witness")
+	require.Equal(t, uint64(len(raw)), tx.Size)
+	require.Equal(t, uint64(len(stripped)*3+len(raw)+3)/4, tx.VSize)
+	require.Equal(t, uint64(2), tx.Version)
+	require.Equal(t, uint64(0x65), tx.LockTime)
+
+	require.Len(t, tx.Vin, 1)
+	require.False(t, tx.Vin[0].IsCoinbase())
+	require.Equal(t, "1111111111111111111111111111111111111111111111111111111111111111", tx.Vin[0].TxID.String())
+	require.Equal(t, uint32(1), tx.Vin[0].Vout)
+	require.Equal(t, &syscoinrpc.ScriptSig{}, tx.Vin[0].ScriptSig)
+	require.Equal(t, []string{"aabbcc", "02" + strings.Repeat("44", 32)}, tx.Vin[0].Witness)
+	require.Equal(t, uint64(0xfffffffe), tx.Vin[0].Sequence)
+
+	require.Len(t, tx.Vout, 2)
+	require.Equal(t, syscoinrpc.Amount(150000000), tx.Vout[0].Value)
+	require.Equal(t, syscoinrpc.ScriptTypePubKeyHash, tx.Vout[0].ScriptPubKey.Type)
+	require.Equal(t, "OP_DUP OP_HASH160 "+strings.Repeat("22", 20)+" OP_EQUALVERIFY OP_CHECKSIG", tx.Vout[0].ScriptPubKey.Asm)
+	require.Equal(t, uint64(1), tx.Vout[0].ScriptPubKey.RequiredSignatures)
+	require.Len(t, tx.Vout[0].ScriptPubKey.Addresses, 1)
+	require.Equal(t, uint32(1), tx.Vout[1].N)
+	require.Equal(t, syscoinrpc.ScriptTypeWitnessV0KeyHash, tx.Vout[1].ScriptPubKey.Type)
+	require.Equal(t, "0 "+strings.Repeat("33", 20), tx.Vout[1].ScriptPubKey.Asm)
+	require.Len(t, tx.Vout[1].ScriptPubKey.Addresses, 1)
+	require.True(t, strings.HasPrefix(tx.Vout[1].ScriptPubKey.Addresses[0], "scrt1q"), "Must encode regtest bech32 addresses")
+
+	mainTx, err := syscoinrpc.DecodeTransaction(raw, syscoinrpc.MainNet)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(mainTx.Vout[0].ScriptPubKey.Addresses[0], "S"), "Must encode main net addresses")
+	require.True(t, strings.HasPrefix(mainTx.Vout[1].ScriptPubKey.Addresses[0], "sys1q"), "Must encode main net bech32 addresses")
+
+	noInputs := append(append([]byte{2, 0, 0, 0, 0, 1}, make([]byte, 8)...), 1, 0x51, 0, 0, 0, 0)
+	tx, err = syscoinrpc.DecodeTransaction(noInputs, syscoinrpc.RegTest)
+	require.NoError(t, err, "Must decode transactions without inputs, like created ones")
+	require.Empty(t, tx.Vin)
+	require.Len(t, tx.Vout, 1)
+	require.Equal(t, doubleSHA256(noInputs), tx.TxID)
+}
+
+func TestDecodeBlockInvalid(t *testing.T) {
+	_, err := syscoinrpc.DecodeBlock(make([]byte, 79), syscoinrpc.RegTest)
+	require.True(t, errors.Is(err, syscoinrpc.ErrMalformedRawData), "Must error on truncated headers")
+
+	_, err = syscoinrpc.DecodeBlock(make([]byte, 81), syscoinrpc.RegTest)
+	require.NoError(t, err, "A header with no transactions is a valid block")
+
+	_, err = syscoinrpc.DecodeBlock(make([]byte, 82), syscoinrpc.RegTest)
+	require.True(t, errors.Is(err, syscoinrpc.ErrMalformedRawData), "Must error on trailing data")
+
+	_, err = syscoinrpc.DecodeBlockHeader(make([]byte, 81))
+	require.True(t, errors.Is(err, syscoinrpc.ErrMalformedRawData), "Must error on trailing data")
+
+	_, err = syscoinrpc.DecodeBlock(make([]byte, 81), "")
+	require.True(t, errors.Is(err, syscoinrpc.ErrUnknownNetwork), "Must error on unknown networks")
+}
+
+func TestDecodeBlockOK(t *testing.T) {
+	cl := newFakeClient(t)
+
+	hash, err := cl.Blockchain.GetBlockHash(3)
+	require.NoError(t, err)
+	raw, err := cl.Blockchain.GetBlock(hash)
+	require.NoError(t, err, "GetBlock: must not error")
+
+	block, err := syscoinrpc.DecodeBlockHex(raw, syscoinrpc.RegTest)
+	require.NoError(t, err, "Must decode the blocks returned by the node")
+	expected, err := cl.Blockchain.GetFullBlock(hash)
+	require.NoError(t, err)
+	require.Len(t, block.Tx, len(expected.Tx))
+	for i, tx := range block.Tx {
+		require.Equal(t, expected.Tx[i], tx.TxID, "Must compute the transaction IDs as the node does")
+	}
+	require.Equal(t, expected.Size, block.Size)
+	require.Equal(t, expected.Hash, block.Hash)
+	require.Equal(t, expected.Version, block.Version)
+	require.Equal(t, expected.VersionHex, block.VersionHex)
+	require.Equal(t, expected.MerkleRoot, block.MerkleRoot)
+	require.Equal(t, expected.Time, block.Time)
+	require.Equal(t, expected.Nonce, block.Nonce)
+	require.Equal(t, expected.Bits, block.Bits)
+	require.Equal(t, expected.Difficulty, block.Difficulty)
+	require.Equal(t, expected.PreviousBlockHash, block.PreviousBlockHash)
+	require.True(t, block.Tx[0].Vin[0].IsCoinbase())
+
+	rawHeader, err := cl.Blockchain.GetBlockHeader(hash)
+	require.NoError(t, err, "GetBlockHeader: must not error")
+	header, err := syscoinrpc.DecodeBlockHeaderHex(rawHeader)
+	require.NoError(t, err)
+	require.Equal(t, block.FullBlockHeader, header)
+}
+
+func TestDecodeBlockAuxPowOK(t *testing.T) {
+	// The header and AuxPoW data of testBlock, followed by the AuxPoW coinbase
+	// standing for the transactions of the block.
+	var raw bytes.Buffer
+	binary.Write(&raw, binary.LittleEndian, uint32(testBlockHeader.Version))
+	raw.Write(testBlockHeader.PreviousBlockHash.Bytes())
+	raw.Write(testBlockHeader.MerkleRoot.Bytes())
+	binary.Write(&raw, binary.LittleEndian, uint32(testBlockHeader.Time))
+	raw.Write([]byte{0xff, 0xff, 0x7f, 0x20})
+	binary.Write(&raw, binary.LittleEndian, uint32(testBlockHeader.Nonce))
+	auxPowTx, err := hex.DecodeString(testBlock.AuxPow.Tx.Hex)
+	require.NoError(t, err)
+	raw.Write(auxPowTx)
+	raw.Write(testBlock.AuxPow.Tx.BlockHash.Bytes())
+	raw.Write([]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0})
+	parentBlock, err := hex.DecodeString(testBlock.AuxPow.ParentBlock)
+	require.NoError(t, err)
+	raw.Write(parentBlock)
+	raw.WriteByte(1)
+	raw.Write(auxPowTx)
+
+	block, err := syscoinrpc.DecodeBlock(raw.Bytes(), syscoinrpc.TestNet)
+	require.NoError(t, err, "Must decode merge mined blocks")
+	require.Equal(t, testBlockHeader.Hash, block.Hash, "Must hash the header as the node does")
+	require.Equal(t, testBlockHeader.VersionHex, block.VersionHex)
+	require.Equal(t, testBlockHeader.Bits, block.Bits)
+	require.Equal(t, testBlockHeader.Difficulty, block.Difficulty)
+	require.Equal(t, testBlockHeader.MerkleRoot, block.MerkleRoot)
+	require.Equal(t, testBlockHeader.PreviousBlockHash, block.PreviousBlockHash)
+	require.Equal(t, uint64(raw.Len()), block.Size)
+
+	expected := testBlock.AuxPow
+	expected.Tx.Hash = expected.Tx.TxID
+	expected.Tx.VSize = expected.Tx.Size
+	require.Equal(t, expected, block.AuxPow, "Must decode the AuxPoW data as the node does")
+	require.Equal(t, expected.Tx.BlockHash, doubleSHA256(parentBlock), "Must link the parent block")
+	require.Len(t, block.Tx, 1)
+
+	// The binary header stream has the AuxPoW data after every merge mined header.
+	header := raw.Bytes()[:raw.Len()-1-len(auxPowTx)]
+	headers, err := syscoinrpc.DecodeBlockHeaders(append(append([]byte(nil), header...), header...))
+	require.NoError(t, err, "Must decode merge mined header streams")
+	require.Len(t, headers, 2)
+	require.Equal(t, testBlockHeader.Hash, headers[1].Hash)
+	_, err = syscoinrpc.DecodeBlockHeaders(header[:len(header)-1])
+	require.True(t, errors.Is(err, syscoinrpc.ErrMalformedRawData), "Must error on truncated streams, got %v", err)
+}
+
+func TestDecodeScriptInvalid(t *testing.T) {
+	_, err := syscoinrpc.DecodeScript([]byte{0x51}, "bitcoin")
+	require.True(t, errors.Is(err, syscoinrpc.ErrUnknownNetwork), "Must error on unknown networks")
+
+	_, err = syscoinrpc.DecodeScriptHex("zz", syscoinrpc.RegTest)
+	require.Error(t, err, "Must error on malformed hex")
+}
+
+func TestDecodeScriptOK(t *testing.T) {
+	keyHash := strings.Repeat("22", 20)
+	script, err := syscoinrpc.DecodeScriptHex("76a914"+keyHash+"88ac", syscoinrpc.RegTest)
+	require.NoError(t, err)
+	require.Equal(t, syscoinrpc.ScriptTypePubKeyHash, script.Type)
+	require.Equal(t, uint64(1), script.RequiredSignatures)
+	require.Len(t, script.Addresses, 1)
+	require.NotEmpty(t, script.P2SH, "Must wrap P2PKH in P2SH")
+	require.NotNil(t, script.Segwit, "Must wrap P2PKH in P2WPKH")
+	require.Equal(t, "0014"+keyHash, script.Segwit.Hex, "P2WPKH must reuse the key hash")
+	require.Equal(t, syscoinrpc.ScriptTypeWitnessV0KeyHash, script.Segwit.Type)
+	require.True(t, strings.HasPrefix(script.Segwit.Addresses[0], "scrt1q"), "Must encode regtest bech32 addresses")
+	require.NotEmpty(t, script.Segwit.P2SHSegwit)
+
+	compressedKey := "02" + strings.Repeat("44", 32)
+	multisig, err := syscoinrpc.DecodeScriptHex("5121"+compressedKey+"51ae", syscoinrpc.RegTest)
+	require.NoError(t, err)
+	require.Equal(t, syscoinrpc.ScriptTypeMultiSig, multisig.Type)
+	require.Equal(t, "1 "+compressedKey+" 1 OP_CHECKMULTISIG", multisig.Asm)
+	require.NotNil(t, multisig.Segwit, "Must wrap multisig in P2WSH")
+	rawMultisig, _ := hex.DecodeString("5121" + compressedKey + "51ae")
+	witnessScriptHash := sha256.Sum256(rawMultisig)
+	require.Equal(t, "0020"+hex.EncodeToString(witnessScriptHash[:]), multisig.Segwit.Hex, "P2WSH must commit to the single SHA256 of the script")
+	require.Equal(t, syscoinrpc.ScriptTypeWitnessV0ScriptHash, multisig.Segwit.Type)
+
+	uncompressed, err := syscoinrpc.DecodeScriptHex("41"+"04"+strings.Repeat("44", 64)+"ac", syscoinrpc.RegTest)
+	require.NoError(t, err)
+	require.Equal(t, syscoinrpc.ScriptTypePubKey, uncompressed.Type)
+	require.NotEmpty(t, uncompressed.P2SH)
+	require.Nil(t, uncompressed.Segwit, "Must not wrap uncompressed keys in segwit")
+
+	p2sh, err := syscoinrpc.DecodeScriptHex("a914"+keyHash+"87", syscoinrpc.RegTest)
+	require.NoError(t, err)
+	require.Equal(t, syscoinrpc.ScriptTypeScriptHash, p2sh.Type)
+	require.Empty(t, p2sh.P2SH, "Must not wrap P2SH in P2SH")
+	require.Nil(t, p2sh.Segwit)
+
+	witness, err := syscoinrpc.DecodeScriptHex("0014"+keyHash, syscoinrpc.RegTest)
+	require.NoError(t, err)
+	require.NotEmpty(t, witness.P2SH, "Must wrap witness programs in P2SH")
+	require.Nil(t, witness.Segwit, "Must not wrap witness programs in segwit")
+
+	nullData, err := syscoinrpc.DecodeScriptHex("6a0401020304", syscoinrpc.RegTest)
+	require.NoError(t, err)
+	require.Equal(t, syscoinrpc.ScriptTypeNullData, nullData.Type)
+	require.Nil(t, nullData.Segwit)
+
+	empty, err := syscoinrpc.DecodeScript(nil, syscoinrpc.MainNet)
+	require.NoError(t, err)
+	require.Equal(t, syscoinrpc.ScriptTypeNonStandard, empty.Type)
+	require.NotEmpty(t, empty.P2SH, "Must wrap any script in P2SH")
+	require.NotNil(t, empty.Segwit, "Must wrap non standard scripts in P2WSH")
+}
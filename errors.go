@@ -0,0 +1,220 @@
+package syscoinrpc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrUnauthorized is returned when the node rejects the RPC credentials.
+	ErrUnauthorized = errors.New("Unauthorized: check the RPC username and password")
+	// ErrForbidden is returned when the node refuses the connection,
+	// usually because the client IP is not allowed by `rpcallowip`.
+	ErrForbidden = errors.New("Forbidden: check the rpcallowip setting of the node")
+	// ErrMethodNotFound is returned when the node does not know the called method
+	// or the requested path.
+	ErrMethodNotFound = errors.New("Method not found")
+	// ErrWorkQueueExceeded is returned when the node has too many pending
+	// requests, see the `rpcworkqueue` setting of the node.
+	ErrWorkQueueExceeded = errors.New("Work queue depth exceeded")
+	// ErrInternalServer is returned when the node fails without
+	// sending a JSON-RPC error object.
+	ErrInternalServer = errors.New("Internal server error")
+	// ErrBadRequest is returned when the node rejects a malformed request,
+	// like a REST request with an invalid hash or format.
+	ErrBadRequest = errors.New("Bad request")
+	// ErrResourceNotFound is returned when the block or transaction
+	// requested through the REST interface is not found.
+	ErrResourceNotFound = errors.New("Not found")
+	// ErrUnexpectedStatus is returned for any other unexpected HTTP status code.
+	ErrUnexpectedStatus = errors.New("Unexpected HTTP status")
+)
+
+// HTTPError represents a failed HTTP response which does not carry
+// a JSON-RPC error object.
+//
+// It wraps one of the ErrUnauthorized, ErrForbidden, ErrMethodNotFound,
+// ErrWorkQueueExceeded, ErrInternalServer, ErrBadRequest, ErrResourceNotFound
+// or ErrUnexpectedStatus errors, use errors.Is to check it.
+type HTTPError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Body is the response body, if any.
+	Body string
+	// Err is the error matching the status code.
+	Err error
+}
+
+func newHTTPError(statusCode int, body []byte) *HTTPError {
+	httpErr := &HTTPError{
+		StatusCode: statusCode,
+		Body:       strings.TrimSpace(string(body)),
+	}
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		httpErr.Err = ErrBadRequest
+	case http.StatusUnauthorized:
+		httpErr.Err = ErrUnauthorized
+	case http.StatusForbidden:
+		httpErr.Err = ErrForbidden
+	case http.StatusNotFound:
+		httpErr.Err = ErrMethodNotFound
+	case http.StatusServiceUnavailable:
+		httpErr.Err = ErrWorkQueueExceeded
+	case http.StatusInternalServerError:
+		httpErr.Err = ErrInternalServer
+	default:
+		httpErr.Err = ErrUnexpectedStatus
+	}
+
+	return httpErr
+}
+
+func (err *HTTPError) Error() string {
+	if err.Body == "" {
+		return fmt.Sprintf("%s (HTTP %d)", err.Err, err.StatusCode)
+	}
+	return fmt.Sprintf("%s (HTTP %d): %s", err.Err, err.StatusCode, err.Body)
+}
+
+// Unwrap returns the error matching the status code.
+func (err *HTTPError) Unwrap() error {
+	return err.Err
+}
+
+// RPCErrorCode is an error code returned by the node in a JSON-RPC error object.
+type RPCErrorCode int
+
+// Standard JSON-RPC 2.0 errors.
+const (
+	// RPCInvalidRequest is RPC_INVALID_REQUEST: the request is not a valid JSON-RPC request.
+	RPCInvalidRequest RPCErrorCode = -32600
+	// RPCMethodNotFound is RPC_METHOD_NOT_FOUND: the method does not exist.
+	RPCMethodNotFound RPCErrorCode = -32601
+	// RPCInvalidParams is RPC_INVALID_PARAMS: the params are invalid.
+	RPCInvalidParams RPCErrorCode = -32602
+	// RPCInternalError is RPC_INTERNAL_ERROR: internal JSON-RPC error.
+	RPCInternalError RPCErrorCode = -32603
+	// RPCParseError is RPC_PARSE_ERROR: the request could not be parsed.
+	RPCParseError RPCErrorCode = -32700
+)
+
+// General application defined errors.
+const (
+	// RPCMiscError is RPC_MISC_ERROR: std::exception thrown in command handling.
+	RPCMiscError RPCErrorCode = -1
+	// RPCForbiddenBySafeMode is RPC_FORBIDDEN_BY_SAFE_MODE: server is in safe mode,
+	// and command is not allowed in safe mode.
+	RPCForbiddenBySafeMode RPCErrorCode = -2
+	// RPCTypeError is RPC_TYPE_ERROR: unexpected type was passed as parameter.
+	RPCTypeError RPCErrorCode = -3
+	// RPCInvalidAddressOrKey is RPC_INVALID_ADDRESS_OR_KEY: invalid address or key,
+	// also returned when a block or transaction is not found.
+	RPCInvalidAddressOrKey RPCErrorCode = -5
+	// RPCOutOfMemory is RPC_OUT_OF_MEMORY: ran out of memory during operation.
+	RPCOutOfMemory RPCErrorCode = -7
+	// RPCInvalidParameter is RPC_INVALID_PARAMETER: invalid, missing or duplicate parameter.
+	RPCInvalidParameter RPCErrorCode = -8
+	// RPCDatabaseError is RPC_DATABASE_ERROR: database error.
+	RPCDatabaseError RPCErrorCode = -20
+	// RPCDeserializationError is RPC_DESERIALIZATION_ERROR: error parsing or
+	// validating structure in raw format.
+	RPCDeserializationError RPCErrorCode = -22
+	// RPCVerifyError is RPC_VERIFY_ERROR: general error during transaction or block submission.
+	RPCVerifyError RPCErrorCode = -25
+	// RPCVerifyRejected is RPC_VERIFY_REJECTED: transaction or block was rejected by network rules.
+	RPCVerifyRejected RPCErrorCode = -26
+	// RPCVerifyAlreadyInChain is RPC_VERIFY_ALREADY_IN_CHAIN: transaction already in chain.
+	RPCVerifyAlreadyInChain RPCErrorCode = -27
+	// RPCInWarmup is RPC_IN_WARMUP: client still warming up.
+	RPCInWarmup RPCErrorCode = -28
+	// RPCMethodDeprecated is RPC_METHOD_DEPRECATED: RPC method is deprecated.
+	RPCMethodDeprecated RPCErrorCode = -32
+)
+
+// P2P client errors.
+const (
+	// RPCClientNotConnected is RPC_CLIENT_NOT_CONNECTED: the node is not connected.
+	RPCClientNotConnected RPCErrorCode = -9
+	// RPCClientInInitialDownload is RPC_CLIENT_IN_INITIAL_DOWNLOAD: still downloading initial blocks.
+	RPCClientInInitialDownload RPCErrorCode = -10
+	// RPCClientNodeAlreadyAdded is RPC_CLIENT_NODE_ALREADY_ADDED: node is already added.
+	RPCClientNodeAlreadyAdded RPCErrorCode = -23
+	// RPCClientNodeNotAdded is RPC_CLIENT_NODE_NOT_ADDED: node has not been added before.
+	RPCClientNodeNotAdded RPCErrorCode = -24
+	// RPCClientNodeNotConnected is RPC_CLIENT_NODE_NOT_CONNECTED: node to disconnect not found in connected nodes.
+	RPCClientNodeNotConnected RPCErrorCode = -29
+	// RPCClientInvalidIPOrSubnet is RPC_CLIENT_INVALID_IP_OR_SUBNET: invalid IP/Subnet.
+	RPCClientInvalidIPOrSubnet RPCErrorCode = -30
+	// RPCClientP2PDisabled is RPC_CLIENT_P2P_DISABLED: no valid connection manager instance found.
+	RPCClientP2PDisabled RPCErrorCode = -31
+)
+
+// Wallet errors.
+const (
+	// RPCWalletError is RPC_WALLET_ERROR: unspecified problem with wallet (key not found etc.).
+	RPCWalletError RPCErrorCode = -4
+	// RPCWalletInsufficientFunds is RPC_WALLET_INSUFFICIENT_FUNDS: not enough funds in wallet or account.
+	RPCWalletInsufficientFunds RPCErrorCode = -6
+	// RPCWalletInvalidAccountName is RPC_WALLET_INVALID_ACCOUNT_NAME: invalid account name.
+	RPCWalletInvalidAccountName RPCErrorCode = -11
+	// RPCWalletKeypoolRanOut is RPC_WALLET_KEYPOOL_RAN_OUT: keypool ran out, call keypoolrefill first.
+	RPCWalletKeypoolRanOut RPCErrorCode = -12
+	// RPCWalletUnlockNeeded is RPC_WALLET_UNLOCK_NEEDED: enter the wallet passphrase with walletpassphrase first.
+	RPCWalletUnlockNeeded RPCErrorCode = -13
+	// RPCWalletPassphraseIncorrect is RPC_WALLET_PASSPHRASE_INCORRECT: the wallet passphrase entered was incorrect.
+	RPCWalletPassphraseIncorrect RPCErrorCode = -14
+	// RPCWalletWrongEncState is RPC_WALLET_WRONG_ENC_STATE: command given in wrong wallet encryption state.
+	RPCWalletWrongEncState RPCErrorCode = -15
+	// RPCWalletEncryptionFailed is RPC_WALLET_ENCRYPTION_FAILED: failed to encrypt the wallet.
+	RPCWalletEncryptionFailed RPCErrorCode = -16
+	// RPCWalletAlreadyUnlocked is RPC_WALLET_ALREADY_UNLOCKED: wallet is already unlocked.
+	RPCWalletAlreadyUnlocked RPCErrorCode = -17
+)
+
+// RPCError represents an error object returned by the node.
+type RPCError struct {
+	// Code is the error code, one of the RPC* constants.
+	Code RPCErrorCode `json:"code,required"`
+	// Message is the error message.
+	Message string `json:"message,required"`
+	// Method is the name of the method which returned the error.
+	Method string `json:"-"`
+}
+
+func (err *RPCError) Error() string {
+	return err.Message
+}
+
+// IsRPCError returns true if err is an RPCError with the given code.
+func IsRPCError(err error, code RPCErrorCode) bool {
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+	return rpcErr.Code == code
+}
+
+// IsWarmup returns true if the node is still warming up (e.g. loading the block index).
+func IsWarmup(err error) bool {
+	return IsRPCError(err, RPCInWarmup)
+}
+
+// IsNotFound returns true if the requested block, transaction or
+// address has not been found by the node.
+func IsNotFound(err error) bool {
+	return IsRPCError(err, RPCInvalidAddressOrKey) || errors.Is(err, ErrResourceNotFound)
+}
+
+// IsMethodNotFound returns true if the node does not know the called method.
+func IsMethodNotFound(err error) bool {
+	return IsRPCError(err, RPCMethodNotFound) || errors.Is(err, ErrMethodNotFound)
+}
+
+// IsUnauthorized returns true if the node rejected the RPC credentials.
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
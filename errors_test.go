@@ -0,0 +1,89 @@
+package syscoinrpc_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+)
+
+func TestRPCErrorInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "")
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.Blockchain.GetFullBlock(syscoinrpc.Hash{})
+	require.Error(t, err, "Must error on any method with invalid URL")
+	require.False(t, syscoinrpc.IsNotFound(err), "Network errors are not RPC errors")
+	require.False(t, syscoinrpc.IsWarmup(err), "Network errors are not RPC errors")
+}
+
+func TestRPCErrorOK(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"result":null,"error":{"code":-5,"message":"Block not found"},"id":"1"}`)
+	}))
+	defer srv.Close()
+
+	cl, err := syscoinrpc.NewClient(srv.URL, "", "")
+	require.NoError(t, err, "Must have no error on creation")
+
+	_, err = cl.Blockchain.GetFullBlock(syscoinrpc.Hash{})
+	require.EqualError(t, err, "Block not found")
+	require.True(t, syscoinrpc.IsNotFound(err), "Must be recognized as not found")
+	require.False(t, syscoinrpc.IsWarmup(err), "Must not be recognized as warmup")
+
+	var rpcErr *syscoinrpc.RPCError
+	require.True(t, errors.As(err, &rpcErr), "Must be an RPCError")
+	require.Equal(t, syscoinrpc.RPCInvalidAddressOrKey, rpcErr.Code)
+	require.Equal(t, "getblock", rpcErr.Method)
+}
+
+func TestHTTPErrorOK(t *testing.T) {
+	var status int
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}))
+	defer srv.Close()
+
+	cl, err := syscoinrpc.NewClient(srv.URL, "", "")
+	require.NoError(t, err, "Must have no error on creation")
+
+	status, body = http.StatusUnauthorized, ""
+	_, err = cl.Blockchain.GetBlockCount()
+	require.True(t, errors.Is(err, syscoinrpc.ErrUnauthorized), "401: must be ErrUnauthorized, got %v", err)
+	require.True(t, syscoinrpc.IsUnauthorized(err), "401: must be recognized as unauthorized")
+
+	status, body = http.StatusForbidden, ""
+	_, err = cl.Blockchain.GetBlockCount()
+	require.True(t, errors.Is(err, syscoinrpc.ErrForbidden), "403: must be ErrForbidden, got %v", err)
+
+	status, body = http.StatusServiceUnavailable, "Work queue depth exceeded"
+	_, err = cl.Blockchain.GetBlockCount()
+	require.True(t, errors.Is(err, syscoinrpc.ErrWorkQueueExceeded), "503: must be ErrWorkQueueExceeded, got %v", err)
+
+	status, body = http.StatusBadRequest, ""
+	_, err = cl.Blockchain.GetBlockCount()
+	require.True(t, errors.Is(err, syscoinrpc.ErrBadRequest), "400: must be ErrBadRequest, got %v", err)
+
+	status, body = http.StatusInternalServerError, ""
+	_, err = cl.Blockchain.GetBlockCount()
+	require.True(t, errors.Is(err, syscoinrpc.ErrInternalServer), "500: must be ErrInternalServer, got %v", err)
+
+	var httpErr *syscoinrpc.HTTPError
+	require.True(t, errors.As(err, &httpErr), "500: must be an HTTPError")
+	require.Equal(t, http.StatusInternalServerError, httpErr.StatusCode)
+
+	status, body = http.StatusNotFound, `{"result":null,"error":{"code":-32601,"message":"Method not found"},"id":"1"}`
+	_, err = cl.Blockchain.GetBlockCount()
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCMethodNotFound), "404: must decode the JSON error, got %v", err)
+	require.True(t, syscoinrpc.IsMethodNotFound(err), "404: must be recognized as method not found")
+
+	status, body = http.StatusInternalServerError, `{"result":null,"error":{"code":-8,"message":"Block height out of range"},"id":"1"}`
+	_, err = cl.Blockchain.GetBlockHash(1000000)
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCInvalidParameter), "500: must decode the JSON error, got %v", err)
+}
@@ -0,0 +1,302 @@
+package syscoinrpc
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ChainEventType is the type of a ChainEvent.
+type ChainEventType int
+
+const (
+	// BlockConnected is the type of the events of blocks added to the active chain.
+	BlockConnected ChainEventType = iota + 1
+	// BlockDisconnected is the type of the events of blocks removed from the
+	// active chain by a reorganization.
+	BlockDisconnected
+)
+
+// String returns the name of the event type.
+func (t ChainEventType) String() string {
+	switch t {
+	case BlockConnected:
+		return "BlockConnected"
+	case BlockDisconnected:
+		return "BlockDisconnected"
+	}
+	return "Unknown"
+}
+
+// ChainEvent represents a change of the active chain.
+type ChainEvent struct {
+	// Type is the type of the change.
+	Type ChainEventType
+	// Header is the header of the connected or disconnected block.
+	Header *FullBlockHeader
+}
+
+// Checkpoint is the last block processed by a ChainFollower.
+type Checkpoint struct {
+	// Hash is the hash of the block.
+	Hash Hash `json:"hash"`
+	// Height is the height of the block.
+	Height uint64 `json:"height"`
+}
+
+// CheckpointStore persists the checkpoint of a ChainFollower,
+// so that it resumes where it stopped after a restart.
+type CheckpointStore interface {
+	// LoadCheckpoint returns the saved checkpoint, nil if none has been saved yet.
+	LoadCheckpoint() (*Checkpoint, error)
+	// SaveCheckpoint saves the checkpoint, replacing the previous one.
+	SaveCheckpoint(checkpoint Checkpoint) error
+}
+
+// FileCheckpointStore is a CheckpointStore saving the checkpoint in a JSON file.
+type FileCheckpointStore struct {
+	path string // The path of the file.
+}
+
+// NewFileCheckpointStore returns a store saving the checkpoint in the file at path.
+// The file is created on the first save, its directory must exist.
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{path: path}
+}
+
+// LoadCheckpoint reads the checkpoint from the file, nil if the file does not exist.
+func (fcs *FileCheckpointStore) LoadCheckpoint() (*Checkpoint, error) {
+	content, err := ioutil.ReadFile(fcs.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var checkpoint Checkpoint
+	err = json.Unmarshal(content, &checkpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &checkpoint, nil
+}
+
+// SaveCheckpoint writes the checkpoint to the file. The file is replaced
+// atomically, so a crash never leaves a partially written checkpoint.
+func (fcs *FileCheckpointStore) SaveCheckpoint(checkpoint Checkpoint) error {
+	content, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(fcs.path), filepath.Base(fcs.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(content)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), fcs.path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// ChainFollower follows the active chain of the node, emitting an event for every
+// block connected to or disconnected from it, in order: when the node switches to
+// another branch, the blocks of the old branch are disconnected from the tip down
+// to the fork point, then the blocks of the new branch are connected.
+//
+// The last processed block is saved in a CheckpointStore after each event, so that
+// the follower resumes where it stopped after a restart, including when the chain
+// has been reorganized meanwhile.
+//
+// A ChainFollower must not be used from multiple goroutines.
+type ChainFollower struct {
+	bic        *BlockchainClient // The client of the node.
+	store      CheckpointStore   // The checkpoint store, nil to not persist the checkpoint.
+	checkpoint *Checkpoint       // The last processed block, nil if not loaded yet.
+}
+
+// NewChainFollower returns a follower of the active chain of the node.
+//
+// Without a saved checkpoint, the follower starts from the tip of the chain at
+// the first Sync: blocks already in the chain are not emitted.
+//
+//     bic   : The client of the node.
+//     store : The store of the checkpoint, nil to not persist it.
+func NewChainFollower(bic *BlockchainClient, store CheckpointStore) *ChainFollower {
+	return &ChainFollower{bic: bic, store: store}
+}
+
+// Checkpoint returns the last processed block, nil before the first Sync.
+func (cf *ChainFollower) Checkpoint() *Checkpoint {
+	if cf.checkpoint == nil {
+		return nil
+	}
+	checkpoint := *cf.checkpoint
+	return &checkpoint
+}
+
+// Sync catches up with the active chain of the node, calling handler for every event, in order.
+//
+// The checkpoint is advanced after handler returns successfully, so the events are
+// delivered at least once: when handler or a call fails, Sync returns the error and
+// the next Sync starts again from the failed event.
+func (cf *ChainFollower) Sync(ctx context.Context, handler func(ChainEvent) error) error {
+	tip, err := cf.bic.GetBestBlockHashContext(ctx)
+	if err != nil {
+		return err
+	}
+	if cf.checkpoint == nil {
+		err = cf.loadCheckpoint(ctx, tip)
+		if err != nil {
+			return err
+		}
+	}
+	if cf.checkpoint.Hash == tip {
+		return nil
+	}
+
+	header, err := cf.bic.GetFullBlockHeaderContext(ctx, cf.checkpoint.Hash)
+	if err != nil {
+		return err
+	}
+	for {
+		// A negative number of confirmations means the block is not in the
+		// active chain anymore: step back towards the fork point.
+		if header.Confirmations < 0 {
+			err = cf.emit(handler, BlockDisconnected, header, Checkpoint{Hash: header.PreviousBlockHash, Height: header.Height - 1})
+			if err != nil {
+				return err
+			}
+			header, err = cf.bic.GetFullBlockHeaderContext(ctx, header.PreviousBlockHash)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if header.NextBlockHash.IsZero() {
+			return nil
+		}
+		next, err := cf.bic.GetFullBlockHeaderContext(ctx, header.NextBlockHash)
+		if err != nil {
+			return err
+		}
+		if next.Confirmations < 0 {
+			// The chain has been reorganized since header has been fetched.
+			header, err = cf.bic.GetFullBlockHeaderContext(ctx, cf.checkpoint.Hash)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		err = cf.emit(handler, BlockConnected, next, Checkpoint{Hash: next.Hash, Height: next.Height})
+		if err != nil {
+			return err
+		}
+		header = next
+	}
+}
+
+// Run calls Sync every interval, until ctx is cancelled or Sync fails.
+// It returns the error of Sync, or the error of ctx once cancelled.
+func (cf *ChainFollower) Run(ctx context.Context, interval time.Duration, handler func(ChainEvent) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := cf.Sync(ctx, handler)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunWithSubscriber is like Run but calls Sync when the subscriber notifies a new block,
+// instead of polling. It returns the error of Sync, the error of ctx once cancelled,
+// or nil once the subscriber is closed.
+func (cf *ChainFollower) RunWithSubscriber(ctx context.Context, sub *Subscriber, handler func(ChainEvent) error) error {
+	for {
+		err := cf.Sync(ctx, handler)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			return err
+		}
+		for waiting := true; waiting; {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case n, ok := <-sub.Notifications():
+				if !ok {
+					return nil
+				}
+				waiting = n.Topic != TopicHashBlock && n.Topic != TopicRawBlock
+			}
+		}
+	}
+}
+
+// loadCheckpoint loads the saved checkpoint, or starts from the tip of the chain.
+func (cf *ChainFollower) loadCheckpoint(ctx context.Context, tip Hash) error {
+	if cf.store != nil {
+		checkpoint, err := cf.store.LoadCheckpoint()
+		if err != nil {
+			return err
+		}
+		if checkpoint != nil {
+			cf.checkpoint = checkpoint
+			return nil
+		}
+	}
+
+	header, err := cf.bic.GetFullBlockHeaderContext(ctx, tip)
+	if err != nil {
+		return err
+	}
+	return cf.setCheckpoint(Checkpoint{Hash: header.Hash, Height: header.Height})
+}
+
+// emit calls handler with the event, then moves the checkpoint.
+func (cf *ChainFollower) emit(handler func(ChainEvent) error, eventType ChainEventType, header *FullBlockHeader, checkpoint Checkpoint) error {
+	err := handler(ChainEvent{Type: eventType, Header: header})
+	if err != nil {
+		return err
+	}
+	return cf.setCheckpoint(checkpoint)
+}
+
+func (cf *ChainFollower) setCheckpoint(checkpoint Checkpoint) error {
+	if cf.store != nil {
+		err := cf.store.SaveCheckpoint(checkpoint)
+		if err != nil {
+			return err
+		}
+	}
+	cf.checkpoint = &checkpoint
+	return nil
+}
@@ -0,0 +1,170 @@
+package syscoinrpc_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+	"github.com/thebotguys/golang-syscoin-rpc-client/syscoinrpctest"
+)
+
+// eventRecorder records the events of a follower.
+type eventRecorder struct {
+	events []string
+}
+
+func (er *eventRecorder) handle(event syscoinrpc.ChainEvent) error {
+	er.events = append(er.events, event.Type.String()+" "+event.Header.Hash.String())
+	return nil
+}
+
+func connected(hashes ...syscoinrpc.Hash) []string {
+	var events []string
+	for _, hash := range hashes {
+		events = append(events, "BlockConnected "+hash.String())
+	}
+	return events
+}
+
+func disconnected(hashes ...syscoinrpc.Hash) []string {
+	var events []string
+	for _, hash := range hashes {
+		events = append(events, "BlockDisconnected "+hash.String())
+	}
+	return events
+}
+
+func TestChainFollowerInvalid(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	t.Cleanup(srv.Close)
+	cl, err := srv.Client(testTimeout)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte("{"), 0600))
+	follower := syscoinrpc.NewChainFollower(cl.Blockchain, syscoinrpc.NewFileCheckpointStore(path))
+	err = follower.Sync(context.Background(), (&eventRecorder{}).handle)
+	require.Error(t, err, "Must error on corrupted checkpoints")
+
+	follower = syscoinrpc.NewChainFollower(cl.Blockchain, nil)
+	require.NoError(t, follower.Sync(context.Background(), (&eventRecorder{}).handle))
+	hashes := srv.Generate(2)
+
+	handlerErr := errors.New("handler failure")
+	err = follower.Sync(context.Background(), func(syscoinrpc.ChainEvent) error { return handlerErr })
+	require.Equal(t, handlerErr, err, "Must stop on handler errors")
+	require.Equal(t, uint64(0), follower.Checkpoint().Height, "Must not advance on handler errors")
+
+	recorder := &eventRecorder{}
+	require.NoError(t, follower.Sync(context.Background(), recorder.handle))
+	require.Equal(t, connected(hashes...), recorder.events, "Must deliver the failed event again")
+
+	srv.SetError("getblockheader", syscoinrpc.RPCMiscError, "failure")
+	srv.Generate(1)
+	err = follower.Sync(context.Background(), recorder.handle)
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCMiscError), "Must stop on call errors")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	srv.ClearErrors()
+	err = follower.Run(ctx, time.Millisecond, recorder.handle)
+	require.True(t, errors.Is(err, context.Canceled), "Must stop when the context is cancelled")
+}
+
+func TestChainFollowerOK(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	t.Cleanup(srv.Close)
+	srv.Generate(10)
+	cl, err := srv.Client(testTimeout)
+	require.NoError(t, err)
+
+	store := syscoinrpc.NewFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoint.json"))
+	follower := syscoinrpc.NewChainFollower(cl.Blockchain, store)
+	require.Nil(t, follower.Checkpoint())
+
+	recorder := &eventRecorder{}
+	require.NoError(t, follower.Sync(context.Background(), recorder.handle))
+	require.Empty(t, recorder.events, "Must start from the tip")
+	require.Equal(t, &syscoinrpc.Checkpoint{Hash: srv.BlockHash(10), Height: 10}, follower.Checkpoint())
+
+	oldBranch := srv.Generate(3)
+	require.NoError(t, follower.Sync(context.Background(), recorder.handle))
+	require.Equal(t, connected(oldBranch...), recorder.events, "Must connect the new blocks")
+
+	recorder.events = nil
+	newBranch := srv.Reorganize(2, 3)
+	require.NoError(t, follower.Sync(context.Background(), recorder.handle))
+	expected := append(disconnected(oldBranch[2], oldBranch[1]), connected(newBranch...)...)
+	require.Equal(t, expected, recorder.events, "Must disconnect the old branch, then connect the new one")
+	require.Equal(t, &syscoinrpc.Checkpoint{Hash: newBranch[2], Height: 14}, follower.Checkpoint())
+
+	tips, err := cl.Blockchain.GetChainTips()
+	require.NoError(t, err)
+	require.Len(t, tips, 2, "The old branch must be a fork")
+	require.Equal(t, oldBranch[2], tips[1].Hash)
+
+	// A new follower resumes from the saved checkpoint, even if the chain
+	// has been reorganized meanwhile.
+	recorder.events = nil
+	lastBranch := srv.Reorganize(1, 2)
+	resumed := syscoinrpc.NewChainFollower(cl.Blockchain, store)
+	require.NoError(t, resumed.Sync(context.Background(), recorder.handle))
+	expected = append(disconnected(newBranch[2]), connected(lastBranch...)...)
+	require.Equal(t, expected, recorder.events, "Must resume from the saved checkpoint")
+
+	recorder.events = nil
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	hashes := srv.Generate(1)
+	err = resumed.Run(ctx, 5*time.Millisecond, recorder.handle)
+	require.Equal(t, context.DeadlineExceeded, err)
+	require.Equal(t, connected(hashes...), recorder.events, "Must sync while running")
+}
+
+func TestChainFollowerRunWithSubscriberOK(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	t.Cleanup(srv.Close)
+	cl, err := srv.Client(testTimeout)
+	require.NoError(t, err)
+	pub := syscoinrpctest.NewPublisher()
+	t.Cleanup(pub.Close)
+	srv.SetPublisher(pub)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sub, err := cl.Blockchain.Subscribe(ctx, syscoinrpc.SubscriberConfig{
+		Endpoints: map[string]string{syscoinrpc.TopicHashBlock: pub.Endpoint},
+	})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return pub.Subscribed(syscoinrpc.TopicHashBlock) }, 5*time.Second, time.Millisecond)
+
+	follower := syscoinrpc.NewChainFollower(cl.Blockchain, nil)
+	require.NoError(t, follower.Sync(ctx, (&eventRecorder{}).handle))
+
+	events := make(chan string)
+	done := make(chan error, 1)
+	go func() {
+		done <- follower.RunWithSubscriber(ctx, sub, func(event syscoinrpc.ChainEvent) error {
+			events <- event.Type.String() + " " + event.Header.Hash.String()
+			return nil
+		})
+	}()
+
+	hashes := srv.Generate(2)
+	for _, expected := range connected(hashes...) {
+		select {
+		case event := <-events:
+			require.Equal(t, expected, event, "Must sync on notifications")
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "Timed out waiting for an event")
+		}
+	}
+
+	sub.Close()
+	require.NoError(t, <-done, "Must stop once the subscriber is closed")
+}
@@ -1,14 +1,17 @@
 package syscoinrpc
 
-import "encoding/json"
+import (
+	"context"
+	"encoding/json"
+)
 
 // GeneratingClient wraps all `generating` related functions.
 type GeneratingClient struct {
 	c *Client // The binded client, must not be nil.
 }
 
-func (gc *GeneratingClient) do(method string, params ...interface{}) (json.RawMessage, error) {
-	return gc.c.do(method, params...)
+func (gc *GeneratingClient) do(ctx context.Context, method string, params ...interface{}) (json.RawMessage, error) {
+	return gc.c.do(ctx, method, params...)
 }
 
 // Generate mines instantly (before RPC call returns) a specified
@@ -18,17 +21,22 @@ func (gc *GeneratingClient) do(method string, params ...interface{}) (json.RawMe
 //
 //     nBlocks  : The number of blocks to generate.
 //     maxTries : The number of iterations to try (default = 0 -> 1000000 iterations).
-func (gc *GeneratingClient) Generate(nBlocks uint64, maxTries uint64) ([]string, error) {
+func (gc *GeneratingClient) Generate(nBlocks uint64, maxTries uint64) ([]Hash, error) {
+	return gc.GenerateContext(context.Background(), nBlocks, maxTries)
+}
+
+// GenerateContext is like Generate but uses the given context for the call.
+func (gc *GeneratingClient) GenerateContext(ctx context.Context, nBlocks uint64, maxTries uint64) ([]Hash, error) {
 	if maxTries == 0 {
 		maxTries = 1000000
 	}
 
-	response, err := gc.do("generate", nBlocks, maxTries)
+	response, err := gc.do(ctx, "generate", nBlocks, maxTries)
 	if err != nil {
 		return nil, err
 	}
 
-	var hashes []string
+	var hashes []Hash
 	err = json.Unmarshal(response, &hashes)
 	if err != nil {
 		return nil, err
@@ -45,17 +53,22 @@ func (gc *GeneratingClient) Generate(nBlocks uint64, maxTries uint64) ([]string,
 //     nBlocks  : The number of blocks to generate.
 //     address  : The address to send the newly generated Syscoin to.
 //     maxTries : The number of iterations to try (default = 0 -> 1000000 iterations).
-func (gc *GeneratingClient) GenerateToAddress(nBlocks uint64, address string, maxTries uint64) ([]string, error) {
+func (gc *GeneratingClient) GenerateToAddress(nBlocks uint64, address string, maxTries uint64) ([]Hash, error) {
+	return gc.GenerateToAddressContext(context.Background(), nBlocks, address, maxTries)
+}
+
+// GenerateToAddressContext is like GenerateToAddress but uses the given context for the call.
+func (gc *GeneratingClient) GenerateToAddressContext(ctx context.Context, nBlocks uint64, address string, maxTries uint64) ([]Hash, error) {
 	if maxTries == 0 {
 		maxTries = 1000000
 	}
 
-	response, err := gc.do("generatetoaddress", nBlocks, address, maxTries)
+	response, err := gc.do(ctx, "generatetoaddress", nBlocks, address, maxTries)
 	if err != nil {
 		return nil, err
 	}
 
-	var hashes []string
+	var hashes []Hash
 	err = json.Unmarshal(response, &hashes)
 	if err != nil {
 		return nil, err
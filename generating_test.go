@@ -1,7 +1,6 @@
 package syscoinrpc_test
 
 import (
-	"os"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -9,7 +8,7 @@ import (
 )
 
 func TestGenerateInvalid(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(invalidURL, "", "")
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
 	_, err = cl.Generating.Generate(1, 1)
@@ -17,7 +16,7 @@ func TestGenerateInvalid(t *testing.T) {
 }
 
 func TestGenerateToAddressInvalid(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(invalidURL, "", "")
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
 	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
 
 	_, err = cl.Generating.GenerateToAddress(1, "", 1)
@@ -25,8 +24,7 @@ func TestGenerateToAddressInvalid(t *testing.T) {
 }
 
 func TestGenerateOK(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"))
-	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+	cl := newFakeClient(t)
 
 	blockHashes, err := cl.Generating.Generate(11, 0)
 	require.NoError(t, err, "Generate: Must not error")
@@ -35,8 +33,7 @@ func TestGenerateOK(t *testing.T) {
 }
 
 func TestGenerateToAddressOK(t *testing.T) {
-	cl, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, os.Getenv("RPC_USER"), os.Getenv("RPC_PASSWORD"))
-	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+	cl := newFakeClient(t)
 
 	testAddress := "SaaxXq67HhzPbsKNNJBQeQK5qf5Hpv8qq2"
 
@@ -0,0 +1,86 @@
+package syscoinrpc
+
+import (
+	"encoding/hex"
+	"errors"
+)
+
+// HashSize is the size of a hash, in bytes.
+const HashSize = 32
+
+// ErrInvalidHash is returned when parsing a malformed hash.
+var ErrInvalidHash = errors.New("Invalid hash: must be 64 hexadecimal characters")
+
+// Hash represents a 32 bytes hash, like block hashes, transaction IDs and merkle roots.
+//
+// The bytes are stored in internal byte order, the one of serialized blocks and
+// transactions, which is the reverse of the display order used by the node in
+// JSON-RPC calls (e.g. "0000...a1b2"). The zero value is the all zeros hash.
+type Hash [HashSize]byte
+
+// ParseHash parses a hash in display byte order, as returned by the node.
+func ParseHash(s string) (Hash, error) {
+	var h Hash
+	err := h.UnmarshalText([]byte(s))
+	return h, err
+}
+
+// MustParseHash is like ParseHash but panics if s is malformed.
+// It simplifies the initialization of global variables holding hashes.
+func MustParseHash(s string) Hash {
+	h, err := ParseHash(s)
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
+
+// NewHash returns the hash with the given bytes, in internal byte order.
+func NewHash(internal []byte) (Hash, error) {
+	var h Hash
+	if len(internal) != HashSize {
+		return h, ErrInvalidHash
+	}
+	copy(h[:], internal)
+	return h, nil
+}
+
+// Bytes returns a copy of the bytes of the hash, in internal byte order.
+func (h Hash) Bytes() []byte {
+	return append([]byte(nil), h[:]...)
+}
+
+// IsZero returns true if h is the all zeros hash.
+func (h Hash) IsZero() bool {
+	return h == Hash{}
+}
+
+// String returns the hash in display byte order, as hexadecimal.
+func (h Hash) String() string {
+	var reversed Hash
+	for i := range h {
+		reversed[i] = h[HashSize-1-i]
+	}
+	return hex.EncodeToString(reversed[:])
+}
+
+// MarshalText encodes the hash in display byte order, as the node does.
+func (h Hash) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+// UnmarshalText decodes a hash in display byte order, validating it.
+func (h *Hash) UnmarshalText(text []byte) error {
+	if len(text) != 2*HashSize {
+		return ErrInvalidHash
+	}
+	var reversed Hash
+	_, err := hex.Decode(reversed[:], text)
+	if err != nil {
+		return ErrInvalidHash
+	}
+	for i := range reversed {
+		h[i] = reversed[HashSize-1-i]
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package syscoinrpc_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+)
+
+const testHashString = "9f362bce7390fb38dfa0f98c11fb9a5158aeb280f29c8f6cb5ef43d916173bf1"
+
+func TestHashInvalid(t *testing.T) {
+	for _, value := range []string{"", "9f36", testHashString + "00", testHashString[:63] + "g", testHashString[:63] + " "} {
+		_, err := syscoinrpc.ParseHash(value)
+		require.Equal(t, syscoinrpc.ErrInvalidHash, err, "Must error on %q", value)
+	}
+
+	_, err := syscoinrpc.NewHash(make([]byte, 31))
+	require.Equal(t, syscoinrpc.ErrInvalidHash, err, "Must error on wrong length")
+
+	require.Panics(t, func() { syscoinrpc.MustParseHash("9f36") }, "Must panic on malformed hash")
+
+	var header syscoinrpc.FullBlockHeader
+	err = json.Unmarshal([]byte(`{"hash":"9f36"}`), &header)
+	require.Error(t, err, "Must validate the hashes returned by the node")
+
+	var hashes []syscoinrpc.Hash
+	err = json.Unmarshal([]byte(`[1]`), &hashes)
+	require.Error(t, err, "Must error on non string hashes")
+}
+
+func TestHashOK(t *testing.T) {
+	hash, err := syscoinrpc.ParseHash(testHashString)
+	require.NoError(t, err, "Must parse a valid hash")
+	require.Equal(t, testHashString, hash.String(), "Must display the hash as parsed")
+	require.Equal(t, byte(0xf1), hash[0], "Must store the bytes in internal order")
+	require.Equal(t, byte(0x9f), hash[31], "Must store the bytes in internal order")
+	require.False(t, hash.IsZero())
+	require.True(t, syscoinrpc.Hash{}.IsZero())
+
+	upper, err := syscoinrpc.ParseHash("9F362BCE7390FB38DFA0F98C11FB9A5158AEB280F29C8F6CB5EF43D916173BF1")
+	require.NoError(t, err, "Must parse upper case hashes")
+	require.Equal(t, hash, upper)
+
+	internal := hash.Bytes()
+	internal[0] = 0
+	require.Equal(t, byte(0xf1), hash[0], "Bytes must return a copy")
+	fromBytes, err := syscoinrpc.NewHash(hash.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, hash, fromBytes)
+
+	encoded, err := json.Marshal(map[syscoinrpc.Hash][]syscoinrpc.Hash{hash: {hash}})
+	require.NoError(t, err)
+	require.Equal(t, `{"`+testHashString+`":["`+testHashString+`"]}`, string(encoded), "Must encode as the node does")
+
+	var decoded map[syscoinrpc.Hash][]syscoinrpc.Hash
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	require.Equal(t, []syscoinrpc.Hash{hash}, decoded[hash], "Must decode hashes used as keys")
+}
@@ -0,0 +1,66 @@
+package syscoinrpc
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// checkNodeURL returns ErrInvalidURL if nodeURL is not an absolute HTTP or HTTPS URL.
+func checkNodeURL(nodeURL string) error {
+	parsedURL, err := url.Parse(nodeURL)
+	if err != nil {
+		return ErrInvalidURL
+	}
+	if (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return ErrInvalidURL
+	}
+	return nil
+}
+
+// newHTTPRequest creates a request to the node, with the additional headers.
+//     ctx     : The context of the request, cancelling it aborts the request.
+//     method  : The HTTP method.
+//     url     : The absolute URL of the request.
+//     body    : The body of the request, nil for none.
+//     headers : The additional headers, see WithHeader.
+func newHTTPRequest(ctx context.Context, method string, url string, body io.Reader, headers http.Header) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	return req, nil
+}
+
+// doHTTPRequest performs the request and returns the status code and the body
+// of the response. The error of the context is returned if it was cancelled.
+func doHTTPRequest(httpClient *http.Client, req *http.Request) (int, []byte, error) {
+	ctx := req.Context()
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return 0, nil, ctx.Err()
+		}
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		if ctx.Err() != nil {
+			return 0, nil, ctx.Err()
+		}
+		return 0, nil, err
+	}
+
+	return resp.StatusCode, content, nil
+}
@@ -2,9 +2,11 @@ package syscoinrpc
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"io/ioutil"
+	"errors"
 	"net/http"
+	"sync"
 
 	"github.com/twinj/uuid"
 )
@@ -20,62 +22,177 @@ type jsonRPCrequest struct {
 // jsonRPCrequest represents a generic JSON RPC response.
 type jsonRPCresponse struct {
 	Result json.RawMessage `json:"result,required"`
-	Error  *errorMessage   `json:"error"`
+	Error  *RPCError       `json:"error"`
 	ID     string          `json:"id,required"`
 }
 
-type errorMessage struct {
-	Code    int    `json:"code,required"`
-	Message string `json:"message,required"`
-}
-
-func (err *errorMessage) Error() string {
-	return err.Message
-}
-
-// do performs a JSON RPC Call.
-//     url   : The endpoint url.
-//     method: The name of the method which is going to be called.
-//     params: The JSON object representing all the params.
-func (c *Client) do(method string, params ...interface{} /*json.Marshaler*/) (json.RawMessage, error) {
-	jsonReq := jsonRPCrequest{
+// newRequest builds a JSON RPC request with a fresh unique ID.
+func newRequest(method string, params []interface{}) jsonRPCrequest {
+	return jsonRPCrequest{
 		JSONRpcVersion: "1.0",
 		Method:         method,
 		Params:         params,
 		ID:             uuid.NewV4().String(),
 	}
+}
 
-	reqBody, err := json.Marshal(jsonReq)
+// do performs a JSON RPC Call, retrying it according to the retry policy of the client.
+//     ctx   : The context of the call, cancelling it aborts the HTTP request.
+//     method: The name of the method which is going to be called.
+//     params: The JSON object representing all the params.
+func (c *Client) do(ctx context.Context, method string, params ...interface{} /*json.Marshaler*/) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := c.retry(ctx, []string{method}, func() error {
+		var err error
+		result, err = c.transport.Call(ctx, method, params)
+		return withMethod(err, method)
+	})
+	return result, err
+}
+
+// withMethod sets the method name of RPC errors lacking it.
+func withMethod(err error, method string) error {
+	var rpcErr *RPCError
+	if errors.As(err, &rpcErr) && rpcErr.Method == "" {
+		rpcErr.Method = method
+	}
+	return err
+}
+
+// httpTransport is the default Transport, speaking JSON-RPC over HTTP with the node.
+type httpTransport struct {
+	url        string       // The url of the node to connect to.
+	authMu     sync.RWMutex // The lock of user and pass, reloaded with the cookie.
+	user       string       // The RPC Username.
+	pass       string       // The RPC Password.
+	cookiePath string       // The cookie file path, empty if not using cookie auth.
+	httpClient *http.Client // The JSON-RPC over HTTP sub client.
+	headers    http.Header  // The additional headers sent on every request.
+}
+
+// Call performs a single JSON RPC Call.
+func (t *httpTransport) Call(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+	content, err := t.post(ctx, newRequest(method, params))
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", c.url, bytes.NewBuffer(reqBody))
+	var jsonResp jsonRPCresponse
+	err = json.Unmarshal(content, &jsonResp)
 	if err != nil {
 		return nil, err
 	}
+	if jsonResp.Error != nil {
+		jsonResp.Error.Method = method
+		return nil, jsonResp.Error
+	}
+
+	return jsonResp.Result, nil
+}
+
+// CallBatch performs all the calls as a single JSON-RPC array request,
+// matching the responses back by ID.
+func (t *httpTransport) CallBatch(ctx context.Context, calls []*RPCCall) error {
+	requests := make([]jsonRPCrequest, 0, len(calls))
+	byID := make(map[string]*RPCCall, len(calls))
+	for _, call := range calls {
+		request := newRequest(call.Method, call.Params)
+		requests = append(requests, request)
+		byID[request.ID] = call
+	}
 
-	req.SetBasicAuth(c.user, c.pass)
+	content, err := t.post(ctx, requests)
+	if err != nil {
+		return err
+	}
 
-	resp, err := c.httpClient.Do(req)
+	var responses []jsonRPCresponse
+	err = json.Unmarshal(content, &responses)
+	if err != nil {
+		// The node answers with a single error object when
+		// the whole batch is rejected.
+		var jsonResp jsonRPCresponse
+		if json.Unmarshal(content, &jsonResp) == nil && jsonResp.Error != nil {
+			err = jsonResp.Error
+		}
+		return err
+	}
+
+	for _, jsonResp := range responses {
+		call, ok := byID[jsonResp.ID]
+		if !ok {
+			continue
+		}
+		if jsonResp.Error != nil {
+			jsonResp.Error.Method = call.Method
+			call.Err = jsonResp.Error
+			continue
+		}
+		call.Result = jsonResp.Result
+		call.Err = nil
+	}
+
+	return nil
+}
+
+// post sends the JSON encoded payload to the node and returns the raw response body.
+//     ctx     : The context of the call, cancelling it aborts the HTTP request.
+//     payload : A single request or an array of requests (batch).
+//
+// When the client authenticates with a cookie file and the node rejects the
+// credentials, the cookie is read again and the request is retried once.
+func (t *httpTransport) post(ctx context.Context, payload interface{}) ([]byte, error) {
+	reqBody, err := json.Marshal(payload)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	content, err := ioutil.ReadAll(resp.Body)
+	content, err := t.send(ctx, reqBody)
+	if t.cookiePath != "" && IsUnauthorized(err) {
+		// The node may have been restarted, rotating the cookie.
+		if t.reloadCookie() == nil {
+			content, err = t.send(ctx, reqBody)
+		}
+	}
+
+	return content, err
+}
+
+// send performs a single HTTP request with the given body.
+func (t *httpTransport) send(ctx context.Context, reqBody []byte) ([]byte, error) {
+	req, err := newHTTPRequest(ctx, "POST", t.url, bytes.NewReader(reqBody), t.headers)
 	if err != nil {
 		return nil, err
 	}
+	user, pass := t.credentials()
+	req.SetBasicAuth(user, pass)
 
-	var jsonResp jsonRPCresponse
-	err = json.Unmarshal(content, &jsonResp)
+	status, content, err := doHTTPRequest(t.httpClient, req)
 	if err != nil {
 		return nil, err
 	}
-	if jsonResp.Error != nil {
-		return nil, jsonResp.Error
+
+	// The node sends RPC errors along with a non 200 status code
+	// (e.g. 404 for unknown methods, 500 for failing calls), in that
+	// case the JSON error object is decoded by the caller.
+	if status != http.StatusOK && !hasJSONRPCError(content) {
+		return nil, newHTTPError(status, content)
 	}
 
-	return jsonResp.Result, nil
+	return content, nil
+}
+
+// credentials returns the RPC Username and Password.
+func (t *httpTransport) credentials() (string, string) {
+	t.authMu.RLock()
+	defer t.authMu.RUnlock()
+	return t.user, t.pass
+}
+
+// hasJSONRPCError returns true if content is a JSON-RPC response
+// carrying an error object.
+func hasJSONRPCError(content []byte) bool {
+	var jsonResp jsonRPCresponse
+	err := json.Unmarshal(content, &jsonResp)
+	return err == nil && jsonResp.Error != nil
 }
@@ -0,0 +1,199 @@
+package syscoinrpc
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidMerkleProof is returned when a merkle proof is malformed.
+var ErrInvalidMerkleProof = errors.New("Invalid merkle proof")
+
+// ErrMerkleRootMismatch is returned when the merkle root of a proof does not
+// match the one of the block header it is verified against.
+var ErrMerkleRootMismatch = errors.New("Merkle root mismatch")
+
+// ErrNilHeader is returned when verifying a merkle proof against a nil block header.
+var ErrNilHeader = errors.New("Block header must not be nil")
+
+// maxMerkleProofTransactions is the maximum number of transactions of a proof,
+// the maximum block weight divided by the minimum transaction weight.
+const maxMerkleProofTransactions = 4000000 / 240
+
+// MerkleProof represents a merkle block, the proof returned by GetTxOutProof
+// that some transactions are included in a block.
+//
+// It contains the block header and a partial merkle tree, made of the hashes
+// of the tree nodes needed to recompute the merkle root from the proven
+// transaction IDs, walked depth first.
+type MerkleProof struct {
+	// Header is the header of the block, the fields that depend on the chain state are zero.
+	Header *FullBlockHeader
+	// TotalTransactions is the number of transactions in the block.
+	TotalTransactions uint32
+	// Hashes are the hashes of the partial merkle tree, depth first.
+	Hashes []Hash
+	// Flags are the bits of the partial merkle tree, depth first, least significant bit first.
+	// A bit is set on the nodes which are ancestors of (or are) a proven transaction.
+	Flags []byte
+}
+
+// ParseMerkleProof parses a serialized merkle block, as returned by GetTxOutProof.
+// Merge mined blocks have their AuxPoW data between the header and the partial
+// merkle tree, it is skipped.
+func ParseMerkleProof(rawProof []byte) (*MerkleProof, error) {
+	r := &wireReader{data: rawProof}
+	proof := &MerkleProof{Header: r.blockHeader()}
+	if r.err == nil && proof.Header.Version&blockVersionAuxPow != 0 {
+		r.auxPow(networkAddressParams[MainNet])
+	}
+	proof.TotalTransactions = r.uint32()
+	proof.Hashes = r.hashes()
+	proof.Flags = append([]byte(nil), r.varBytes()...)
+	r.end()
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return proof, nil
+}
+
+// ParseMerkleProofHex is like ParseMerkleProof but takes the hex encoded proof.
+func ParseMerkleProofHex(proof string) (*MerkleProof, error) {
+	rawProof, err := hex.DecodeString(proof)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMerkleProof(rawProof)
+}
+
+// ExtractMatches recomputes the merkle root of the partial merkle tree,
+// returning it with the proven transaction IDs, in block order.
+//
+// It checks the consistency of the tree, like the node does, but not
+// that the root matches the one of the header, see Verify.
+func (mp *MerkleProof) ExtractMatches() (root Hash, txIDs []Hash, err error) {
+	if mp.TotalTransactions == 0 {
+		return root, nil, fmt.Errorf("%w: no transactions", ErrInvalidMerkleProof)
+	}
+	if mp.TotalTransactions > maxMerkleProofTransactions {
+		return root, nil, fmt.Errorf("%w: too many transactions", ErrInvalidMerkleProof)
+	}
+	if len(mp.Hashes) > int(mp.TotalTransactions) {
+		return root, nil, fmt.Errorf("%w: more hashes than transactions", ErrInvalidMerkleProof)
+	}
+	if len(mp.Flags)*8 < len(mp.Hashes) {
+		return root, nil, fmt.Errorf("%w: fewer flags than hashes", ErrInvalidMerkleProof)
+	}
+
+	height := 0
+	for mp.treeWidth(height) > 1 {
+		height++
+	}
+
+	t := &merkleTraversal{proof: mp}
+	root = t.traverse(height, 0)
+	if t.err != nil {
+		return Hash{}, nil, t.err
+	}
+	if (t.bitsUsed+7)/8 != len(mp.Flags) {
+		return Hash{}, nil, fmt.Errorf("%w: unused flags", ErrInvalidMerkleProof)
+	}
+	if t.hashesUsed != len(mp.Hashes) {
+		return Hash{}, nil, fmt.Errorf("%w: unused hashes", ErrInvalidMerkleProof)
+	}
+
+	return root, t.matches, nil
+}
+
+// Verify checks that the proof is consistent and that its merkle root matches
+// the one of the given header, obtained from a trusted source, returning the
+// proven transaction IDs.
+func (mp *MerkleProof) Verify(header *FullBlockHeader) ([]Hash, error) {
+	if header == nil {
+		return nil, ErrNilHeader
+	}
+	if mp.Header == nil {
+		return nil, fmt.Errorf("%w: missing header", ErrInvalidMerkleProof)
+	}
+
+	root, txIDs, err := mp.ExtractMatches()
+	if err != nil {
+		return nil, err
+	}
+	if root != mp.Header.MerkleRoot {
+		return nil, fmt.Errorf("%w: the proof does not match its own header", ErrInvalidMerkleProof)
+	}
+	if root != header.MerkleRoot {
+		return nil, fmt.Errorf("%w: got %s, expected %s", ErrMerkleRootMismatch, root, header.MerkleRoot)
+	}
+
+	return txIDs, nil
+}
+
+// VerifyMerkleProof verifies a hex encoded proof returned by GetTxOutProof against
+// the header of the block, obtained from a trusted source, without asking the node
+// like VerifyTxOutProof does. It returns the proven transaction IDs.
+//
+//     proof  : The hex-encoded proof generated by `gettxoutproof`.
+//     header : The trusted header of the block the proof refers to.
+func VerifyMerkleProof(proof string, header *FullBlockHeader) ([]Hash, error) {
+	mp, err := ParseMerkleProofHex(proof)
+	if err != nil {
+		return nil, err
+	}
+	return mp.Verify(header)
+}
+
+// treeWidth returns the number of nodes of the merkle tree at the given height,
+// 0 being the height of the transactions.
+func (mp *MerkleProof) treeWidth(height int) uint32 {
+	return uint32((uint64(mp.TotalTransactions) + (1 << uint(height)) - 1) >> uint(height))
+}
+
+// merkleTraversal is the state of the depth first walk of a partial merkle tree.
+type merkleTraversal struct {
+	proof      *MerkleProof // The walked proof.
+	bitsUsed   int          // The number of flag bits consumed.
+	hashesUsed int          // The number of hashes consumed.
+	matches    []Hash       // The proven transaction IDs.
+	err        error        // The first error met.
+}
+
+// traverse returns the hash of the node at the given height and position.
+func (t *merkleTraversal) traverse(height int, pos uint32) Hash {
+	if t.err != nil {
+		return Hash{}
+	}
+	if t.bitsUsed >= len(t.proof.Flags)*8 {
+		t.err = fmt.Errorf("%w: overflowed the flags", ErrInvalidMerkleProof)
+		return Hash{}
+	}
+	parentOfMatch := t.proof.Flags[t.bitsUsed/8]&(1<<uint(t.bitsUsed%8)) != 0
+	t.bitsUsed++
+
+	if height == 0 || !parentOfMatch {
+		if t.hashesUsed >= len(t.proof.Hashes) {
+			t.err = fmt.Errorf("%w: overflowed the hashes", ErrInvalidMerkleProof)
+			return Hash{}
+		}
+		hash := t.proof.Hashes[t.hashesUsed]
+		t.hashesUsed++
+		if height == 0 && parentOfMatch {
+			t.matches = append(t.matches, hash)
+		}
+		return hash
+	}
+
+	left := t.traverse(height-1, pos*2)
+	right := left
+	if pos*2+1 < t.proof.treeWidth(height-1) {
+		right = t.traverse(height-1, pos*2+1)
+		// Identical siblings allow to forge proofs of duplicated transactions (CVE-2012-2459).
+		if t.err == nil && right == left {
+			t.err = fmt.Errorf("%w: identical sibling hashes", ErrInvalidMerkleProof)
+			return Hash{}
+		}
+	}
+	return doubleSHA256(append(left[:], right[:]...))
+}
@@ -0,0 +1,105 @@
+package syscoinrpc_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+	"github.com/thebotguys/golang-syscoin-rpc-client/syscoinrpctest"
+)
+
+// testProofTxID is the transaction proven by testProof, the only one of its block.
+var testProofTxID = syscoinrpc.MustParseHash("0f6f667c7b31395a5c0e25b5b0f10cb94a1fa1d4be0f0c9b83e5d99e30ffeed3")
+
+func TestVerifyMerkleProofInvalid(t *testing.T) {
+	header := &syscoinrpc.FullBlockHeader{MerkleRoot: testProofTxID}
+
+	_, err := syscoinrpc.VerifyMerkleProof("zz", header)
+	require.Error(t, err, "Must error on malformed hex")
+
+	_, err = syscoinrpc.VerifyMerkleProof(testProof[:len(testProof)-2], header)
+	require.True(t, errors.Is(err, syscoinrpc.ErrMalformedRawData), "Must error on truncated proofs")
+
+	_, err = syscoinrpc.VerifyMerkleProof(testProof, &syscoinrpc.FullBlockHeader{})
+	require.True(t, errors.Is(err, syscoinrpc.ErrMerkleRootMismatch), "Must error when the root does not match the header")
+
+	_, err = syscoinrpc.VerifyMerkleProof(testProof, nil)
+	require.Equal(t, syscoinrpc.ErrNilHeader, err, "Must error on nil headers")
+
+	proof, err := syscoinrpc.ParseMerkleProofHex(testProof)
+	require.NoError(t, err)
+
+	tampered := *proof
+	tampered.Header = nil
+	_, err = tampered.Verify(header)
+	require.True(t, errors.Is(err, syscoinrpc.ErrInvalidMerkleProof), "Must error on proofs without header")
+
+	tampered = *proof
+	tampered.Hashes = []syscoinrpc.Hash{{1}}
+	_, err = tampered.Verify(header)
+	require.True(t, errors.Is(err, syscoinrpc.ErrInvalidMerkleProof), "Must error when the root does not match the proof header")
+
+	tampered = *proof
+	tampered.TotalTransactions = 0
+	_, err = tampered.Verify(header)
+	require.True(t, errors.Is(err, syscoinrpc.ErrInvalidMerkleProof), "Must error on empty blocks")
+
+	tampered = *proof
+	tampered.Flags = []byte{1, 0}
+	_, err = tampered.Verify(header)
+	require.True(t, errors.Is(err, syscoinrpc.ErrInvalidMerkleProof), "Must error on unused flags")
+
+	tampered = *proof
+	tampered.Hashes = append(tampered.Hashes, testProofTxID)
+	tampered.TotalTransactions = 2
+	tampered.Flags = []byte{0x07}
+	_, err = tampered.Verify(header)
+	require.True(t, errors.Is(err, syscoinrpc.ErrInvalidMerkleProof), "Must error on duplicated transactions")
+
+	tampered.Flags = []byte{}
+	_, err = tampered.Verify(header)
+	require.True(t, errors.Is(err, syscoinrpc.ErrInvalidMerkleProof), "Must error on missing flags")
+}
+
+func TestVerifyMerkleProofOK(t *testing.T) {
+	proof, err := syscoinrpc.ParseMerkleProofHex(testProof)
+	require.NoError(t, err, "Must parse merkle blocks with AuxPoW data")
+	require.Equal(t, uint32(1), proof.TotalTransactions)
+	require.Equal(t, "30000100", proof.Header.VersionHex)
+	require.Equal(t, testProofTxID, proof.Header.MerkleRoot)
+
+	txIDs, err := syscoinrpc.VerifyMerkleProof(testProof, proof.Header)
+	require.NoError(t, err, "Must verify the proofs of the node")
+	require.Equal(t, []syscoinrpc.Hash{testProofTxID}, txIDs)
+
+	srv := syscoinrpctest.NewServer("user", "pass")
+	t.Cleanup(srv.Close)
+	for i := byte(0); i < 6; i++ {
+		srv.AddRawTransaction([]byte{1, 0, 0, 0, 0, 0, i, 0, 0, 0})
+	}
+	blockHash := srv.Generate(1)[0]
+	cl, err := srv.Client(testTimeout)
+	require.NoError(t, err)
+
+	block, err := cl.Blockchain.GetFullBlock(blockHash)
+	require.NoError(t, err)
+	require.Len(t, block.Tx, 7)
+	proven := []syscoinrpc.Hash{block.Tx[2], block.Tx[5], block.Tx[6]}
+
+	proofHex, err := cl.Blockchain.GetTxOutProofInBlock([]syscoinrpc.Hash{block.Tx[6], block.Tx[2], block.Tx[5]}, blockHash)
+	require.NoError(t, err, "GetTxOutProofInBlock: must not error")
+	txIDs, err = syscoinrpc.VerifyMerkleProof(proofHex, block.FullBlockHeader)
+	require.NoError(t, err, "Must verify proofs of unbalanced trees")
+	require.Equal(t, proven, txIDs, "Must return the proven transactions in block order")
+
+	nodeTxIDs, err := cl.Blockchain.VerifyTxOutProof(proofHex)
+	require.NoError(t, err)
+	require.Equal(t, nodeTxIDs, txIDs, "Must agree with the node")
+
+	proofHex, err = cl.Blockchain.GetTxOutProof(block.Tx[:1])
+	require.NoError(t, err, "GetTxOutProof: must not error")
+	txIDs, err = syscoinrpc.VerifyMerkleProof(proofHex, block.FullBlockHeader)
+	require.NoError(t, err)
+	require.Equal(t, block.Tx[:1], txIDs)
+}
@@ -0,0 +1,477 @@
+package syscoinrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// MiningClient wraps all `mining` related functions.
+type MiningClient struct {
+	c *Client // The binded client, must not be nil.
+}
+
+func (mc *MiningClient) do(ctx context.Context, method string, params ...interface{}) (json.RawMessage, error) {
+	return mc.c.do(ctx, method, params...)
+}
+
+// MiningInfo represents the response of a `getmininginfo` call.
+type MiningInfo struct {
+	// Blocks is the current block height.
+	Blocks uint64 `json:"blocks,required"`
+	// CurrentBlockWeight is the last block weight.
+	CurrentBlockWeight uint64 `json:"currentblockweight,required"`
+	// CurrentBlockTx is the last block transaction count.
+	CurrentBlockTx uint64 `json:"currentblocktx,required"`
+	// Difficulty is the current difficulty.
+	Difficulty float64 `json:"difficulty,required"`
+	// NetworkHashPS is the network hashes per second.
+	NetworkHashPS float64 `json:"networkhashps,required"`
+	// PooledTx is the size of the mempool.
+	PooledTx uint64 `json:"pooledtx,required"`
+	// Chain is the current network name (main, test, regtest).
+	Chain string `json:"chain,required"`
+	// Warnings are any network and blockchain warnings.
+	Warnings string `json:"warnings,required"`
+}
+
+// GetMiningInfo returns a json object containing mining-related information.
+func (mc *MiningClient) GetMiningInfo() (*MiningInfo, error) {
+	return mc.GetMiningInfoContext(context.Background())
+}
+
+// GetMiningInfoContext is like GetMiningInfo but uses the given context for the call.
+func (mc *MiningClient) GetMiningInfoContext(ctx context.Context) (*MiningInfo, error) {
+	response, err := mc.do(ctx, "getmininginfo")
+	if err != nil {
+		return nil, err
+	}
+
+	var info MiningInfo
+	err = json.Unmarshal(response, &info)
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// GetNetworkHashPS returns the estimated network hashes per second
+// based on the last n blocks.
+//
+//     nBlocks : The number of blocks, -1 (or 0) for the blocks since the last difficulty change (default = 120).
+//     height  : The height of the estimation, -1 for the tip.
+func (mc *MiningClient) GetNetworkHashPS(nBlocks int64, height int64) (float64, error) {
+	return mc.GetNetworkHashPSContext(context.Background(), nBlocks, height)
+}
+
+// GetNetworkHashPSContext is like GetNetworkHashPS but uses the given context for the call.
+func (mc *MiningClient) GetNetworkHashPSContext(ctx context.Context, nBlocks int64, height int64) (float64, error) {
+	response, err := mc.do(ctx, "getnetworkhashps", nBlocks, height)
+	if err != nil {
+		return 0, err
+	}
+
+	var hashPS float64
+	err = json.Unmarshal(response, &hashPS)
+	if err != nil {
+		return 0, err
+	}
+
+	return hashPS, nil
+}
+
+// BlockTemplateRequest represents the template request of a `getblocktemplate` call.
+type BlockTemplateRequest struct {
+	// Mode is "template" or omitted.
+	Mode string `json:"mode,omitempty"`
+	// Capabilities are the client side supported features
+	// (e.g. "longpoll", "coinbasetxn", "coinbasevalue", "proposal").
+	Capabilities []string `json:"capabilities,omitempty"`
+	// Rules are the client side supported softfork deployments (e.g. "segwit").
+	Rules []string `json:"rules,omitempty"`
+	// LongPollID makes the call wait for a new template, when set to the
+	// LongPollID of the previous template.
+	LongPollID string `json:"longpollid,omitempty"`
+}
+
+// BlockTemplate represents the response of a `getblocktemplate` call,
+// everything needed to build and mine a new block.
+type BlockTemplate struct {
+	// Capabilities are the specific block template features supported by the node.
+	Capabilities []string `json:"capabilities,required"`
+	// Version is the preferred block version.
+	Version uint32 `json:"version,required"`
+	// Rules are the specific block rules to be enforced.
+	Rules []string `json:"rules,required"`
+	// VBAvailable are the pending versionbits softfork deployments,
+	// with the bit number used to signal each of them.
+	VBAvailable map[string]uint32 `json:"vbavailable,required"`
+	// VBRequired is the bit mask of the versionbits the node requires to be set.
+	VBRequired uint32 `json:"vbrequired,required"`
+	// PreviousBlockHash is the hash of the current highest block.
+	PreviousBlockHash Hash `json:"previousblockhash,required"`
+	// Transactions are the non-coinbase transactions to be included in the next block.
+	Transactions []*BlockTemplateTransaction `json:"transactions,required"`
+	// CoinbaseAux is the data that should be included in the coinbase's scriptSig content.
+	CoinbaseAux map[string]string `json:"coinbaseaux,required"`
+	// CoinbaseValue is the maximum allowable input to coinbase transaction,
+	// including the generation award and transaction fees.
+	CoinbaseValue SatoshiAmount `json:"coinbasevalue,required"`
+	// LongPollID is the id to use for a long poll request, see BlockTemplateRequest.
+	LongPollID string `json:"longpollid,required"`
+	// Target is the hash target, in hex.
+	Target string `json:"target,required"`
+	// MinTime is the minimum timestamp appropriate for the next block time
+	// in seconds since epoch (Jan 1 1970 GMT).
+	MinTime uint64 `json:"mintime,required"`
+	// Mutable is the list of ways the block template may be changed (e.g. "time", "transactions", "prevblock").
+	Mutable []string `json:"mutable,required"`
+	// NonceRange is the range of valid nonces, in hex.
+	NonceRange string `json:"noncerange,required"`
+	// SigOpLimit is the limit of sigops in blocks.
+	SigOpLimit uint64 `json:"sigoplimit,required"`
+	// SizeLimit is the limit of block size.
+	SizeLimit uint64 `json:"sizelimit,required"`
+	// WeightLimit is the limit of block weight.
+	WeightLimit uint64 `json:"weightlimit,required"`
+	// CurTime is the current timestamp in seconds since epoch (Jan 1 1970 GMT).
+	CurTime uint64 `json:"curtime,required"`
+	// Bits are the compressed target of the next block.
+	Bits string `json:"bits,required"`
+	// Height is the height of the next block.
+	Height uint64 `json:"height,required"`
+	// DefaultWitnessCommitment is the witness commitment output script of the
+	// coinbase, in hex, only set when the template has witness transactions.
+	DefaultWitnessCommitment string `json:"default_witness_commitment,omitempty"`
+	// Masternode is the masternode payment the coinbase must include,
+	// its Payee is empty when no masternode is paid.
+	Masternode *BlockTemplatePayee `json:"masternode,required"`
+	// MasternodePaymentsStarted is true if the masternode payments started.
+	MasternodePaymentsStarted bool `json:"masternode_payments_started,required"`
+	// MasternodePaymentsEnforced is true if the masternode payments are enforced.
+	MasternodePaymentsEnforced bool `json:"masternode_payments_enforced,required"`
+	// Superblock are the governance payments the coinbase must include,
+	// empty when the next block is not a superblock.
+	Superblock []*BlockTemplatePayee `json:"superblock,required"`
+	// SuperblocksStarted is true if the superblocks started.
+	SuperblocksStarted bool `json:"superblocks_started,required"`
+	// SuperblocksEnabled is true if the superblocks are enabled.
+	SuperblocksEnabled bool `json:"superblocks_enabled,required"`
+}
+
+// BlockTemplateTransaction represents a transaction of a block template.
+type BlockTemplateTransaction struct {
+	// Data is the serialized transaction, in hex.
+	Data string `json:"data,required"`
+	// TxID is the transaction ID.
+	TxID Hash `json:"txid,required"`
+	// Hash is the transaction hash including witness data.
+	Hash Hash `json:"hash,required"`
+	// Depends are the 1-based indexes of the transactions of the
+	// template this transaction depends on.
+	Depends []uint64 `json:"depends,required"`
+	// Fee is the difference between the inputs and the outputs of the transaction.
+	Fee SatoshiAmount `json:"fee,required"`
+	// SigOps is the total SigOps cost, as counted for the SigOpLimit.
+	SigOps uint64 `json:"sigops,required"`
+	// Weight is the total transaction weight, as counted for the WeightLimit.
+	Weight uint64 `json:"weight,required"`
+}
+
+// BlockTemplatePayee represents a payment the coinbase of a block template must include.
+type BlockTemplatePayee struct {
+	// Payee is the address paid.
+	Payee string `json:"payee,required"`
+	// Script is the output script paid, in hex.
+	Script string `json:"script,required"`
+	// Amount is the amount paid.
+	Amount SatoshiAmount `json:"amount,required"`
+}
+
+// GetBlockTemplate returns the data needed to construct a block to work on,
+// as described by BIP 22 and BIP 23.
+//
+//     request : The template request, nil for a template with the "segwit" rule,
+//               which the node requires.
+func (mc *MiningClient) GetBlockTemplate(request *BlockTemplateRequest) (*BlockTemplate, error) {
+	return mc.GetBlockTemplateContext(context.Background(), request)
+}
+
+// GetBlockTemplateContext is like GetBlockTemplate but uses the given context for the call.
+//
+// Long poll requests wait for a new template, until the context is done.
+func (mc *MiningClient) GetBlockTemplateContext(ctx context.Context, request *BlockTemplateRequest) (*BlockTemplate, error) {
+	if request == nil {
+		request = &BlockTemplateRequest{Rules: []string{"segwit"}}
+	}
+
+	response, err := mc.do(ctx, "getblocktemplate", request)
+	if err != nil {
+		return nil, err
+	}
+
+	var template BlockTemplate
+	err = json.Unmarshal(response, &template)
+	if err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+// BlockRejectReason is the reason of a block rejection, as reported by `submitblock` (BIP 22).
+type BlockRejectReason string
+
+// The generic block rejection reasons. BlockRejectDuplicate, BlockRejectDuplicateInconclusive
+// and BlockRejectInconclusive mean that the node has the block, they are not rejections.
+const (
+	// BlockRejectDuplicate means that the node already has the block.
+	BlockRejectDuplicate BlockRejectReason = "duplicate"
+	// BlockRejectDuplicateInvalid means that the node already has the block, and it is invalid.
+	BlockRejectDuplicateInvalid BlockRejectReason = "duplicate-invalid"
+	// BlockRejectDuplicateInconclusive means that the node already has the block,
+	// but did not validate it (e.g. it is not on the active chain).
+	BlockRejectDuplicateInconclusive BlockRejectReason = "duplicate-inconclusive"
+	// BlockRejectInconclusive means that the block was accepted but not validated
+	// (e.g. it is not on the active chain).
+	BlockRejectInconclusive BlockRejectReason = "inconclusive"
+	// BlockRejectRejected means that the block was rejected for an unknown reason.
+	BlockRejectRejected BlockRejectReason = "rejected"
+)
+
+// The most common specific block rejection reasons.
+const (
+	// BlockRejectHighHash means that the block hash does not meet the target.
+	BlockRejectHighHash BlockRejectReason = "high-hash"
+	// BlockRejectBadDiffBits means that the block bits do not match the expected target.
+	BlockRejectBadDiffBits BlockRejectReason = "bad-diffbits"
+	// BlockRejectBadMerkleRoot means that the merkle root does not match the transactions.
+	BlockRejectBadMerkleRoot BlockRejectReason = "bad-txnmrklroot"
+	// BlockRejectPrevNotFound means that the previous block is unknown.
+	BlockRejectPrevNotFound BlockRejectReason = "prev-blk-not-found"
+	// BlockRejectBadPrevBlock means that the previous block is invalid.
+	BlockRejectBadPrevBlock BlockRejectReason = "bad-prevblk"
+	// BlockRejectTimeTooOld means that the block time is not after the median time of the previous blocks.
+	BlockRejectTimeTooOld BlockRejectReason = "time-too-old"
+	// BlockRejectTimeTooNew means that the block time is too far in the future.
+	BlockRejectTimeTooNew BlockRejectReason = "time-too-new"
+	// BlockRejectBadVersion means that the block version is obsolete.
+	BlockRejectBadVersion BlockRejectReason = "bad-version"
+	// BlockRejectBadCoinbaseAmount means that the coinbase pays more than the CoinbaseValue.
+	BlockRejectBadCoinbaseAmount BlockRejectReason = "bad-cb-amount"
+	// BlockRejectBadCoinbaseHeight means that the coinbase does not start with the block height.
+	BlockRejectBadCoinbaseHeight BlockRejectReason = "bad-cb-height"
+	// BlockRejectBadCoinbasePayee means that the coinbase does not include the masternode
+	// or the superblock payments.
+	BlockRejectBadCoinbasePayee BlockRejectReason = "bad-cb-payee"
+)
+
+// acceptedResults are the `submitblock` results meaning that the node has the block,
+// even if it did not validate it.
+var acceptedResults = map[BlockRejectReason]bool{
+	BlockRejectDuplicate:             true,
+	BlockRejectDuplicateInconclusive: true,
+	BlockRejectInconclusive:          true,
+}
+
+// ErrBlockRejected is returned when the node rejects a submitted block,
+// wrapped in a BlockRejectedError.
+var ErrBlockRejected = errors.New("Block rejected")
+
+// BlockRejectedError represents the rejection of a submitted block.
+//
+// It wraps ErrBlockRejected, use errors.Is to check it and errors.As to get the reason.
+type BlockRejectedError struct {
+	// Reason is the rejection reason, one of the BlockReject* constants
+	// or any other reason reported by the node.
+	Reason BlockRejectReason
+}
+
+func (err *BlockRejectedError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrBlockRejected, err.Reason)
+}
+
+// Unwrap returns ErrBlockRejected.
+func (err *BlockRejectedError) Unwrap() error {
+	return ErrBlockRejected
+}
+
+// SubmitBlock submits a new block to the network (BIP 22).
+//
+// It returns a *BlockRejectedError if the node rejects the block. It returns nil
+// if the node accepts the block, even without validating it because it is not
+// on the active chain, and if the node already has the block.
+//
+//     rawBlockHex : The serialized block, in hex.
+func (mc *MiningClient) SubmitBlock(rawBlockHex string) error {
+	return mc.SubmitBlockContext(context.Background(), rawBlockHex)
+}
+
+// SubmitBlockContext is like SubmitBlock but uses the given context for the call.
+func (mc *MiningClient) SubmitBlockContext(ctx context.Context, rawBlockHex string) error {
+	response, err := mc.do(ctx, "submitblock", rawBlockHex)
+	if err != nil {
+		return err
+	}
+
+	var reason *BlockRejectReason
+	err = json.Unmarshal(response, &reason)
+	if err != nil {
+		return err
+	}
+	if reason != nil && !acceptedResults[*reason] {
+		return &BlockRejectedError{Reason: *reason}
+	}
+
+	return nil
+}
+
+// PrioritiseTransaction accepts the transaction into mined blocks at a higher
+// (or lower) priority, without changing the fee it actually pays.
+//
+// The fee delta is kept by the node even if the transaction is not in the mempool yet.
+//
+//     txID     : The transaction ID.
+//     feeDelta : The fee to add (or subtract, if negative) when selecting the
+//                transactions of a block.
+func (mc *MiningClient) PrioritiseTransaction(txID Hash, feeDelta Amount) (bool, error) {
+	return mc.PrioritiseTransactionContext(context.Background(), txID, feeDelta)
+}
+
+// PrioritiseTransactionContext is like PrioritiseTransaction but uses the given context for the call.
+func (mc *MiningClient) PrioritiseTransactionContext(ctx context.Context, txID Hash, feeDelta Amount) (bool, error) {
+	// The second param is the deprecated priority delta, which must be 0.
+	response, err := mc.do(ctx, "prioritisetransaction", txID, 0, feeDelta.Satoshis())
+	if err != nil {
+		return false, err
+	}
+
+	var prioritised bool
+	err = json.Unmarshal(response, &prioritised)
+	if err != nil {
+		return false, err
+	}
+
+	return prioritised, nil
+}
+
+// AuxBlock represents a block to merge mine, the response of a `createauxblock`
+// or `getauxblock` call.
+//
+// The work on the block is proven by an AuxPoW, built from a block of the parent
+// chain whose coinbase commits to Hash (see NewAuxPow), and submitted with
+// SubmitAuxBlock.
+type AuxBlock struct {
+	// Hash is the hash of the block to merge mine.
+	Hash Hash `json:"hash,required"`
+	// ChainID is the chain ID of the merge mined chain, used to place the
+	// block in the merkle tree of the merge mined chains.
+	ChainID uint32 `json:"chainid,required"`
+	// PreviousBlockHash is the hash of the previous block.
+	PreviousBlockHash Hash `json:"previousblockhash,required"`
+	// CoinbaseValue is the value of the coinbase of the block.
+	CoinbaseValue SatoshiAmount `json:"coinbasevalue,required"`
+	// Bits are the compressed target of the block.
+	Bits string `json:"bits,required"`
+	// Height is the height of the block.
+	Height uint64 `json:"height,required"`
+	// Target is the target the hash of the parent block must meet, in hex
+	// and in reversed byte order (little endian).
+	Target string `json:"_target,required"`
+}
+
+// auxBlockFields has the fields of AuxBlock, without its JSON methods.
+type auxBlockFields AuxBlock
+
+// UnmarshalJSON decodes the block, older nodes report Target as "target".
+func (ab *AuxBlock) UnmarshalJSON(data []byte) error {
+	err := json.Unmarshal(data, (*auxBlockFields)(ab))
+	if err != nil {
+		return err
+	}
+	if ab.Target != "" {
+		return nil
+	}
+
+	var legacy struct {
+		Target string `json:"target"`
+	}
+	err = json.Unmarshal(data, &legacy)
+	if err != nil {
+		return err
+	}
+	ab.Target = legacy.Target
+	return nil
+}
+
+// CreateAuxBlock creates a new block to merge mine, paying the coinbase to the given address.
+//
+//     address : The address the coinbase of the block pays to.
+func (mc *MiningClient) CreateAuxBlock(address string) (*AuxBlock, error) {
+	return mc.CreateAuxBlockContext(context.Background(), address)
+}
+
+// CreateAuxBlockContext is like CreateAuxBlock but uses the given context for the call.
+func (mc *MiningClient) CreateAuxBlockContext(ctx context.Context, address string) (*AuxBlock, error) {
+	return mc.auxBlock(ctx, "createauxblock", address)
+}
+
+// GetAuxBlock creates a new block to merge mine, paying the coinbase to a new
+// address of the wallet of the node.
+func (mc *MiningClient) GetAuxBlock() (*AuxBlock, error) {
+	return mc.GetAuxBlockContext(context.Background())
+}
+
+// GetAuxBlockContext is like GetAuxBlock but uses the given context for the call.
+func (mc *MiningClient) GetAuxBlockContext(ctx context.Context) (*AuxBlock, error) {
+	return mc.auxBlock(ctx, "getauxblock")
+}
+
+func (mc *MiningClient) auxBlock(ctx context.Context, method string, params ...interface{}) (*AuxBlock, error) {
+	response, err := mc.do(ctx, method, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	var block AuxBlock
+	err = json.Unmarshal(response, &block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &block, nil
+}
+
+// SubmitAuxBlock submits the AuxPoW of a block created by CreateAuxBlock or GetAuxBlock.
+//
+// It returns true if the node accepted the block, the reason of a rejection
+// is only reported in the log of the node.
+//
+//     hash   : The hash of the merge mined block, see AuxBlock.
+//     auxPow : The AuxPoW proving the work on the block, see NewAuxPow.
+func (mc *MiningClient) SubmitAuxBlock(hash Hash, auxPow *AuxPow) (bool, error) {
+	return mc.SubmitAuxBlockContext(context.Background(), hash, auxPow)
+}
+
+// SubmitAuxBlockContext is like SubmitAuxBlock but uses the given context for the call.
+func (mc *MiningClient) SubmitAuxBlockContext(ctx context.Context, hash Hash, auxPow *AuxPow) (bool, error) {
+	rawAuxPow, err := auxPow.SerializeHex()
+	if err != nil {
+		return false, err
+	}
+
+	response, err := mc.do(ctx, "submitauxblock", hash, rawAuxPow)
+	if err != nil {
+		return false, err
+	}
+
+	var accepted bool
+	err = json.Unmarshal(response, &accepted)
+	if err != nil {
+		return false, err
+	}
+
+	return accepted, nil
+}
@@ -0,0 +1,412 @@
+package syscoinrpc_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+	"github.com/thebotguys/golang-syscoin-rpc-client/syscoinrpctest"
+)
+
+// testMerkleRoot computes the merkle root of the transaction IDs.
+func testMerkleRoot(txIDs []syscoinrpc.Hash) syscoinrpc.Hash {
+	level := append([]syscoinrpc.Hash(nil), txIDs...)
+	for len(level) > 1 {
+		if len(level)%2 == 1 {
+			level = append(level, level[len(level)-1])
+		}
+		var next []syscoinrpc.Hash
+		for i := 0; i < len(level); i += 2 {
+			next = append(next, doubleSHA256(append(level[i][:], level[i+1][:]...)))
+		}
+		level = next
+	}
+	return level[0]
+}
+
+// mineTemplate builds a block from the template, with a coinbase paying the
+// masternode and the rest of the coinbase value to an OP_TRUE output, and
+// grinds its nonce until it meets the target. It returns the block in hex.
+func mineTemplate(t *testing.T, template *syscoinrpc.BlockTemplate) string {
+	var heightPush []byte
+	for height := template.Height; height > 0; height >>= 8 {
+		heightPush = append(heightPush, byte(height))
+	}
+	if heightPush[len(heightPush)-1]&0x80 != 0 {
+		heightPush = append(heightPush, 0)
+	}
+	masternodeScript, err := hex.DecodeString(template.Masternode.Script)
+	require.NoError(t, err)
+
+	coinbase := new(bytes.Buffer)
+	binary.Write(coinbase, binary.LittleEndian, uint32(1)) // version
+	coinbase.WriteByte(1)                                  // vin count
+	coinbase.Write(make([]byte, 32))
+	binary.Write(coinbase, binary.LittleEndian, uint32(0xffffffff))
+	coinbase.WriteByte(byte(len(heightPush) + 1))
+	coinbase.WriteByte(byte(len(heightPush)))
+	coinbase.Write(heightPush)
+	binary.Write(coinbase, binary.LittleEndian, uint32(0xffffffff))
+	coinbase.WriteByte(2) // vout count
+	binary.Write(coinbase, binary.LittleEndian, template.Masternode.Amount.Amount().Satoshis())
+	coinbase.WriteByte(byte(len(masternodeScript)))
+	coinbase.Write(masternodeScript)
+	binary.Write(coinbase, binary.LittleEndian, template.CoinbaseValue.Amount().Sub(template.Masternode.Amount.Amount()).Satoshis())
+	coinbase.Write([]byte{1, 0x51}) // OP_TRUE
+	coinbase.Write(make([]byte, 4)) // locktime
+
+	txIDs := []syscoinrpc.Hash{doubleSHA256(coinbase.Bytes())}
+	txs := [][]byte{coinbase.Bytes()}
+	for _, tx := range template.Transactions {
+		raw, err := hex.DecodeString(tx.Data)
+		require.NoError(t, err)
+		txIDs = append(txIDs, tx.TxID)
+		txs = append(txs, raw)
+	}
+	merkleRoot := testMerkleRoot(txIDs)
+	bits, err := strconv.ParseUint(template.Bits, 16, 32)
+	require.NoError(t, err)
+	target, err := hex.DecodeString(template.Target)
+	require.NoError(t, err)
+
+	header := new(bytes.Buffer)
+	for nonce := uint32(0); ; nonce++ {
+		header.Reset()
+		binary.Write(header, binary.LittleEndian, template.Version)
+		header.Write(template.PreviousBlockHash[:])
+		header.Write(merkleRoot[:])
+		binary.Write(header, binary.LittleEndian, uint32(template.CurTime))
+		binary.Write(header, binary.LittleEndian, uint32(bits))
+		binary.Write(header, binary.LittleEndian, nonce)
+		hash, err := hex.DecodeString(doubleSHA256(header.Bytes()).String())
+		require.NoError(t, err)
+		if bytes.Compare(hash, target) <= 0 {
+			break
+		}
+	}
+
+	block := bytes.NewBuffer(header.Bytes())
+	block.WriteByte(byte(len(txs)))
+	for _, tx := range txs {
+		block.Write(tx)
+	}
+	return hex.EncodeToString(block.Bytes())
+}
+
+// mineAuxBlock builds a parent block whose coinbase commits to the block to
+// merge mine, and grinds its nonce until its hash meets the target of the
+// block. It returns the AuxPoW of the block.
+func mineAuxBlock(t *testing.T, auxBlock *syscoinrpc.AuxBlock) *syscoinrpc.AuxPow {
+	coinbase := testParentCoinbase(append([]byte{1, 1}, syscoinrpc.AuxPowCommitment(auxBlock.Hash)...))
+	target, err := hex.DecodeString(auxBlock.Target)
+	require.NoError(t, err)
+	for i, j := 0, len(target)-1; i < j; i, j = i+1, j-1 {
+		target[i], target[j] = target[j], target[i]
+	}
+
+	for nonce := uint32(0); ; nonce++ {
+		header := testParentHeader(doubleSHA256(coinbase), nonce)
+		hash, err := hex.DecodeString(doubleSHA256(header).String())
+		require.NoError(t, err)
+		if bytes.Compare(hash, target) <= 0 {
+			auxPow, err := syscoinrpc.NewAuxPow(auxBlock.Hash, coinbase, nil, header, syscoinrpc.RegTest)
+			require.NoError(t, err)
+			return auxPow
+		}
+	}
+}
+
+func TestGetMiningInfoInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.Mining.GetMiningInfo()
+	require.Error(t, err, "Must error on any method with invalid URL")
+}
+
+func TestGetNetworkHashPSInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.Mining.GetNetworkHashPS(120, -1)
+	require.Error(t, err, "Must error on any method with invalid URL")
+}
+
+func TestGetBlockTemplateInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.Mining.GetBlockTemplate(nil)
+	require.Error(t, err, "Must error on any method with invalid URL")
+
+	cl = newFakeClient(t)
+	_, err = cl.Mining.GetBlockTemplate(&syscoinrpc.BlockTemplateRequest{})
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCInvalidParameter), "Must error without the segwit rule, got %v", err)
+}
+
+func TestSubmitBlockInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	err = cl.Mining.SubmitBlock("00")
+	require.Error(t, err, "Must error on any method with invalid URL")
+
+	srv := syscoinrpctest.NewServer("user", "pass")
+	t.Cleanup(srv.Close)
+	srv.Generate(10)
+	cl, err = srv.Client(testTimeout)
+	require.NoError(t, err)
+
+	err = cl.Mining.SubmitBlock("00")
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCDeserializationError), "Must error on malformed blocks, got %v", err)
+	require.False(t, errors.Is(err, syscoinrpc.ErrBlockRejected), "Malformed blocks are not rejections")
+
+	rawBlock, err := cl.Blockchain.GetBlock(srv.BlockHash(10))
+	require.NoError(t, err)
+	err = cl.Mining.SubmitBlock(rawBlock)
+	require.NoError(t, err, "Must not reject known blocks")
+
+	template, err := cl.Mining.GetBlockTemplate(nil)
+	require.NoError(t, err)
+	forkTemplate := *template
+	forkTemplate.PreviousBlockHash = srv.BlockHash(9)
+	err = cl.Mining.SubmitBlock(mineTemplate(t, &forkTemplate))
+	require.NoError(t, err, "Must not reject inconclusive blocks, which the node accepted without validating them")
+
+	var rejected *syscoinrpc.BlockRejectedError
+	rejections := map[syscoinrpc.BlockRejectReason]func(tmpl *syscoinrpc.BlockTemplate){
+		syscoinrpc.BlockRejectPrevNotFound:      func(tmpl *syscoinrpc.BlockTemplate) { tmpl.PreviousBlockHash[0]++ },
+		syscoinrpc.BlockRejectTimeTooOld:        func(tmpl *syscoinrpc.BlockTemplate) { tmpl.CurTime = tmpl.MinTime - 1 },
+		syscoinrpc.BlockRejectBadDiffBits:       func(tmpl *syscoinrpc.BlockTemplate) { tmpl.Bits = "207ffffe" },
+		syscoinrpc.BlockRejectBadCoinbaseHeight: func(tmpl *syscoinrpc.BlockTemplate) { tmpl.Height++ },
+		syscoinrpc.BlockRejectBadCoinbaseAmount: func(tmpl *syscoinrpc.BlockTemplate) { tmpl.CoinbaseValue++ },
+		syscoinrpc.BlockRejectBadCoinbasePayee:  func(tmpl *syscoinrpc.BlockTemplate) { tmpl.Masternode.Script = "51" },
+	}
+	for reason, alter := range rejections {
+		tmpl := *template
+		masternode := *template.Masternode
+		tmpl.Masternode = &masternode
+		alter(&tmpl)
+
+		err = cl.Mining.SubmitBlock(mineTemplate(t, &tmpl))
+		require.True(t, errors.As(err, &rejected), "Must reject the block, got %v", err)
+		require.Equal(t, reason, rejected.Reason)
+		require.Equal(t, "Block rejected: "+string(reason), err.Error())
+	}
+	require.Equal(t, uint64(10), srv.Height(), "Rejected blocks must not be connected")
+}
+
+func TestPrioritiseTransactionInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.Mining.PrioritiseTransaction(syscoinrpc.Hash{}, 1000)
+	require.Error(t, err, "Must error on any method with invalid URL")
+}
+
+func TestCreateAuxBlockInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.Mining.CreateAuxBlock(syscoinrpctest.DefaultAddress)
+	require.Error(t, err, "Must error on any method with invalid URL")
+
+	cl = newFakeClient(t)
+	_, err = cl.Mining.CreateAuxBlock("")
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCInvalidAddressOrKey), "Must error on invalid addresses, got %v", err)
+}
+
+func TestGetAuxBlockInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.Mining.GetAuxBlock()
+	require.Error(t, err, "Must error on any method with invalid URL")
+}
+
+func TestSubmitAuxBlockInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.Mining.SubmitAuxBlock(testBlockHeader.Hash, &testBlock.AuxPow)
+	require.Error(t, err, "Must error on any method with invalid URL")
+
+	_, err = cl.Mining.SubmitAuxBlock(testBlockHeader.Hash, &syscoinrpc.AuxPow{})
+	require.True(t, errors.Is(err, syscoinrpc.ErrInvalidAuxPow), "Must error on malformed AuxPoW before the call, got %v", err)
+
+	srv := syscoinrpctest.NewServer("user", "pass")
+	t.Cleanup(srv.Close)
+	srv.Generate(10)
+	cl, err = srv.Client(testTimeout)
+	require.NoError(t, err)
+
+	_, err = cl.Mining.SubmitAuxBlock(testBlockHeader.Hash, &testBlock.AuxPow)
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCInvalidParameter), "Must error on unknown blocks, got %v", err)
+
+	auxBlock, err := cl.Mining.CreateAuxBlock(syscoinrpctest.DefaultAddress)
+	require.NoError(t, err)
+	otherAuxBlock, err := cl.Mining.CreateAuxBlock(syscoinrpctest.MasternodeAddress)
+	require.NoError(t, err)
+	accepted, err := cl.Mining.SubmitAuxBlock(auxBlock.Hash, mineAuxBlock(t, otherAuxBlock))
+	require.NoError(t, err)
+	require.False(t, accepted, "Must reject AuxPoW committing to another block")
+	require.Equal(t, uint64(10), srv.Height(), "Rejected blocks must not be connected")
+}
+
+func TestGetMiningInfoOK(t *testing.T) {
+	cl := newFakeClient(t)
+
+	info, err := cl.Mining.GetMiningInfo()
+	require.NoError(t, err, "GetMiningInfo : must not error")
+	require.Equal(t, uint64(10), info.Blocks)
+	require.Equal(t, "regtest", info.Chain)
+
+	t.Log("GetMiningInfo :", info)
+}
+
+func TestGetNetworkHashPSOK(t *testing.T) {
+	cl := newFakeClient(t)
+
+	hashPS, err := cl.Mining.GetNetworkHashPS(120, -1)
+	require.NoError(t, err, "GetNetworkHashPS : must not error")
+	require.True(t, hashPS > 0, "Must estimate the hashes per second")
+
+	info, err := cl.Mining.GetMiningInfo()
+	require.NoError(t, err)
+	require.Equal(t, info.NetworkHashPS, hashPS)
+
+	hashPS, err = cl.Mining.GetNetworkHashPS(-1, 0)
+	require.NoError(t, err, "GetNetworkHashPS : must not error")
+	require.Zero(t, hashPS, "The genesis block has no hashes per second")
+}
+
+func TestGetBlockTemplateOK(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	t.Cleanup(srv.Close)
+	srv.Generate(10)
+	cl, err := srv.Client(testTimeout)
+	require.NoError(t, err)
+
+	_, rawTx := testWitnessTx()
+	txID := srv.AddRawTransaction(rawTx)
+
+	template, err := cl.Mining.GetBlockTemplate(nil)
+	require.NoError(t, err, "GetBlockTemplate : must not error")
+	require.Equal(t, uint64(11), template.Height)
+	require.Equal(t, srv.BlockHash(10), template.PreviousBlockHash)
+	require.Len(t, template.Transactions, 1)
+	require.Equal(t, txID, template.Transactions[0].TxID)
+	require.Equal(t, hex.EncodeToString(rawTx), template.Transactions[0].Data)
+	require.Equal(t, syscoinrpc.SatoshiAmount(syscoinrpctest.BlockSubsidy)+template.Transactions[0].Fee, template.CoinbaseValue)
+	require.Equal(t, syscoinrpctest.MasternodeAddress, template.Masternode.Payee)
+	require.Equal(t, syscoinrpc.SatoshiAmount(syscoinrpctest.MasternodePayment), template.Masternode.Amount)
+	require.Empty(t, template.Superblock)
+
+	t.Log("GetBlockTemplate :", template)
+}
+
+func TestSubmitBlockOK(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	t.Cleanup(srv.Close)
+	srv.Generate(10)
+	cl, err := srv.Client(testTimeout)
+	require.NoError(t, err)
+
+	_, rawTx := testWitnessTx()
+	txID := srv.AddRawTransaction(rawTx)
+	template, err := cl.Mining.GetBlockTemplate(&syscoinrpc.BlockTemplateRequest{
+		Capabilities: []string{"coinbasevalue", "longpoll"},
+		Rules:        []string{"segwit"},
+	})
+	require.NoError(t, err)
+
+	rawBlock := mineTemplate(t, template)
+	err = cl.Mining.SubmitBlock(rawBlock)
+	require.NoError(t, err, "SubmitBlock : must not error")
+
+	block, err := syscoinrpc.DecodeBlockHex(rawBlock, syscoinrpc.RegTest)
+	require.NoError(t, err)
+	require.Equal(t, uint64(11), srv.Height())
+	require.Equal(t, block.Hash, srv.BlockHash(11), "Must connect the block")
+	mempool, err := cl.Blockchain.GetRawMempool()
+	require.NoError(t, err)
+	require.NotContains(t, mempool, txID, "Must remove the mined transactions from the mempool")
+}
+
+func TestCreateAuxBlockOK(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	t.Cleanup(srv.Close)
+	srv.Generate(10)
+	cl, err := srv.Client(testTimeout)
+	require.NoError(t, err)
+
+	auxBlock, err := cl.Mining.CreateAuxBlock(syscoinrpctest.DefaultAddress)
+	require.NoError(t, err, "CreateAuxBlock : must not error")
+	require.Equal(t, uint64(11), auxBlock.Height)
+	require.Equal(t, srv.BlockHash(10), auxBlock.PreviousBlockHash)
+	require.Equal(t, syscoinrpctest.AuxPowChainID, auxBlock.ChainID)
+	require.Equal(t, syscoinrpc.SatoshiAmount(syscoinrpctest.BlockSubsidy), auxBlock.CoinbaseValue)
+	require.NotEmpty(t, auxBlock.Target)
+
+	t.Log("CreateAuxBlock :", auxBlock)
+}
+
+func TestGetAuxBlockOK(t *testing.T) {
+	cl := newFakeClient(t)
+
+	auxBlock, err := cl.Mining.GetAuxBlock()
+	require.NoError(t, err, "GetAuxBlock : must not error")
+	require.Equal(t, uint64(11), auxBlock.Height)
+	require.Equal(t, syscoinrpctest.AuxPowChainID, auxBlock.ChainID)
+
+	t.Log("GetAuxBlock :", auxBlock)
+}
+
+func TestSubmitAuxBlockOK(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	t.Cleanup(srv.Close)
+	srv.Generate(10)
+	cl, err := srv.Client(testTimeout)
+	require.NoError(t, err)
+
+	auxBlock, err := cl.Mining.CreateAuxBlock(syscoinrpctest.DefaultAddress)
+	require.NoError(t, err)
+	auxPow := mineAuxBlock(t, auxBlock)
+
+	accepted, err := cl.Mining.SubmitAuxBlock(auxBlock.Hash, auxPow)
+	require.NoError(t, err, "SubmitAuxBlock : must not error")
+	require.True(t, accepted, "Must accept the block")
+	require.Equal(t, uint64(11), srv.Height())
+	require.Equal(t, auxBlock.Hash, srv.BlockHash(11), "Must connect the block")
+	accepted, err = cl.Mining.SubmitAuxBlock(auxBlock.Hash, auxPow)
+	require.NoError(t, err)
+	require.True(t, accepted, "Must accept known blocks")
+
+	block, err := cl.Blockchain.GetFullBlock(auxBlock.Hash)
+	require.NoError(t, err)
+	require.Equal(t, auxPow.ParentBlock, block.AuxPow.ParentBlock, "Must store the AuxPoW with the block")
+	require.NoError(t, block.AuxPow.Verify(auxBlock.Hash, auxBlock.ChainID))
+}
+
+func TestPrioritiseTransactionOK(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	t.Cleanup(srv.Close)
+	cl, err := srv.Client(testTimeout)
+	require.NoError(t, err)
+
+	_, rawTx := testWitnessTx()
+	txID := srv.AddRawTransaction(rawTx)
+
+	prioritised, err := cl.Mining.PrioritiseTransaction(txID, 5000*syscoinrpc.Satoshi)
+	require.NoError(t, err, "PrioritiseTransaction : must not error")
+	require.True(t, prioritised)
+
+	entries, err := cl.Blockchain.GetRawMempoolFull()
+	require.NoError(t, err)
+	require.Equal(t, entries[txID].Fee+5000*syscoinrpc.Satoshi, entries[txID].ModifiedFee, "Must add the fee delta")
+}
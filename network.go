@@ -0,0 +1,468 @@
+package syscoinrpc
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+)
+
+// NetworkClient wraps all `network` related functions.
+type NetworkClient struct {
+	c *Client // The binded client, must not be nil.
+}
+
+func (nc *NetworkClient) do(ctx context.Context, method string, params ...interface{}) (json.RawMessage, error) {
+	return nc.c.do(ctx, method, params...)
+}
+
+// AddNodeCommand is the command of an `addnode` call.
+type AddNodeCommand string
+
+const (
+	// AddNodeAdd adds the node to the added nodes list, the node keeps trying to connect to it.
+	AddNodeAdd AddNodeCommand = "add"
+	// AddNodeRemove removes the node from the added nodes list.
+	AddNodeRemove AddNodeCommand = "remove"
+	// AddNodeOneTry tries a connection to the node once, without adding it to the list.
+	AddNodeOneTry AddNodeCommand = "onetry"
+)
+
+// AddNode attempts to add or remove a node from the added nodes list,
+// or to try a connection to a node once.
+//
+//     node    : The node address, as host:port (e.g. 192.168.0.6:8369).
+//     command : The command, see AddNodeCommand.
+func (nc *NetworkClient) AddNode(node string, command AddNodeCommand) error {
+	return nc.AddNodeContext(context.Background(), node, command)
+}
+
+// AddNodeContext is like AddNode but uses the given context for the call.
+func (nc *NetworkClient) AddNodeContext(ctx context.Context, node string, command AddNodeCommand) error {
+	_, err := nc.do(ctx, "addnode", node, command)
+	return err
+}
+
+// ClearBanned clears all banned IPs.
+func (nc *NetworkClient) ClearBanned() error {
+	return nc.ClearBannedContext(context.Background())
+}
+
+// ClearBannedContext is like ClearBanned but uses the given context for the call.
+func (nc *NetworkClient) ClearBannedContext(ctx context.Context) error {
+	_, err := nc.do(ctx, "clearbanned")
+	return err
+}
+
+// DisconnectNode immediately disconnects from the peer with the given address.
+//
+//     address : The address of the peer, see PeerInfo.Address.
+func (nc *NetworkClient) DisconnectNode(address string) error {
+	return nc.DisconnectNodeContext(context.Background(), address)
+}
+
+// DisconnectNodeContext is like DisconnectNode but uses the given context for the call.
+func (nc *NetworkClient) DisconnectNodeContext(ctx context.Context, address string) error {
+	_, err := nc.do(ctx, "disconnectnode", address)
+	return err
+}
+
+// DisconnectNodeByID immediately disconnects from the peer with the given node ID.
+//
+//     nodeID : The node ID of the peer, see PeerInfo.ID.
+func (nc *NetworkClient) DisconnectNodeByID(nodeID uint64) error {
+	return nc.DisconnectNodeByIDContext(context.Background(), nodeID)
+}
+
+// DisconnectNodeByIDContext is like DisconnectNodeByID but uses the given context for the call.
+func (nc *NetworkClient) DisconnectNodeByIDContext(ctx context.Context, nodeID uint64) error {
+	_, err := nc.do(ctx, "disconnectnode", "", nodeID)
+	return err
+}
+
+// AddedNodeInfo represents the information about a node added with AddNode.
+type AddedNodeInfo struct {
+	// AddedNode is the node address, as given to AddNode.
+	AddedNode string `json:"addednode,required"`
+	// Connected is true if the node is connected.
+	Connected bool `json:"connected,required"`
+	// Addresses are the addresses the node is connected with, only when connected.
+	Addresses []AddedNodeAddress `json:"addresses,required"`
+}
+
+// AddedNodeAddress represents an address an added node is connected with.
+type AddedNodeAddress struct {
+	// Address is the address of the connection, as host:port.
+	Address string `json:"address,required"`
+	// Connected is the direction of the connection, "inbound" or "outbound".
+	Connected string `json:"connected,required"`
+}
+
+// GetAddedNodeInfo returns information about the given added node,
+// or all added nodes when node is empty.
+//
+// Nodes added with AddNodeOneTry are not listed.
+//
+//     node : The node address, as given to AddNode, empty for all added nodes.
+func (nc *NetworkClient) GetAddedNodeInfo(node string) ([]*AddedNodeInfo, error) {
+	return nc.GetAddedNodeInfoContext(context.Background(), node)
+}
+
+// GetAddedNodeInfoContext is like GetAddedNodeInfo but uses the given context for the call.
+func (nc *NetworkClient) GetAddedNodeInfoContext(ctx context.Context, node string) ([]*AddedNodeInfo, error) {
+	params := make([]interface{}, 0, 1)
+	if node != "" {
+		params = append(params, node)
+	}
+
+	response, err := nc.do(ctx, "getaddednodeinfo", params...)
+	if err != nil {
+		return nil, err
+	}
+
+	var infos []*AddedNodeInfo
+	err = json.Unmarshal(response, &infos)
+	if err != nil {
+		return nil, err
+	}
+
+	return infos, nil
+}
+
+// GetConnectionCount returns the number of connections to other nodes.
+func (nc *NetworkClient) GetConnectionCount() (uint64, error) {
+	return nc.GetConnectionCountContext(context.Background())
+}
+
+// GetConnectionCountContext is like GetConnectionCount but uses the given context for the call.
+func (nc *NetworkClient) GetConnectionCountContext(ctx context.Context) (uint64, error) {
+	response, err := nc.do(ctx, "getconnectioncount")
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := strconv.ParseUint(string(response), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// NetTotals represents information about the network traffic.
+type NetTotals struct {
+	// TotalBytesRecv is the total number of bytes received.
+	TotalBytesRecv uint64 `json:"totalbytesrecv,required"`
+	// TotalBytesSent is the total number of bytes sent.
+	TotalBytesSent uint64 `json:"totalbytessent,required"`
+	// TimeMillis is the current time in milliseconds since epoch (Jan 1 1970 GMT).
+	TimeMillis int64 `json:"timemillis,required"`
+	// UploadTarget is the status of the upload target (`-maxuploadtarget`).
+	UploadTarget UploadTarget `json:"uploadtarget,required"`
+}
+
+// UploadTarget represents the status of the upload target of the node.
+type UploadTarget struct {
+	// TimeFrame is the length of the measuring timeframe in seconds.
+	TimeFrame uint64 `json:"timeframe,required"`
+	// Target is the target in bytes, 0 for none.
+	Target uint64 `json:"target,required"`
+	// TargetReached is true if the target is reached.
+	TargetReached bool `json:"target_reached,required"`
+	// ServeHistoricalBlocks is true if serving historical blocks.
+	ServeHistoricalBlocks bool `json:"serve_historical_blocks,required"`
+	// BytesLeftInCycle is the number of bytes left in the current time cycle.
+	BytesLeftInCycle uint64 `json:"bytes_left_in_cycle,required"`
+	// TimeLeftInCycle is the number of seconds left in the current time cycle.
+	TimeLeftInCycle uint64 `json:"time_left_in_cycle,required"`
+}
+
+// GetNetTotals returns information about the network traffic, including
+// bytes in, bytes out and the current time.
+func (nc *NetworkClient) GetNetTotals() (*NetTotals, error) {
+	return nc.GetNetTotalsContext(context.Background())
+}
+
+// GetNetTotalsContext is like GetNetTotals but uses the given context for the call.
+func (nc *NetworkClient) GetNetTotalsContext(ctx context.Context) (*NetTotals, error) {
+	response, err := nc.do(ctx, "getnettotals")
+	if err != nil {
+		return nil, err
+	}
+
+	var totals NetTotals
+	err = json.Unmarshal(response, &totals)
+	if err != nil {
+		return nil, err
+	}
+
+	return &totals, nil
+}
+
+// NetworkInfo represents various state info regarding P2P networking.
+type NetworkInfo struct {
+	// Version is the server version.
+	Version uint64 `json:"version,required"`
+	// SubVersion is the server subversion string.
+	SubVersion string `json:"subversion,required"`
+	// ProtocolVersion is the protocol version.
+	ProtocolVersion uint64 `json:"protocolversion,required"`
+	// LocalServices are the services offered to the network, in hex.
+	LocalServices string `json:"localservices,required"`
+	// LocalRelay is true if transaction relay is requested from peers.
+	LocalRelay bool `json:"localrelay,required"`
+	// TimeOffset is the time offset in seconds.
+	TimeOffset int64 `json:"timeoffset,required"`
+	// NetworkActive is true if P2P networking is enabled.
+	NetworkActive bool `json:"networkactive,required"`
+	// Connections is the number of connections.
+	Connections uint64 `json:"connections,required"`
+	// Networks is the information per network.
+	Networks []NetworkReachability `json:"networks,required"`
+	// RelayFee is the minimum relay fee per kB for transactions.
+	RelayFee Amount `json:"relayfee,required"`
+	// IncrementalFee is the minimum fee rate increment per kB for mempool
+	// limiting or BIP 125 replacement.
+	IncrementalFee Amount `json:"incrementalfee,required"`
+	// LocalAddresses is the list of local addresses.
+	LocalAddresses []LocalAddress `json:"localaddresses,required"`
+	// Warnings are any network and blockchain warnings.
+	Warnings string `json:"warnings,required"`
+}
+
+// NetworkReachability represents the reachability of a network (ipv4, ipv6 or onion).
+type NetworkReachability struct {
+	// Name is the name of the network.
+	Name string `json:"name,required"`
+	// Limited is true if only connecting to this network.
+	Limited bool `json:"limited,required"`
+	// Reachable is true if the network is reachable.
+	Reachable bool `json:"reachable,required"`
+	// Proxy is the proxy used for this network, as host:port, empty for none.
+	Proxy string `json:"proxy,required"`
+	// ProxyRandomizeCredentials is true if randomized credentials are used for the proxy.
+	ProxyRandomizeCredentials bool `json:"proxy_randomize_credentials,required"`
+}
+
+// LocalAddress represents a local address of the node.
+type LocalAddress struct {
+	// Address is the network address.
+	Address string `json:"address,required"`
+	// Port is the network port.
+	Port uint16 `json:"port,required"`
+	// Score is the relative score.
+	Score int64 `json:"score,required"`
+}
+
+// GetNetworkInfo returns various state info regarding P2P networking.
+func (nc *NetworkClient) GetNetworkInfo() (*NetworkInfo, error) {
+	return nc.GetNetworkInfoContext(context.Background())
+}
+
+// GetNetworkInfoContext is like GetNetworkInfo but uses the given context for the call.
+func (nc *NetworkClient) GetNetworkInfoContext(ctx context.Context) (*NetworkInfo, error) {
+	response, err := nc.do(ctx, "getnetworkinfo")
+	if err != nil {
+		return nil, err
+	}
+
+	var info NetworkInfo
+	err = json.Unmarshal(response, &info)
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// PeerInfo represents the information about a connected peer.
+type PeerInfo struct {
+	// ID is the node ID of the peer.
+	ID uint64 `json:"id,required"`
+	// Address is the address of the peer, as host:port.
+	Address string `json:"addr,required"`
+	// AddressBind is the local address of the connection, as host:port.
+	AddressBind string `json:"addrbind"`
+	// AddressLocal is the local address as reported by the peer.
+	AddressLocal string `json:"addrlocal"`
+	// Services are the services offered by the peer, in hex.
+	Services string `json:"services,required"`
+	// RelayTxes is true if the peer has asked us to relay transactions to it.
+	RelayTxes bool `json:"relaytxes,required"`
+	// LastSend is the time of the last send, in seconds since epoch (Jan 1 1970 GMT).
+	LastSend int64 `json:"lastsend,required"`
+	// LastRecv is the time of the last receive, in seconds since epoch (Jan 1 1970 GMT).
+	LastRecv int64 `json:"lastrecv,required"`
+	// BytesSent is the total number of bytes sent.
+	BytesSent uint64 `json:"bytessent,required"`
+	// BytesRecv is the total number of bytes received.
+	BytesRecv uint64 `json:"bytesrecv,required"`
+	// ConnTime is the connection time, in seconds since epoch (Jan 1 1970 GMT).
+	ConnTime int64 `json:"conntime,required"`
+	// TimeOffset is the time offset of the peer, in seconds.
+	TimeOffset int64 `json:"timeoffset,required"`
+	// PingTime is the ping time in seconds, if any.
+	PingTime float64 `json:"pingtime"`
+	// MinPing is the minimum observed ping time in seconds, if any.
+	MinPing float64 `json:"minping"`
+	// PingWait is the ping wait in seconds, if a ping is outstanding.
+	PingWait float64 `json:"pingwait"`
+	// Version is the protocol version of the peer.
+	Version uint64 `json:"version,required"`
+	// SubVersion is the string version of the peer (e.g. /Satoshi:0.15.1/).
+	SubVersion string `json:"subver,required"`
+	// Inbound is true if the peer connected to us (inbound), false for outbound.
+	Inbound bool `json:"inbound,required"`
+	// AddNode is true if the peer was added with AddNode.
+	AddNode bool `json:"addnode,required"`
+	// StartingHeight is the height of the peer when the connection started.
+	StartingHeight int64 `json:"startingheight,required"`
+	// BanScore is the ban score of the peer.
+	BanScore int64 `json:"banscore,required"`
+	// SyncedHeaders is the last header we have in common with the peer, -1 if none.
+	SyncedHeaders int64 `json:"synced_headers,required"`
+	// SyncedBlocks is the last block we have in common with the peer, -1 if none.
+	SyncedBlocks int64 `json:"synced_blocks,required"`
+	// InFlight are the heights of the blocks we are currently requesting from the peer.
+	InFlight []uint64 `json:"inflight,required"`
+	// Whitelisted is true if the peer is whitelisted.
+	Whitelisted bool `json:"whitelisted,required"`
+	// BytesSentPerMsg is the total number of bytes sent, by message type.
+	BytesSentPerMsg map[string]uint64 `json:"bytessent_per_msg,required"`
+	// BytesRecvPerMsg is the total number of bytes received, by message type.
+	BytesRecvPerMsg map[string]uint64 `json:"bytesrecv_per_msg,required"`
+}
+
+// GetPeerInfo returns data about each connected peer.
+func (nc *NetworkClient) GetPeerInfo() ([]*PeerInfo, error) {
+	return nc.GetPeerInfoContext(context.Background())
+}
+
+// GetPeerInfoContext is like GetPeerInfo but uses the given context for the call.
+func (nc *NetworkClient) GetPeerInfoContext(ctx context.Context) ([]*PeerInfo, error) {
+	response, err := nc.do(ctx, "getpeerinfo")
+	if err != nil {
+		return nil, err
+	}
+
+	var peers []*PeerInfo
+	err = json.Unmarshal(response, &peers)
+	if err != nil {
+		return nil, err
+	}
+
+	return peers, nil
+}
+
+// BanReason is the reason of a ban, as reported by `listbanned`.
+type BanReason string
+
+const (
+	// BanReasonManuallyAdded means that the subnet was banned with SetBan.
+	BanReasonManuallyAdded BanReason = "manually added"
+	// BanReasonNodeMisbehaving means that a peer of the subnet exceeded the ban score.
+	BanReasonNodeMisbehaving BanReason = "node misbehaving"
+	// BanReasonUnknown means that the reason of the ban is unknown.
+	BanReasonUnknown BanReason = "unknown"
+)
+
+// BannedSubnet represents a banned IP or subnet.
+type BannedSubnet struct {
+	// Address is the banned IP or subnet, with its netmask (e.g. 192.168.0.6/32).
+	Address string `json:"address,required"`
+	// BannedUntil is the end time of the ban, in seconds since epoch (Jan 1 1970 GMT).
+	BannedUntil int64 `json:"banned_until,required"`
+	// BanCreated is the start time of the ban, in seconds since epoch (Jan 1 1970 GMT).
+	BanCreated int64 `json:"ban_created,required"`
+	// BanReason is the reason of the ban.
+	BanReason BanReason `json:"ban_reason,required"`
+}
+
+// ListBanned returns all banned IPs and subnets.
+func (nc *NetworkClient) ListBanned() ([]*BannedSubnet, error) {
+	return nc.ListBannedContext(context.Background())
+}
+
+// ListBannedContext is like ListBanned but uses the given context for the call.
+func (nc *NetworkClient) ListBannedContext(ctx context.Context) ([]*BannedSubnet, error) {
+	response, err := nc.do(ctx, "listbanned")
+	if err != nil {
+		return nil, err
+	}
+
+	var banned []*BannedSubnet
+	err = json.Unmarshal(response, &banned)
+	if err != nil {
+		return nil, err
+	}
+
+	return banned, nil
+}
+
+// Ping requests that a ping be sent to all other nodes, to measure ping time.
+//
+// The results are reported in PeerInfo.PingTime and PeerInfo.PingWait by GetPeerInfo.
+func (nc *NetworkClient) Ping() error {
+	return nc.PingContext(context.Background())
+}
+
+// PingContext is like Ping but uses the given context for the call.
+func (nc *NetworkClient) PingContext(ctx context.Context) error {
+	_, err := nc.do(ctx, "ping")
+	return err
+}
+
+// SetBanCommand is the command of a `setban` call.
+type SetBanCommand string
+
+const (
+	// SetBanAdd adds the IP or subnet to the banned list.
+	SetBanAdd SetBanCommand = "add"
+	// SetBanRemove removes the IP or subnet from the banned list.
+	SetBanRemove SetBanCommand = "remove"
+)
+
+// SetBan adds or removes an IP or subnet from the banned list.
+//
+//     subnet   : The IP or subnet, with an optional netmask (e.g. 192.168.0.6 or 192.168.0.0/24).
+//     command  : The command, see SetBanCommand.
+//     banTime  : The ban duration in seconds, or the end time of the ban when absolute,
+//                0 for the default of the node (24h, `-bantime`). Ignored by SetBanRemove.
+//     absolute : True if banTime is an absolute time in seconds since epoch (Jan 1 1970 GMT).
+func (nc *NetworkClient) SetBan(subnet string, command SetBanCommand, banTime int64, absolute bool) error {
+	return nc.SetBanContext(context.Background(), subnet, command, banTime, absolute)
+}
+
+// SetBanContext is like SetBan but uses the given context for the call.
+func (nc *NetworkClient) SetBanContext(ctx context.Context, subnet string, command SetBanCommand, banTime int64, absolute bool) error {
+	params := []interface{}{subnet, command}
+	if command == SetBanAdd {
+		params = append(params, banTime, absolute)
+	}
+
+	_, err := nc.do(ctx, "setban", params...)
+	return err
+}
+
+// SetNetworkActive disables or enables all P2P network activity.
+// It returns the new state of the network activity.
+//
+//     active : True to enable the network activity, false to disable it.
+func (nc *NetworkClient) SetNetworkActive(active bool) (bool, error) {
+	return nc.SetNetworkActiveContext(context.Background(), active)
+}
+
+// SetNetworkActiveContext is like SetNetworkActive but uses the given context for the call.
+func (nc *NetworkClient) SetNetworkActiveContext(ctx context.Context, active bool) (bool, error) {
+	response, err := nc.do(ctx, "setnetworkactive", active)
+	if err != nil {
+		return false, err
+	}
+
+	var networkActive bool
+	err = json.Unmarshal(response, &networkActive)
+	if err != nil {
+		return false, err
+	}
+
+	return networkActive, nil
+}
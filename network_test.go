@@ -0,0 +1,375 @@
+package syscoinrpc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+	"github.com/thebotguys/golang-syscoin-rpc-client/syscoinrpctest"
+)
+
+func TestAddNodeInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	err = cl.Network.AddNode("192.168.0.6:8369", syscoinrpc.AddNodeAdd)
+	require.Error(t, err, "Must error on any method with invalid URL")
+
+	cl = newFakeClient(t)
+	err = cl.Network.AddNode("192.168.0.6:8369", syscoinrpc.AddNodeRemove)
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCClientNodeNotAdded), "Must error on nodes not added, got %v", err)
+	require.NoError(t, cl.Network.AddNode("192.168.0.6:8369", syscoinrpc.AddNodeAdd))
+	err = cl.Network.AddNode("192.168.0.6:8369", syscoinrpc.AddNodeAdd)
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCClientNodeAlreadyAdded), "Must error on nodes already added, got %v", err)
+}
+
+func TestClearBannedInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	err = cl.Network.ClearBanned()
+	require.Error(t, err, "Must error on any method with invalid URL")
+}
+
+func TestDisconnectNodeInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	err = cl.Network.DisconnectNode("192.168.0.6:8369")
+	require.Error(t, err, "Must error on any method with invalid URL")
+	err = cl.Network.DisconnectNodeByID(0)
+	require.Error(t, err, "Must error on any method with invalid URL")
+
+	cl = newFakeClient(t)
+	err = cl.Network.DisconnectNode("192.168.0.6:8369")
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCClientNodeNotConnected), "Must error on peers not connected, got %v", err)
+	err = cl.Network.DisconnectNodeByID(42)
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCClientNodeNotConnected), "Must error on peers not connected, got %v", err)
+}
+
+func TestGetAddedNodeInfoInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.Network.GetAddedNodeInfo("")
+	require.Error(t, err, "Must error on any method with invalid URL")
+
+	cl = newFakeClient(t)
+	_, err = cl.Network.GetAddedNodeInfo("192.168.0.6:8369")
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCClientNodeNotAdded), "Must error on nodes not added, got %v", err)
+}
+
+func TestGetConnectionCountInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.Network.GetConnectionCount()
+	require.Error(t, err, "Must error on any method with invalid URL")
+}
+
+func TestGetNetTotalsInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.Network.GetNetTotals()
+	require.Error(t, err, "Must error on any method with invalid URL")
+}
+
+func TestGetNetworkInfoInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.Network.GetNetworkInfo()
+	require.Error(t, err, "Must error on any method with invalid URL")
+}
+
+func TestGetPeerInfoInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.Network.GetPeerInfo()
+	require.Error(t, err, "Must error on any method with invalid URL")
+}
+
+func TestListBannedInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.Network.ListBanned()
+	require.Error(t, err, "Must error on any method with invalid URL")
+}
+
+func TestPingInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	err = cl.Network.Ping()
+	require.Error(t, err, "Must error on any method with invalid URL")
+}
+
+func TestSetBanInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	err = cl.Network.SetBan("192.168.0.6", syscoinrpc.SetBanAdd, 0, false)
+	require.Error(t, err, "Must error on any method with invalid URL")
+
+	cl = newFakeClient(t)
+	err = cl.Network.SetBan("192.168.0", syscoinrpc.SetBanAdd, 0, false)
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCClientInvalidIPOrSubnet), "Must error on invalid subnets, got %v", err)
+	err = cl.Network.SetBan("192.168.0.6", syscoinrpc.SetBanRemove, 0, false)
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCClientInvalidIPOrSubnet), "Must error on subnets not banned, got %v", err)
+	require.NoError(t, cl.Network.SetBan("192.168.0.6", syscoinrpc.SetBanAdd, 0, false))
+	err = cl.Network.SetBan("192.168.0.6/32", syscoinrpc.SetBanAdd, 0, false)
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCClientNodeAlreadyAdded), "Must error on subnets already banned, got %v", err)
+}
+
+func TestSetNetworkActiveInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.Network.SetNetworkActive(false)
+	require.Error(t, err, "Must error on any method with invalid URL")
+}
+
+func TestAddNodeOK(t *testing.T) {
+	cl := newFakeClient(t)
+
+	err := cl.Network.AddNode("192.168.0.6:8369", syscoinrpc.AddNodeAdd)
+	require.NoError(t, err, "AddNode : must not error")
+	err = cl.Network.AddNode("192.168.0.7:8369", syscoinrpc.AddNodeOneTry)
+	require.NoError(t, err, "AddNode : must not error")
+
+	peers, err := cl.Network.GetPeerInfo()
+	require.NoError(t, err)
+	require.Len(t, peers, 2, "Must connect to the nodes")
+	require.True(t, peers[0].AddNode)
+	require.False(t, peers[1].AddNode, "Nodes tried once are not added")
+
+	err = cl.Network.AddNode("192.168.0.6:8369", syscoinrpc.AddNodeRemove)
+	require.NoError(t, err, "AddNode : must not error")
+	infos, err := cl.Network.GetAddedNodeInfo("")
+	require.NoError(t, err)
+	require.Empty(t, infos, "Must remove the node from the added nodes")
+}
+
+func TestClearBannedOK(t *testing.T) {
+	cl := newFakeClient(t)
+
+	require.NoError(t, cl.Network.SetBan("192.168.0.6", syscoinrpc.SetBanAdd, 0, false))
+	err := cl.Network.ClearBanned()
+	require.NoError(t, err, "ClearBanned : must not error")
+
+	banned, err := cl.Network.ListBanned()
+	require.NoError(t, err)
+	require.Empty(t, banned, "Must clear the banned subnets")
+}
+
+func TestDisconnectNodeOK(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	t.Cleanup(srv.Close)
+	cl, err := srv.Client(testTimeout)
+	require.NoError(t, err)
+
+	_, ok := srv.ConnectPeer("192.168.0.6:8369")
+	require.True(t, ok)
+	nodeID, ok := srv.ConnectPeer("192.168.0.7:8369")
+	require.True(t, ok)
+
+	err = cl.Network.DisconnectNode("192.168.0.6:8369")
+	require.NoError(t, err, "DisconnectNode : must not error")
+	err = cl.Network.DisconnectNodeByID(nodeID)
+	require.NoError(t, err, "DisconnectNodeByID : must not error")
+
+	count, err := cl.Network.GetConnectionCount()
+	require.NoError(t, err)
+	require.Zero(t, count, "Must disconnect the peers")
+}
+
+func TestGetAddedNodeInfoOK(t *testing.T) {
+	cl := newFakeClient(t)
+
+	require.NoError(t, cl.Network.AddNode("192.168.0.6:8369", syscoinrpc.AddNodeAdd))
+	require.NoError(t, cl.Network.AddNode("192.168.0.7:8369", syscoinrpc.AddNodeAdd))
+	require.NoError(t, cl.Network.DisconnectNode("192.168.0.7:8369"))
+
+	infos, err := cl.Network.GetAddedNodeInfo("")
+	require.NoError(t, err, "GetAddedNodeInfo : must not error")
+	require.Len(t, infos, 2)
+	require.Equal(t, &syscoinrpc.AddedNodeInfo{
+		AddedNode: "192.168.0.6:8369",
+		Connected: true,
+		Addresses: []syscoinrpc.AddedNodeAddress{{Address: "192.168.0.6:8369", Connected: "outbound"}},
+	}, infos[0])
+	require.False(t, infos[1].Connected)
+	require.Empty(t, infos[1].Addresses)
+
+	infos, err = cl.Network.GetAddedNodeInfo("192.168.0.7:8369")
+	require.NoError(t, err, "GetAddedNodeInfo : must not error")
+	require.Len(t, infos, 1)
+	require.Equal(t, "192.168.0.7:8369", infos[0].AddedNode)
+
+	t.Log("GetAddedNodeInfo :", infos)
+}
+
+func TestGetConnectionCountOK(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	t.Cleanup(srv.Close)
+	cl, err := srv.Client(testTimeout)
+	require.NoError(t, err)
+
+	count, err := cl.Network.GetConnectionCount()
+	require.NoError(t, err, "GetConnectionCount : must not error")
+	require.Zero(t, count)
+
+	srv.ConnectPeer("192.168.0.6:8369")
+	count, err = cl.Network.GetConnectionCount()
+	require.NoError(t, err, "GetConnectionCount : must not error")
+	require.Equal(t, uint64(1), count)
+}
+
+func TestGetNetTotalsOK(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	t.Cleanup(srv.Close)
+	cl, err := srv.Client(testTimeout)
+	require.NoError(t, err)
+
+	srv.ConnectPeer("192.168.0.6:8369")
+	totals, err := cl.Network.GetNetTotals()
+	require.NoError(t, err, "GetNetTotals : must not error")
+	require.True(t, totals.TotalBytesSent > 0)
+	require.True(t, totals.TotalBytesRecv > 0)
+	require.InDelta(t, time.Now().UnixNano()/int64(time.Millisecond), totals.TimeMillis, 60000)
+	require.Equal(t, uint64(24*60*60), totals.UploadTarget.TimeFrame)
+
+	t.Log("GetNetTotals :", totals)
+}
+
+func TestGetNetworkInfoOK(t *testing.T) {
+	cl := newFakeClient(t)
+
+	info, err := cl.Network.GetNetworkInfo()
+	require.NoError(t, err, "GetNetworkInfo : must not error")
+	require.True(t, info.NetworkActive)
+	require.Zero(t, info.Connections)
+	require.Len(t, info.Networks, 3)
+	require.Equal(t, "ipv4", info.Networks[0].Name)
+	require.Equal(t, 1000*syscoinrpc.Satoshi, info.RelayFee)
+	require.NotEmpty(t, info.LocalAddresses)
+
+	t.Log("GetNetworkInfo :", info)
+}
+
+func TestGetPeerInfoOK(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	t.Cleanup(srv.Close)
+	srv.Generate(10)
+	cl, err := srv.Client(testTimeout)
+	require.NoError(t, err)
+
+	nodeID, ok := srv.ConnectPeer("192.168.0.6:8369")
+	require.True(t, ok)
+
+	peers, err := cl.Network.GetPeerInfo()
+	require.NoError(t, err, "GetPeerInfo : must not error")
+	require.Len(t, peers, 1)
+	peer := peers[0]
+	require.Equal(t, nodeID, peer.ID)
+	require.Equal(t, "192.168.0.6:8369", peer.Address)
+	require.True(t, peer.Inbound)
+	require.True(t, peer.RelayTxes)
+	require.Equal(t, int64(10), peer.StartingHeight)
+	require.Equal(t, int64(10), peer.SyncedHeaders)
+	require.Equal(t, int64(10), peer.SyncedBlocks)
+	require.Equal(t, peer.BytesSent, peer.BytesSentPerMsg["version"])
+	require.Zero(t, peer.PingTime, "Must have no ping time before a ping")
+
+	t.Log("GetPeerInfo :", peer)
+}
+
+func TestListBannedOK(t *testing.T) {
+	cl := newFakeClient(t)
+
+	banned, err := cl.Network.ListBanned()
+	require.NoError(t, err, "ListBanned : must not error")
+	require.Empty(t, banned)
+
+	until := time.Now().Add(time.Hour).Unix()
+	require.NoError(t, cl.Network.SetBan("192.168.0.0/24", syscoinrpc.SetBanAdd, until, true))
+	require.NoError(t, cl.Network.SetBan("10.0.0.1", syscoinrpc.SetBanAdd, 0, false))
+
+	banned, err = cl.Network.ListBanned()
+	require.NoError(t, err, "ListBanned : must not error")
+	require.Len(t, banned, 2)
+	require.Equal(t, "10.0.0.1/32", banned[0].Address)
+	require.Equal(t, syscoinrpc.BanReasonManuallyAdded, banned[0].BanReason)
+	require.InDelta(t, banned[0].BanCreated+24*60*60, banned[0].BannedUntil, 1, "Must ban for the default ban time")
+	require.Equal(t, "192.168.0.0/24", banned[1].Address)
+	require.Equal(t, until, banned[1].BannedUntil)
+
+	t.Log("ListBanned :", banned)
+}
+
+func TestPingOK(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	t.Cleanup(srv.Close)
+	cl, err := srv.Client(testTimeout)
+	require.NoError(t, err)
+
+	srv.ConnectPeer("192.168.0.6:8369")
+	err = cl.Network.Ping()
+	require.NoError(t, err, "Ping : must not error")
+
+	peers, err := cl.Network.GetPeerInfo()
+	require.NoError(t, err)
+	require.True(t, peers[0].PingTime > 0, "Must measure the ping time")
+	require.Equal(t, peers[0].PingTime, peers[0].MinPing)
+}
+
+func TestSetBanOK(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	t.Cleanup(srv.Close)
+	cl, err := srv.Client(testTimeout)
+	require.NoError(t, err)
+
+	srv.ConnectPeer("192.168.0.6:8369")
+	srv.ConnectPeer("10.0.0.1:8369")
+
+	err = cl.Network.SetBan("192.168.0.0/24", syscoinrpc.SetBanAdd, 3600, false)
+	require.NoError(t, err, "SetBan : must not error")
+	peers, err := cl.Network.GetPeerInfo()
+	require.NoError(t, err)
+	require.Len(t, peers, 1, "Must disconnect the banned peers")
+	require.Equal(t, "10.0.0.1:8369", peers[0].Address)
+	_, ok := srv.ConnectPeer("192.168.0.7:8369")
+	require.False(t, ok, "Must refuse the banned peers")
+
+	err = cl.Network.SetBan("192.168.0.0/24", syscoinrpc.SetBanRemove, 0, false)
+	require.NoError(t, err, "SetBan : must not error")
+	_, ok = srv.ConnectPeer("192.168.0.7:8369")
+	require.True(t, ok, "Must accept the unbanned peers")
+}
+
+func TestSetNetworkActiveOK(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	t.Cleanup(srv.Close)
+	cl, err := srv.Client(testTimeout)
+	require.NoError(t, err)
+
+	srv.ConnectPeer("192.168.0.6:8369")
+	active, err := cl.Network.SetNetworkActive(false)
+	require.NoError(t, err, "SetNetworkActive : must not error")
+	require.False(t, active)
+
+	info, err := cl.Network.GetNetworkInfo()
+	require.NoError(t, err)
+	require.False(t, info.NetworkActive)
+	require.Zero(t, info.Connections, "Must disconnect all the peers")
+	_, ok := srv.ConnectPeer("192.168.0.6:8369")
+	require.False(t, ok, "Must refuse connections")
+
+	active, err = cl.Network.SetNetworkActive(true)
+	require.NoError(t, err, "SetNetworkActive : must not error")
+	require.True(t, active)
+}
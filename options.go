@@ -0,0 +1,130 @@
+package syscoinrpc
+
+import (
+	"crypto/tls"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ErrInvalidOption is returned by NewClient when an option has an invalid value.
+var ErrInvalidOption = errors.New("Invalid client option")
+
+// ClientOption configures a Client on creation.
+type ClientOption func(*clientOptions) error
+
+// clientOptions collects the settings of all the options passed to NewClient.
+type clientOptions struct {
+	httpClient  *http.Client  // The custom HTTP client, nil to use http.DefaultClient.
+	timeout     time.Duration // The timeout of every HTTP request, 0 to keep the client one.
+	tlsConfig   *tls.Config   // The TLS configuration of HTTPS connections.
+	headers     http.Header   // The additional headers sent on every request.
+	retryPolicy *RetryPolicy  // The retry policy, nil to never retry.
+	recorder    io.Writer     // The cassette the calls are recorded to, nil to not record.
+}
+
+// WithHTTPClient makes the client use the given HTTP client instead
+// of http.DefaultClient.
+//
+// The given client is never modified, other options are applied to a copy of it.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(opts *clientOptions) error {
+		if httpClient == nil {
+			return ErrInvalidOption
+		}
+		opts.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithTimeout sets the timeout of every HTTP request made by the client.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(opts *clientOptions) error {
+		if timeout <= 0 {
+			return ErrInvalidOption
+		}
+		opts.timeout = timeout
+		return nil
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used to connect to HTTPS nodes.
+// It can be combined with WithHTTPClient only if the client transport
+// is nil or an *http.Transport.
+func WithTLSConfig(tlsConfig *tls.Config) ClientOption {
+	return func(opts *clientOptions) error {
+		if tlsConfig == nil {
+			return ErrInvalidOption
+		}
+		opts.tlsConfig = tlsConfig
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent on every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return WithHeader("User-Agent", userAgent)
+}
+
+// WithHeader adds a header sent on every request.
+// It can be used multiple times to add more headers.
+func WithHeader(key string, value string) ClientOption {
+	return func(opts *clientOptions) error {
+		if key == "" {
+			return ErrInvalidOption
+		}
+		if opts.headers == nil {
+			opts.headers = make(http.Header)
+		}
+		opts.headers.Add(key, value)
+		return nil
+	}
+}
+
+// applyOptions collects the settings of the options.
+func applyOptions(options []ClientOption) (*clientOptions, error) {
+	var opts clientOptions
+	for _, option := range options {
+		err := option(&opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The TLS configuration can only be set on an *http.Transport.
+	if opts.tlsConfig != nil && opts.httpClient != nil && opts.httpClient.Transport != nil {
+		if _, ok := opts.httpClient.Transport.(*http.Transport); !ok {
+			return nil, ErrInvalidOption
+		}
+	}
+	return &opts, nil
+}
+
+// buildHTTPClient returns the HTTP client matching the options.
+func (opts *clientOptions) buildHTTPClient() *http.Client {
+	httpClient := opts.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if opts.timeout == 0 && opts.tlsConfig == nil {
+		return httpClient
+	}
+
+	// Work on a copy, to never alter a shared client.
+	custom := *httpClient
+	if opts.timeout > 0 {
+		custom.Timeout = opts.timeout
+	}
+	if opts.tlsConfig != nil {
+		// applyOptions already rejected any other transport type.
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if t, ok := custom.Transport.(*http.Transport); ok {
+			transport = t.Clone()
+		}
+		transport.TLSClientConfig = opts.tlsConfig
+		custom.Transport = transport
+	}
+
+	return &custom
+}
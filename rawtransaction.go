@@ -0,0 +1,532 @@
+package syscoinrpc
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// RawTransactionClient wraps all `rawtransactions` related functions.
+type RawTransactionClient struct {
+	c *Client // The binded client, must not be nil.
+}
+
+func (rtc *RawTransactionClient) do(ctx context.Context, method string, params ...interface{}) (json.RawMessage, error) {
+	return rtc.c.do(ctx, method, params...)
+}
+
+// RawTxInput represents an input of a transaction to create.
+type RawTxInput struct {
+	// TxID is the ID of the transaction of the spent output.
+	TxID Hash `json:"txid"`
+	// Vout is the index of the spent output in its transaction.
+	Vout uint32 `json:"vout"`
+	// Sequence is the sequence number of the input, nil for the default
+	// one (depending on the lock time and the replaceable flag).
+	Sequence *uint32 `json:"sequence,omitempty"`
+}
+
+// RawTxOutput represents an output of a transaction to create, either paying
+// an amount to an address or carrying data, see NewAddressOutput and NewDataOutput.
+type RawTxOutput struct {
+	// Address is the address to pay, empty for data outputs.
+	Address string
+	// Amount is the amount to pay to the address.
+	Amount Amount
+	// Data is the data of the output, nil for address outputs.
+	Data []byte
+}
+
+// NewAddressOutput returns an output paying the amount to the address.
+func NewAddressOutput(address string, amount Amount) RawTxOutput {
+	return RawTxOutput{Address: address, Amount: amount}
+}
+
+// NewDataOutput returns a nulldata (OP_RETURN) output carrying the data.
+func NewDataOutput(data []byte) RawTxOutput {
+	return RawTxOutput{Data: append([]byte{}, data...)}
+}
+
+// MarshalJSON encodes the output like the node expects it, as an object with
+// the address as key and the amount as value, or "data" as key and the data in hex.
+func (rto RawTxOutput) MarshalJSON() ([]byte, error) {
+	if rto.Data != nil {
+		return json.Marshal(map[string]string{"data": hex.EncodeToString(rto.Data)})
+	}
+	return json.Marshal(map[string]Amount{rto.Address: rto.Amount})
+}
+
+// CreateRawTransaction creates a transaction spending the given inputs and
+// creating new outputs. It returns the hex encoded transaction.
+//
+// The inputs are not signed, and the transaction is neither stored in the
+// wallet nor transmitted to the network.
+//
+//     inputs      : The inputs of the transaction.
+//     outputs     : The outputs of the transaction, in order.
+//     lockTime    : The lock time of the transaction, 0 for none.
+//     replaceable : True to signal the transaction as replaceable (BIP 125),
+//                   ignored for inputs with an explicit sequence number.
+func (rtc *RawTransactionClient) CreateRawTransaction(inputs []RawTxInput, outputs []RawTxOutput, lockTime uint32, replaceable bool) (string, error) {
+	return rtc.CreateRawTransactionContext(context.Background(), inputs, outputs, lockTime, replaceable)
+}
+
+// CreateRawTransactionContext is like CreateRawTransaction but uses the given context for the call.
+func (rtc *RawTransactionClient) CreateRawTransactionContext(ctx context.Context, inputs []RawTxInput, outputs []RawTxOutput, lockTime uint32, replaceable bool) (string, error) {
+	if inputs == nil {
+		inputs = []RawTxInput{}
+	}
+	if outputs == nil {
+		outputs = []RawTxOutput{}
+	}
+
+	response, err := rtc.do(ctx, "createrawtransaction", inputs, outputs, lockTime, replaceable)
+	if err != nil {
+		return "", err
+	}
+
+	var rawTxHex string
+	err = json.Unmarshal(response, &rawTxHex)
+	if err != nil {
+		return "", err
+	}
+
+	return rawTxHex, nil
+}
+
+// DecodeRawTransaction returns the decoded transaction, its Hex is not reported by the node.
+//
+// To decode the transaction without calling the node, see DecodeTransactionHex.
+//
+//     rawTxHex : The serialized transaction, in hex.
+func (rtc *RawTransactionClient) DecodeRawTransaction(rawTxHex string) (*Transaction, error) {
+	return rtc.DecodeRawTransactionContext(context.Background(), rawTxHex)
+}
+
+// DecodeRawTransactionContext is like DecodeRawTransaction but uses the given context for the call.
+func (rtc *RawTransactionClient) DecodeRawTransactionContext(ctx context.Context, rawTxHex string) (*Transaction, error) {
+	response, err := rtc.do(ctx, "decoderawtransaction", rawTxHex)
+	if err != nil {
+		return nil, err
+	}
+
+	var tx Transaction
+	err = json.Unmarshal(response, &tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tx, nil
+}
+
+// DecodedScript represents a decoded script, as returned by a `decodescript` call.
+type DecodedScript struct {
+	// Asm is the ASM code of the script.
+	Asm string `json:"asm,required"`
+	// Type is the type of the script, one of the ScriptType* constants.
+	Type string `json:"type,required"`
+	// RequiredSignatures is the number of required signatures.
+	RequiredSignatures uint64 `json:"reqSigs,omitempty"`
+	// Addresses is the array of syscoin addresses involved in the script.
+	Addresses []string `json:"addresses,omitempty"`
+	// P2SH is the address of the script wrapped in P2SH, empty for P2SH scripts.
+	P2SH string `json:"p2sh,omitempty"`
+	// Segwit is the script wrapped in segwit, nil for the scripts which cannot be wrapped.
+	Segwit *DecodedSegwitScript `json:"segwit,omitempty"`
+}
+
+// DecodedSegwitScript represents a script wrapped in segwit: P2WPKH for the
+// public key scripts, P2WSH for the other ones.
+type DecodedSegwitScript struct {
+	ScriptPubKey
+	// P2SHSegwit is the address of the segwit script wrapped in P2SH.
+	P2SHSegwit string `json:"p2sh-segwit,required"`
+}
+
+// DecodeScript returns the decoded script.
+//
+// To decode the script without calling the node, see DecodeScriptHex.
+//
+//     scriptHex : The script, in hex.
+func (rtc *RawTransactionClient) DecodeScript(scriptHex string) (*DecodedScript, error) {
+	return rtc.DecodeScriptContext(context.Background(), scriptHex)
+}
+
+// DecodeScriptContext is like DecodeScript but uses the given context for the call.
+func (rtc *RawTransactionClient) DecodeScriptContext(ctx context.Context, scriptHex string) (*DecodedScript, error) {
+	response, err := rtc.do(ctx, "decodescript", scriptHex)
+	if err != nil {
+		return nil, err
+	}
+
+	var script DecodedScript
+	err = json.Unmarshal(response, &script)
+	if err != nil {
+		return nil, err
+	}
+
+	return &script, nil
+}
+
+// FundRawTransactionOptions are the options of a `fundrawtransaction` call,
+// the zero values select the defaults of the node.
+type FundRawTransactionOptions struct {
+	// ChangeAddress is the address to receive the change, empty for a new wallet address.
+	ChangeAddress string `json:"changeAddress,omitempty"`
+	// ChangePosition is the index of the change output, nil for a random position.
+	ChangePosition *int `json:"changePosition,omitempty"`
+	// ChangeType is the output type of the change (legacy, p2sh-segwit or bech32),
+	// empty for the default of the node (`-changetype`).
+	ChangeType string `json:"change_type,omitempty"`
+	// IncludeWatching is true to also select inputs which are watch only.
+	IncludeWatching bool `json:"includeWatching,omitempty"`
+	// LockUnspents is true to lock the selected outputs.
+	LockUnspents bool `json:"lockUnspents,omitempty"`
+	// FeeRate is the fee rate per kB, zero to estimate it.
+	FeeRate Amount `json:"feeRate,omitempty"`
+	// SubtractFeeFromOutputs are the indexes of the outputs paying the fee,
+	// equally deducted from their amounts, instead of adding inputs.
+	SubtractFeeFromOutputs []uint32 `json:"subtractFeeFromOutputs,omitempty"`
+	// Replaceable signals the transaction as replaceable (BIP 125), nil for
+	// the default of the node (`-walletrbf`).
+	Replaceable *bool `json:"replaceable,omitempty"`
+	// ConfTarget is the confirmation target in blocks of the fee estimation, 0 for the default.
+	ConfTarget uint64 `json:"conf_target,omitempty"`
+	// EstimateMode is the fee estimation mode, empty for the default.
+	EstimateMode EstimateMode `json:"estimate_mode,omitempty"`
+}
+
+// FundedTransaction represents the result of a `fundrawtransaction` call.
+type FundedTransaction struct {
+	// Hex is the funded transaction, in hex.
+	Hex string `json:"hex,required"`
+	// Fee is the fee paid by the transaction.
+	Fee Amount `json:"fee,required"`
+	// ChangePosition is the index of the change output, -1 if none was added.
+	ChangePosition int `json:"changepos,required"`
+}
+
+// FundRawTransaction adds inputs from the wallet to the transaction until it
+// pays its outputs and the fee, and a change output if needed.
+//
+// The existing inputs are kept, and the added ones are not signed, see SignRawTransaction.
+//
+//     rawTxHex : The serialized transaction, in hex.
+//     options  : The options, nil for the defaults.
+func (rtc *RawTransactionClient) FundRawTransaction(rawTxHex string, options *FundRawTransactionOptions) (*FundedTransaction, error) {
+	return rtc.FundRawTransactionContext(context.Background(), rawTxHex, options)
+}
+
+// FundRawTransactionContext is like FundRawTransaction but uses the given context for the call.
+func (rtc *RawTransactionClient) FundRawTransactionContext(ctx context.Context, rawTxHex string, options *FundRawTransactionOptions) (*FundedTransaction, error) {
+	params := []interface{}{rawTxHex}
+	if options != nil {
+		params = append(params, options)
+	}
+
+	response, err := rtc.do(ctx, "fundrawtransaction", params...)
+	if err != nil {
+		return nil, err
+	}
+
+	var funded FundedTransaction
+	err = json.Unmarshal(response, &funded)
+	if err != nil {
+		return nil, err
+	}
+
+	return &funded, nil
+}
+
+// RawTransaction represents a transaction as returned by a verbose `getrawtransaction` call.
+type RawTransaction struct {
+	Transaction
+	// Confirmations is the number of confirmations, 0 for mempool transactions.
+	Confirmations uint64 `json:"confirmations"`
+	// Time is the block time in seconds since epoch (Jan 1 1970 GMT), 0 for mempool transactions.
+	Time uint64 `json:"time"`
+	// BlockTime is the block time in seconds since epoch (Jan 1 1970 GMT), 0 for mempool transactions.
+	BlockTime uint64 `json:"blocktime"`
+}
+
+// GetRawTransaction returns the serialized transaction, in hex.
+//
+// Without `-txindex`, only the mempool transactions and the ones with an
+// unspent output are found.
+//
+//     txID : The transaction ID.
+func (rtc *RawTransactionClient) GetRawTransaction(txID Hash) (string, error) {
+	return rtc.GetRawTransactionContext(context.Background(), txID)
+}
+
+// GetRawTransactionContext is like GetRawTransaction but uses the given context for the call.
+func (rtc *RawTransactionClient) GetRawTransactionContext(ctx context.Context, txID Hash) (string, error) {
+	response, err := rtc.do(ctx, "getrawtransaction", txID, false)
+	if err != nil {
+		return "", err
+	}
+
+	var rawTxHex string
+	err = json.Unmarshal(response, &rawTxHex)
+	if err != nil {
+		return "", err
+	}
+
+	return rawTxHex, nil
+}
+
+// GetFullRawTransaction returns the decoded transaction, with the block it is included in.
+//
+// Without `-txindex`, only the mempool transactions and the ones with an
+// unspent output are found.
+//
+//     txID : The transaction ID.
+func (rtc *RawTransactionClient) GetFullRawTransaction(txID Hash) (*RawTransaction, error) {
+	return rtc.GetFullRawTransactionContext(context.Background(), txID)
+}
+
+// GetFullRawTransactionContext is like GetFullRawTransaction but uses the given context for the call.
+func (rtc *RawTransactionClient) GetFullRawTransactionContext(ctx context.Context, txID Hash) (*RawTransaction, error) {
+	response, err := rtc.do(ctx, "getrawtransaction", txID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var tx RawTransaction
+	err = json.Unmarshal(response, &tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tx, nil
+}
+
+// TxRejectReason is the reason of a transaction rejection, as reported by `sendrawtransaction`.
+type TxRejectReason string
+
+// The most common transaction rejection reasons.
+const (
+	// TxRejectAlreadyInChain means that the transaction is already in the active chain.
+	TxRejectAlreadyInChain TxRejectReason = "transaction already in block chain"
+	// TxRejectMissingInputs means that the spent outputs are unknown or already spent.
+	TxRejectMissingInputs TxRejectReason = "Missing inputs"
+	// TxRejectMinRelayFee means that the fee is below the minimum relay fee (`-minrelaytxfee`).
+	TxRejectMinRelayFee TxRejectReason = "min relay fee not met"
+	// TxRejectMempoolMinFee means that the fee is below the minimum fee of the full mempool.
+	TxRejectMempoolMinFee TxRejectReason = "mempool min fee not met"
+	// TxRejectInsufficientFee means that the fee is too low to replace the conflicting transactions.
+	TxRejectInsufficientFee TxRejectReason = "insufficient fee"
+	// TxRejectAbsurdFee means that the fee is too high, unless allowing high fees.
+	TxRejectAbsurdFee TxRejectReason = "absurdly-high-fee"
+	// TxRejectMempoolConflict means that the transaction conflicts with a non
+	// replaceable mempool transaction.
+	TxRejectMempoolConflict TxRejectReason = "txn-mempool-conflict"
+	// TxRejectNonFinal means that the lock time of the transaction is not reached.
+	TxRejectNonFinal TxRejectReason = "non-final"
+	// TxRejectDust means that an output is too small to be relayed.
+	TxRejectDust TxRejectReason = "dust"
+)
+
+// rejectInsufficientFee is the BIP 61 reject code of the fee related rejections.
+const rejectInsufficientFee = 0x42
+
+// ErrTxRejected is the error wrapped by TxRejectedError.
+var ErrTxRejected = errors.New("Transaction rejected")
+
+// TxRejectedError is returned by SendRawTransaction when the node does not
+// accept the transaction.
+//
+// It matches ErrTxRejected with errors.Is, and unwraps to the *RPCError of the node.
+type TxRejectedError struct {
+	// Reason is the rejection reason, one of the TxReject* constants
+	// or any other reason reported by the node.
+	Reason TxRejectReason
+	// Code is the BIP 61 reject code, 0 if not reported.
+	Code int
+	// Details are the details of the rejection (e.g. the fees), if any.
+	Details string
+
+	rpcErr *RPCError // The error of the node.
+}
+
+func (err *TxRejectedError) Error() string {
+	if err.Details != "" {
+		return fmt.Sprintf("%s: %s, %s", ErrTxRejected, err.Reason, err.Details)
+	}
+	return fmt.Sprintf("%s: %s", ErrTxRejected, err.Reason)
+}
+
+// Is returns true for ErrTxRejected.
+func (err *TxRejectedError) Is(target error) bool {
+	return target == ErrTxRejected
+}
+
+// Unwrap returns the *RPCError of the node.
+func (err *TxRejectedError) Unwrap() error {
+	return err.rpcErr
+}
+
+// InsufficientFee returns true if the transaction was rejected for its fee
+// being too low (e.g. TxRejectMinRelayFee or TxRejectInsufficientFee).
+func (err *TxRejectedError) InsufficientFee() bool {
+	return err.Code == rejectInsufficientFee
+}
+
+// rejectMessage matches the rejection messages of the node, formatted
+// as "reason, details (code N)" or "N: reason".
+var rejectMessage = regexp.MustCompile(`^(?:(.*?)(?:, (.*))? \(code (\d+)\)|(\d+): (.*))$`)
+
+// newTxRejectedError returns the rejection of the node as a *TxRejectedError,
+// or err itself if it is not a rejection.
+func newTxRejectedError(err error) error {
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) {
+		return err
+	}
+
+	rejected := &TxRejectedError{Reason: TxRejectReason(rpcErr.Message), rpcErr: rpcErr}
+	switch rpcErr.Code {
+	case RPCVerifyAlreadyInChain:
+		rejected.Reason = TxRejectAlreadyInChain
+	case RPCVerifyError, RPCVerifyRejected:
+		if m := rejectMessage.FindStringSubmatch(rpcErr.Message); m != nil {
+			if m[3] != "" {
+				rejected.Reason, rejected.Details, rejected.Code = TxRejectReason(m[1]), m[2], atoi(m[3])
+			} else {
+				rejected.Reason, rejected.Code = TxRejectReason(m[5]), atoi(m[4])
+			}
+		}
+	default:
+		return err
+	}
+
+	return rejected
+}
+
+// atoi returns the value of the decimal digits, 0 if it overflows.
+func atoi(digits string) int {
+	n, _ := strconv.Atoi(digits)
+	return n
+}
+
+// SendRawTransaction submits the transaction to the node and the network.
+// It returns the transaction ID.
+//
+// It returns a *TxRejectedError if the node does not accept the transaction.
+//
+//     rawTxHex      : The serialized signed transaction, in hex.
+//     allowHighFees : True to allow absurdly high fees.
+func (rtc *RawTransactionClient) SendRawTransaction(rawTxHex string, allowHighFees bool) (Hash, error) {
+	return rtc.SendRawTransactionContext(context.Background(), rawTxHex, allowHighFees)
+}
+
+// SendRawTransactionContext is like SendRawTransaction but uses the given context for the call.
+func (rtc *RawTransactionClient) SendRawTransactionContext(ctx context.Context, rawTxHex string, allowHighFees bool) (Hash, error) {
+	response, err := rtc.do(ctx, "sendrawtransaction", rawTxHex, allowHighFees)
+	if err != nil {
+		return Hash{}, newTxRejectedError(err)
+	}
+
+	var txID Hash
+	err = json.Unmarshal(response, &txID)
+	if err != nil {
+		return Hash{}, err
+	}
+
+	return txID, nil
+}
+
+// SigHashType is the signature hash type of the signatures, the parts of the
+// transaction they commit to.
+type SigHashType string
+
+const (
+	// SigHashAll signs all the inputs and outputs.
+	SigHashAll SigHashType = "ALL"
+	// SigHashNone signs all the inputs and none of the outputs.
+	SigHashNone SigHashType = "NONE"
+	// SigHashSingle signs all the inputs and the output with the index of the input.
+	SigHashSingle SigHashType = "SINGLE"
+	// SigHashAllAnyoneCanPay signs the input and all the outputs.
+	SigHashAllAnyoneCanPay SigHashType = "ALL|ANYONECANPAY"
+	// SigHashNoneAnyoneCanPay signs the input and none of the outputs.
+	SigHashNoneAnyoneCanPay SigHashType = "NONE|ANYONECANPAY"
+	// SigHashSingleAnyoneCanPay signs the input and the output with its index.
+	SigHashSingleAnyoneCanPay SigHashType = "SINGLE|ANYONECANPAY"
+)
+
+// PrevTx represents an output spent by a transaction to sign, which may not be
+// known by the node yet.
+type PrevTx struct {
+	// TxID is the ID of the transaction of the output.
+	TxID Hash `json:"txid"`
+	// Vout is the index of the output in its transaction.
+	Vout uint32 `json:"vout"`
+	// ScriptPubKey is the output script, in hex.
+	ScriptPubKey string `json:"scriptPubKey"`
+	// RedeemScript is the redeem script of P2SH outputs, in hex.
+	RedeemScript string `json:"redeemScript,omitempty"`
+	// WitnessScript is the witness script of P2WSH outputs, in hex.
+	WitnessScript string `json:"witnessScript,omitempty"`
+	// Amount is the amount of the output, required for segwit outputs.
+	Amount Amount `json:"amount,omitempty"`
+}
+
+// SignedTransaction represents the result of a `signrawtransaction` call.
+type SignedTransaction struct {
+	// Hex is the signed transaction, in hex.
+	Hex string `json:"hex,required"`
+	// Complete is true if the transaction has a complete set of signatures.
+	Complete bool `json:"complete,required"`
+	// Errors are the errors of the inputs which could not be signed, if any.
+	Errors []SignatureError `json:"errors"`
+}
+
+// SignatureError represents an input which could not be signed.
+type SignatureError struct {
+	// TxID is the ID of the transaction of the spent output.
+	TxID Hash `json:"txid,required"`
+	// Vout is the index of the spent output in its transaction.
+	Vout uint32 `json:"vout,required"`
+	// ScriptSig is the signature script of the input, in hex.
+	ScriptSig string `json:"scriptSig,required"`
+	// Sequence is the sequence number of the input.
+	Sequence uint32 `json:"sequence,required"`
+	// Error is the verification or signing error.
+	Error string `json:"error,required"`
+}
+
+// SignRawTransaction signs the inputs of the transaction, with the keys of
+// the wallet or the given private keys.
+//
+//     rawTxHex    : The serialized transaction, in hex.
+//     prevTxs     : The spent outputs not known by the node yet, nil for none.
+//     privateKeys : The private keys to sign with (WIF), nil to sign with the wallet.
+//     sigHashType : The signature hash type, empty for SigHashAll.
+func (rtc *RawTransactionClient) SignRawTransaction(rawTxHex string, prevTxs []PrevTx, privateKeys []string, sigHashType SigHashType) (*SignedTransaction, error) {
+	return rtc.SignRawTransactionContext(context.Background(), rawTxHex, prevTxs, privateKeys, sigHashType)
+}
+
+// SignRawTransactionContext is like SignRawTransaction but uses the given context for the call.
+func (rtc *RawTransactionClient) SignRawTransactionContext(ctx context.Context, rawTxHex string, prevTxs []PrevTx, privateKeys []string, sigHashType SigHashType) (*SignedTransaction, error) {
+	if sigHashType == "" {
+		sigHashType = SigHashAll
+	}
+
+	response, err := rtc.do(ctx, "signrawtransaction", rawTxHex, prevTxs, privateKeys, sigHashType)
+	if err != nil {
+		return nil, err
+	}
+
+	var signed SignedTransaction
+	err = json.Unmarshal(response, &signed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &signed, nil
+}
@@ -0,0 +1,381 @@
+package syscoinrpc_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+	"github.com/thebotguys/golang-syscoin-rpc-client/syscoinrpctest"
+)
+
+const testPayAddress = "SkPqgDwM7eBfoWuqDzWe1zcvgHTmdZnTz8"
+
+// coinbaseTxID returns the ID of the coinbase transaction of the block at height.
+func coinbaseTxID(t *testing.T, cl *syscoinrpc.Client, srv *syscoinrpctest.Server, height uint64) syscoinrpc.Hash {
+	b, err := cl.Blockchain.GetBlockWithTransactions(srv.BlockHash(height))
+	require.NoError(t, err)
+	return b.Tx[0].TxID
+}
+
+// spendCoinbase creates a transaction spending the coinbase of the block at
+// height, paying the block subsidy minus the fee to testPayAddress.
+func spendCoinbase(t *testing.T, cl *syscoinrpc.Client, srv *syscoinrpctest.Server, height uint64, fee syscoinrpc.Amount) string {
+	inputs := []syscoinrpc.RawTxInput{{TxID: coinbaseTxID(t, cl, srv, height), Vout: 0}}
+	outputs := []syscoinrpc.RawTxOutput{syscoinrpc.NewAddressOutput(testPayAddress, syscoinrpc.Amount(syscoinrpctest.BlockSubsidy).Sub(fee))}
+	rawTxHex, err := cl.RawTx.CreateRawTransaction(inputs, outputs, 0, false)
+	require.NoError(t, err)
+	return rawTxHex
+}
+
+// newFakeServer is like newFakeClient but also returns the fake node.
+func newFakeServer(t *testing.T) (*syscoinrpctest.Server, *syscoinrpc.Client) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	t.Cleanup(srv.Close)
+	srv.Generate(10)
+	cl, err := srv.Client(testTimeout)
+	require.NoError(t, err)
+	return srv, cl
+}
+
+func TestCreateRawTransactionInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.RawTx.CreateRawTransaction(nil, nil, 0, false)
+	require.Error(t, err, "Must error on any method with invalid URL")
+
+	cl = newFakeClient(t)
+	outputs := []syscoinrpc.RawTxOutput{
+		syscoinrpc.NewAddressOutput(testPayAddress, 100000000),
+		syscoinrpc.NewAddressOutput(testPayAddress, 200000000),
+	}
+	_, err = cl.RawTx.CreateRawTransaction(nil, outputs, 0, false)
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCInvalidParameter), "Must error on duplicated addresses, got %v", err)
+}
+
+func TestCreateRawTransactionOK(t *testing.T) {
+	cl := newFakeClient(t)
+
+	rawTxHex, err := cl.RawTx.CreateRawTransaction(nil, nil, 0, false)
+	require.NoError(t, err, "CreateRawTransaction : must not error")
+	empty, err := syscoinrpc.DecodeTransactionHex(rawTxHex, syscoinrpc.RegTest)
+	require.NoError(t, err)
+	require.Empty(t, empty.Vin)
+	require.Empty(t, empty.Vout)
+
+	sequence := uint32(42)
+	inputs := []syscoinrpc.RawTxInput{
+		{TxID: syscoinrpc.MustParseHash("1111111111111111111111111111111111111111111111111111111111111111"), Vout: 1},
+		{TxID: syscoinrpc.MustParseHash("2222222222222222222222222222222222222222222222222222222222222222"), Vout: 0, Sequence: &sequence},
+	}
+	outputs := []syscoinrpc.RawTxOutput{
+		syscoinrpc.NewDataOutput([]byte("hello")),
+		syscoinrpc.NewAddressOutput(testPayAddress, 150000000),
+	}
+	rawTxHex, err = cl.RawTx.CreateRawTransaction(inputs, outputs, 100, true)
+	require.NoError(t, err, "CreateRawTransaction : must not error")
+
+	tx, err := syscoinrpc.DecodeTransactionHex(rawTxHex, syscoinrpc.RegTest)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), tx.LockTime)
+	require.Len(t, tx.Vin, 2)
+	require.Equal(t, inputs[0].TxID, tx.Vin[0].TxID)
+	require.Equal(t, uint32(1), tx.Vin[0].Vout)
+	require.Equal(t, uint64(0xfffffffd), tx.Vin[0].Sequence, "Must signal replaceability")
+	require.Equal(t, uint64(42), tx.Vin[1].Sequence, "Must keep explicit sequence numbers")
+	require.Len(t, tx.Vout, 2)
+	require.Equal(t, syscoinrpc.ScriptTypeNullData, tx.Vout[0].ScriptPubKey.Type, "Must keep the outputs order")
+	require.Equal(t, "OP_RETURN 68656c6c6f", tx.Vout[0].ScriptPubKey.Asm)
+	require.Equal(t, syscoinrpc.Amount(150000000), tx.Vout[1].Value)
+
+	t.Log("CreateRawTransaction :", rawTxHex)
+}
+
+func TestDecodeRawTransactionInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.RawTx.DecodeRawTransaction("")
+	require.Error(t, err, "Must error on any method with invalid URL")
+
+	cl = newFakeClient(t)
+	_, err = cl.RawTx.DecodeRawTransaction("zz")
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCDeserializationError), "Must error on malformed transactions, got %v", err)
+}
+
+func TestDecodeRawTransactionOK(t *testing.T) {
+	cl := newFakeClient(t)
+
+	raw, _ := testWitnessTx()
+	tx, err := cl.RawTx.DecodeRawTransaction(hex.EncodeToString(raw))
+	require.NoError(t, err, "DecodeRawTransaction : must not error")
+
+	expected, err := syscoinrpc.DecodeTransaction(raw, syscoinrpc.RegTest)
+	require.NoError(t, err)
+	expected.Hex = ""
+	require.Equal(t, expected, tx, "Must decode as DecodeTransaction does, without the hex")
+
+	t.Log("DecodeRawTransaction :", tx)
+}
+
+func TestDecodeScriptRPCInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.RawTx.DecodeScript("")
+	require.Error(t, err, "Must error on any method with invalid URL")
+
+	cl = newFakeClient(t)
+	_, err = cl.RawTx.DecodeScript("zz")
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCInvalidParameter), "Must error on malformed scripts, got %v", err)
+}
+
+func TestDecodeScriptRPCOK(t *testing.T) {
+	cl := newFakeClient(t)
+
+	scriptHex := "5121020000000000000000000000000000000000000000000000000000000000000000" + "51ae"
+	script, err := cl.RawTx.DecodeScript(scriptHex)
+	require.NoError(t, err, "DecodeScript : must not error")
+
+	expected, err := syscoinrpc.DecodeScriptHex(scriptHex, syscoinrpc.RegTest)
+	require.NoError(t, err)
+	require.Equal(t, expected, script, "Must decode as DecodeScript does")
+	require.Equal(t, syscoinrpc.ScriptTypeMultiSig, script.Type)
+	require.NotNil(t, script.Segwit)
+	require.NotEmpty(t, script.Segwit.P2SHSegwit)
+
+	t.Log("DecodeScript :", script)
+}
+
+func TestFundRawTransactionInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.RawTx.FundRawTransaction("", nil)
+	require.Error(t, err, "Must error on any method with invalid URL")
+
+	cl = newFakeClient(t)
+	rawTxHex, err := cl.RawTx.CreateRawTransaction(nil, []syscoinrpc.RawTxOutput{syscoinrpc.NewAddressOutput(testPayAddress, 1000*100000000)}, 0, false)
+	require.NoError(t, err)
+	_, err = cl.RawTx.FundRawTransaction(rawTxHex, nil)
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCWalletInsufficientFunds), "Must error on insufficient funds, got %v", err)
+
+	changePosition := 2
+	_, err = cl.RawTx.FundRawTransaction(rawTxHex, &syscoinrpc.FundRawTransactionOptions{ChangePosition: &changePosition})
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCInvalidParameter), "Must error on change position out of bounds, got %v", err)
+}
+
+func TestFundRawTransactionOK(t *testing.T) {
+	cl := newFakeClient(t)
+
+	rawTxHex, err := cl.RawTx.CreateRawTransaction(nil, []syscoinrpc.RawTxOutput{syscoinrpc.NewAddressOutput(testPayAddress, 100000000)}, 0, false)
+	require.NoError(t, err)
+
+	changePosition := 0
+	funded, err := cl.RawTx.FundRawTransaction(rawTxHex, &syscoinrpc.FundRawTransactionOptions{ChangePosition: &changePosition})
+	require.NoError(t, err, "FundRawTransaction : must not error")
+	require.Equal(t, 0, funded.ChangePosition)
+	require.Equal(t, syscoinrpc.Amount(10000), funded.Fee)
+
+	tx, err := syscoinrpc.DecodeTransactionHex(funded.Hex, syscoinrpc.RegTest)
+	require.NoError(t, err)
+	require.Len(t, tx.Vin, 1, "Must add one coinbase input")
+	require.Len(t, tx.Vout, 2)
+	require.Equal(t, syscoinrpc.Amount(syscoinrpctest.BlockSubsidy-100000000-10000), tx.Vout[0].Value, "Must add the change at the requested position")
+	require.Equal(t, syscoinrpc.Amount(100000000), tx.Vout[1].Value)
+
+	funded, err = cl.RawTx.FundRawTransaction(funded.Hex, nil)
+	require.NoError(t, err, "FundRawTransaction : must not error")
+	require.Equal(t, -1, funded.ChangePosition, "Must add no change to funded transactions")
+
+	t.Log("FundRawTransaction :", funded)
+}
+
+func TestGetRawTransactionInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.RawTx.GetRawTransaction(syscoinrpc.Hash{})
+	require.Error(t, err, "Must error on any method with invalid URL")
+	_, err = cl.RawTx.GetFullRawTransaction(syscoinrpc.Hash{})
+	require.Error(t, err, "Must error on any method with invalid URL")
+
+	cl = newFakeClient(t)
+	_, err = cl.RawTx.GetRawTransaction(syscoinrpc.Hash{})
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCInvalidAddressOrKey), "Must error on unknown transactions, got %v", err)
+	_, err = cl.RawTx.GetFullRawTransaction(syscoinrpc.Hash{})
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCInvalidAddressOrKey), "Must error on unknown transactions, got %v", err)
+}
+
+func TestGetRawTransactionOK(t *testing.T) {
+	srv, cl := newFakeServer(t)
+
+	txID := coinbaseTxID(t, cl, srv, 1)
+	rawTxHex, err := cl.RawTx.GetRawTransaction(txID)
+	require.NoError(t, err, "GetRawTransaction : must not error")
+	tx, err := syscoinrpc.DecodeTransactionHex(rawTxHex, syscoinrpc.RegTest)
+	require.NoError(t, err)
+	require.Equal(t, txID, tx.TxID)
+
+	fullTx, err := cl.RawTx.GetFullRawTransaction(txID)
+	require.NoError(t, err, "GetFullRawTransaction : must not error")
+	require.Equal(t, txID, fullTx.TxID)
+	require.Equal(t, rawTxHex, fullTx.Hex)
+	require.Equal(t, srv.BlockHash(1), fullTx.BlockHash)
+	require.Equal(t, uint64(10), fullTx.Confirmations)
+	require.Equal(t, uint64(syscoinrpctest.GenesisTime+syscoinrpctest.BlockInterval), fullTx.BlockTime)
+	require.Equal(t, fullTx.BlockTime, fullTx.Time)
+
+	_, stripped := testWitnessTx()
+	mempoolTxID := srv.AddRawTransaction(stripped)
+	mempoolTx, err := cl.RawTx.GetFullRawTransaction(mempoolTxID)
+	require.NoError(t, err, "GetFullRawTransaction : must not error")
+	require.Equal(t, mempoolTxID, mempoolTx.TxID)
+	require.Zero(t, mempoolTx.Confirmations, "Mempool transactions have no confirmations")
+	require.True(t, mempoolTx.BlockHash.IsZero(), "Mempool transactions have no block")
+
+	t.Log("GetFullRawTransaction :", fullTx)
+}
+
+func TestSendRawTransactionInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.RawTx.SendRawTransaction("", false)
+	require.Error(t, err, "Must error on any method with invalid URL")
+	require.False(t, errors.Is(err, syscoinrpc.ErrTxRejected), "Must not be a rejection without node")
+
+	srv, cl := newFakeServer(t)
+	var rejected *syscoinrpc.TxRejectedError
+
+	_, err = cl.RawTx.SendRawTransaction("zz", false)
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCDeserializationError), "Must error on malformed transactions, got %v", err)
+	require.False(t, errors.Is(err, syscoinrpc.ErrTxRejected), "Must not be a rejection on malformed transactions")
+
+	raw, _ := testWitnessTx()
+	_, err = cl.RawTx.SendRawTransaction(hex.EncodeToString(raw), false)
+	require.True(t, errors.Is(err, syscoinrpc.ErrTxRejected), "Must be a rejection, got %v", err)
+	require.True(t, errors.As(err, &rejected))
+	require.Equal(t, syscoinrpc.TxRejectMissingInputs, rejected.Reason)
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCVerifyError), "Must unwrap to the node error")
+
+	_, err = cl.RawTx.SendRawTransaction(spendCoinbase(t, cl, srv, 1, 0), false)
+	require.True(t, errors.As(err, &rejected), "Must be a rejection, got %v", err)
+	require.Equal(t, syscoinrpc.TxRejectMinRelayFee, rejected.Reason)
+	require.Equal(t, "0 < 10000", rejected.Details)
+	require.True(t, rejected.InsufficientFee(), "Must report the fee as insufficient")
+	require.Equal(t, "Transaction rejected: min relay fee not met, 0 < 10000", rejected.Error())
+
+	_, err = cl.RawTx.SendRawTransaction(spendCoinbase(t, cl, srv, 1, 100000000), false)
+	require.True(t, errors.As(err, &rejected), "Must be a rejection, got %v", err)
+	require.Equal(t, syscoinrpc.TxRejectAbsurdFee, rejected.Reason)
+	require.Equal(t, 256, rejected.Code)
+	require.False(t, rejected.InsufficientFee())
+
+	rawCoinbaseHex, err := cl.RawTx.GetRawTransaction(coinbaseTxID(t, cl, srv, 1))
+	require.NoError(t, err)
+	_, err = cl.RawTx.SendRawTransaction(rawCoinbaseHex, false)
+	require.True(t, errors.As(err, &rejected), "Must be a rejection, got %v", err)
+	require.Equal(t, syscoinrpc.TxRejectAlreadyInChain, rejected.Reason)
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCVerifyAlreadyInChain))
+
+	_, err = cl.RawTx.SendRawTransaction(spendCoinbase(t, cl, srv, 1, 10000), false)
+	require.NoError(t, err)
+	_, err = cl.RawTx.SendRawTransaction(spendCoinbase(t, cl, srv, 1, 20000), false)
+	require.True(t, errors.As(err, &rejected), "Must be a rejection, got %v", err)
+	require.Equal(t, syscoinrpc.TxRejectMempoolConflict, rejected.Reason)
+	require.Equal(t, 18, rejected.Code)
+
+	srv.SetError("sendrawtransaction", syscoinrpc.RPCVerifyRejected, "66: insufficient fee")
+	_, err = cl.RawTx.SendRawTransaction(spendCoinbase(t, cl, srv, 2, 10000), false)
+	require.True(t, errors.As(err, &rejected), "Must be a rejection, got %v", err)
+	require.Equal(t, syscoinrpc.TxRejectInsufficientFee, rejected.Reason, "Must parse the legacy format")
+	require.True(t, rejected.InsufficientFee())
+
+	srv.SetError("sendrawtransaction", syscoinrpc.RPCMiscError, "Unexpected")
+	_, err = cl.RawTx.SendRawTransaction(spendCoinbase(t, cl, srv, 2, 10000), false)
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCMiscError))
+	require.False(t, errors.Is(err, syscoinrpc.ErrTxRejected), "Must not be a rejection on other errors")
+}
+
+func TestSendRawTransactionOK(t *testing.T) {
+	srv, cl := newFakeServer(t)
+
+	rawTxHex, err := cl.RawTx.CreateRawTransaction(nil, []syscoinrpc.RawTxOutput{syscoinrpc.NewAddressOutput(testPayAddress, 100000000)}, 0, false)
+	require.NoError(t, err)
+	funded, err := cl.RawTx.FundRawTransaction(rawTxHex, nil)
+	require.NoError(t, err)
+	signed, err := cl.RawTx.SignRawTransaction(funded.Hex, nil, nil, "")
+	require.NoError(t, err)
+	require.True(t, signed.Complete)
+
+	txID, err := cl.RawTx.SendRawTransaction(signed.Hex, false)
+	require.NoError(t, err, "SendRawTransaction : must not error")
+	mempool, err := cl.Blockchain.GetRawMempool()
+	require.NoError(t, err)
+	require.Contains(t, mempool, txID)
+
+	sameTxID, err := cl.RawTx.SendRawTransaction(signed.Hex, false)
+	require.NoError(t, err, "Must accept transactions already in the mempool")
+	require.Equal(t, txID, sameTxID)
+
+	highFeeTxID, err := cl.RawTx.SendRawTransaction(spendCoinbase(t, cl, srv, 2, 100000000), true)
+	require.NoError(t, err, "Must accept high fees when allowed")
+
+	srv.Generate(1)
+	for _, id := range []syscoinrpc.Hash{txID, highFeeTxID} {
+		tx, err := cl.RawTx.GetFullRawTransaction(id)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), tx.Confirmations, "Must be mined in the next block")
+	}
+
+	t.Log("SendRawTransaction :", txID)
+}
+
+func TestSignRawTransactionInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.RawTx.SignRawTransaction("", nil, nil, "")
+	require.Error(t, err, "Must error on any method with invalid URL")
+
+	cl = newFakeClient(t)
+	_, err = cl.RawTx.SignRawTransaction("zz", nil, nil, "")
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCDeserializationError), "Must error on malformed transactions, got %v", err)
+	raw, _ := testWitnessTx()
+	_, err = cl.RawTx.SignRawTransaction(hex.EncodeToString(raw), nil, nil, "ANY")
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCInvalidParameter), "Must error on invalid sighash types, got %v", err)
+}
+
+func TestSignRawTransactionOK(t *testing.T) {
+	srv, cl := newFakeServer(t)
+
+	signed, err := cl.RawTx.SignRawTransaction(spendCoinbase(t, cl, srv, 1, 10000), nil, nil, syscoinrpc.SigHashSingleAnyoneCanPay)
+	require.NoError(t, err, "SignRawTransaction : must not error")
+	require.True(t, signed.Complete)
+	require.Empty(t, signed.Errors)
+
+	raw, _ := testWitnessTx()
+	signed, err = cl.RawTx.SignRawTransaction(hex.EncodeToString(raw), nil, nil, "")
+	require.NoError(t, err, "SignRawTransaction : must not error")
+	require.False(t, signed.Complete, "Must not sign unknown inputs")
+	require.Len(t, signed.Errors, 1)
+	require.Equal(t, "1111111111111111111111111111111111111111111111111111111111111111", signed.Errors[0].TxID.String())
+	require.Equal(t, uint32(1), signed.Errors[0].Vout)
+	require.Equal(t, uint32(0xfffffffe), signed.Errors[0].Sequence)
+	require.NotEmpty(t, signed.Errors[0].Error)
+
+	prevTxs := []syscoinrpc.PrevTx{{
+		TxID:         signed.Errors[0].TxID,
+		Vout:         1,
+		ScriptPubKey: "0014" + hex.EncodeToString(make([]byte, 20)),
+		Amount:       100000000,
+	}}
+	signed, err = cl.RawTx.SignRawTransaction(hex.EncodeToString(raw), prevTxs, nil, syscoinrpc.SigHashAll)
+	require.NoError(t, err, "SignRawTransaction : must not error")
+	require.True(t, signed.Complete, "Must sign inputs given as previous outputs")
+
+	t.Log("SignRawTransaction :", signed)
+}
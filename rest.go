@@ -0,0 +1,397 @@
+package syscoinrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// RESTFormat is the format of the responses of the REST interface.
+type RESTFormat string
+
+const (
+	// RESTFormatJSON is the JSON format, the same as the one of the verbose RPC calls.
+	RESTFormatJSON RESTFormat = "json"
+	// RESTFormatHex is the hex encoded serialized format.
+	RESTFormatHex RESTFormat = "hex"
+	// RESTFormatBinary is the serialized format.
+	RESTFormatBinary RESTFormat = "bin"
+)
+
+// maxRESTHeaders is the maximum number of headers returned by a single `headers` request.
+const maxRESTHeaders = 2000
+
+// ErrInvalidHeaderCount is returned when requesting a number of headers the node does not serve.
+var ErrInvalidHeaderCount = errors.New("Invalid header count: must be between 1 and 2000")
+
+// RESTClient represents a client of the REST interface of the node, enabled
+// with the `-rest` option. It needs no RPC credentials, so it suits public
+// read-only services.
+type RESTClient struct {
+	url         string       // The base URL of the node.
+	httpClient  *http.Client // The HTTP client.
+	headers     http.Header  // The additional headers sent on every request.
+	retryPolicy *RetryPolicy // The retry policy, nil to never retry.
+}
+
+// NewRESTClient creates a new client of the REST interface of the node.
+//
+// The options configuring the HTTP connection and WithRetryPolicy are
+// supported, the other ones are ignored.
+//
+//     nodeURL : The absolute URL of the node (e.g. http://127.0.0.1:8370).
+//     options : Optional settings, see ClientOption.
+func NewRESTClient(nodeURL string, options ...ClientOption) (*RESTClient, error) {
+	err := checkNodeURL(nodeURL)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := applyOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RESTClient{
+		url:         strings.TrimSuffix(nodeURL, "/"),
+		httpClient:  opts.buildHTTPClient(),
+		headers:     opts.headers,
+		retryPolicy: opts.retryPolicy,
+	}, nil
+}
+
+// get performs a REST request and returns the raw response body.
+//     ctx    : The context of the request, cancelling it aborts the HTTP request.
+//     format : The format of the response.
+//     path   : The path of the resource, after `/rest/`.
+func (rc *RESTClient) get(ctx context.Context, format RESTFormat, path ...string) ([]byte, error) {
+	var content []byte
+	err := rc.retryPolicy.run(ctx, []string{"rest/" + path[0]}, func() error {
+		var err error
+		content, err = rc.send(ctx, "/rest/"+strings.Join(path, "/")+"."+string(format))
+		return err
+	})
+	return content, err
+}
+
+// send performs a single HTTP request.
+func (rc *RESTClient) send(ctx context.Context, path string) ([]byte, error) {
+	req, err := newHTTPRequest(ctx, "GET", rc.url+path, nil, rc.headers)
+	if err != nil {
+		return nil, err
+	}
+
+	status, content, err := doHTTPRequest(rc.httpClient, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != http.StatusOK {
+		httpErr := newHTTPError(status, content)
+		// The node answers unknown paths, like when REST is disabled,
+		// with an empty 404, and missing resources with a message.
+		if status == http.StatusNotFound && httpErr.Body != "" {
+			httpErr.Err = ErrResourceNotFound
+		}
+		return nil, httpErr
+	}
+
+	return content, nil
+}
+
+// getJSON performs a REST request in JSON format and decodes the response into v.
+func (rc *RESTClient) getJSON(ctx context.Context, v interface{}, path ...string) error {
+	content, err := rc.get(ctx, RESTFormatJSON, path...)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(content, v)
+}
+
+// getHex performs a REST request in hex format and returns the trimmed response.
+func (rc *RESTClient) getHex(ctx context.Context, path ...string) (string, error) {
+	content, err := rc.get(ctx, RESTFormatHex, path...)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+// GetBlock returns the hex-encoded serialized block, like BlockchainClient.GetBlock.
+// See DecodeBlockHex to decode it.
+func (rc *RESTClient) GetBlock(blockHash Hash) (string, error) {
+	return rc.GetBlockContext(context.Background(), blockHash)
+}
+
+// GetBlockContext is like GetBlock but uses the given context for the request.
+func (rc *RESTClient) GetBlockContext(ctx context.Context, blockHash Hash) (string, error) {
+	return rc.getHex(ctx, "block", blockHash.String())
+}
+
+// GetBlockBinary returns the serialized block. See DecodeBlock to decode it.
+func (rc *RESTClient) GetBlockBinary(blockHash Hash) ([]byte, error) {
+	return rc.GetBlockBinaryContext(context.Background(), blockHash)
+}
+
+// GetBlockBinaryContext is like GetBlockBinary but uses the given context for the request.
+func (rc *RESTClient) GetBlockBinaryContext(ctx context.Context, blockHash Hash) ([]byte, error) {
+	return rc.get(ctx, RESTFormatBinary, "block", blockHash.String())
+}
+
+// GetFullBlock returns the block with the IDs of its transactions,
+// like BlockchainClient.GetFullBlock.
+func (rc *RESTClient) GetFullBlock(blockHash Hash) (*FullBlock, error) {
+	return rc.GetFullBlockContext(context.Background(), blockHash)
+}
+
+// GetFullBlockContext is like GetFullBlock but uses the given context for the request.
+func (rc *RESTClient) GetFullBlockContext(ctx context.Context, blockHash Hash) (*FullBlock, error) {
+	var block FullBlock
+	err := rc.getJSON(ctx, &block, "block", "notxdetails", blockHash.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return &block, nil
+}
+
+// GetBlockWithTransactions returns the block with its fully decoded transactions,
+// like BlockchainClient.GetBlockWithTransactions.
+func (rc *RESTClient) GetBlockWithTransactions(blockHash Hash) (*BlockWithTransactions, error) {
+	return rc.GetBlockWithTransactionsContext(context.Background(), blockHash)
+}
+
+// GetBlockWithTransactionsContext is like GetBlockWithTransactions but uses the given context for the request.
+func (rc *RESTClient) GetBlockWithTransactionsContext(ctx context.Context, blockHash Hash) (*BlockWithTransactions, error) {
+	var block BlockWithTransactions
+	err := rc.getJSON(ctx, &block, "block", blockHash.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return &block, nil
+}
+
+// GetBlockHeaders returns up to count headers of the active chain, starting from
+// the given block, in height order. The node returns at most 2000 headers.
+//
+//     count     : The maximum number of headers, from 1 to 2000.
+//     blockHash : The hash of the first block.
+func (rc *RESTClient) GetBlockHeaders(count int, blockHash Hash) ([]*FullBlockHeader, error) {
+	return rc.GetBlockHeadersContext(context.Background(), count, blockHash)
+}
+
+// GetBlockHeadersContext is like GetBlockHeaders but uses the given context for the request.
+func (rc *RESTClient) GetBlockHeadersContext(ctx context.Context, count int, blockHash Hash) ([]*FullBlockHeader, error) {
+	path, err := headersPath(count, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var headers []*FullBlockHeader
+	err = rc.getJSON(ctx, &headers, path...)
+	if err != nil {
+		return nil, err
+	}
+
+	return headers, nil
+}
+
+// GetBlockHeadersHex is like GetBlockHeaders but returns the hex-encoded header stream.
+// See DecodeBlockHeaders to decode it.
+func (rc *RESTClient) GetBlockHeadersHex(count int, blockHash Hash) (string, error) {
+	return rc.GetBlockHeadersHexContext(context.Background(), count, blockHash)
+}
+
+// GetBlockHeadersHexContext is like GetBlockHeadersHex but uses the given context for the request.
+func (rc *RESTClient) GetBlockHeadersHexContext(ctx context.Context, count int, blockHash Hash) (string, error) {
+	path, err := headersPath(count, blockHash)
+	if err != nil {
+		return "", err
+	}
+	return rc.getHex(ctx, path...)
+}
+
+// GetBlockHeadersBinary is like GetBlockHeaders but returns the binary header stream,
+// the serialized headers one after the other. See DecodeBlockHeaders to decode it.
+func (rc *RESTClient) GetBlockHeadersBinary(count int, blockHash Hash) ([]byte, error) {
+	return rc.GetBlockHeadersBinaryContext(context.Background(), count, blockHash)
+}
+
+// GetBlockHeadersBinaryContext is like GetBlockHeadersBinary but uses the given context for the request.
+func (rc *RESTClient) GetBlockHeadersBinaryContext(ctx context.Context, count int, blockHash Hash) ([]byte, error) {
+	path, err := headersPath(count, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	return rc.get(ctx, RESTFormatBinary, path...)
+}
+
+// headersPath returns the path of a `headers` request.
+func headersPath(count int, blockHash Hash) ([]string, error) {
+	if count < 1 || count > maxRESTHeaders {
+		return nil, ErrInvalidHeaderCount
+	}
+	return []string{"headers", strconv.Itoa(count), blockHash.String()}, nil
+}
+
+// GetTransaction returns the decoded transaction. Transactions not in the
+// mempool are only found when the node maintains the transaction index (`-txindex`).
+func (rc *RESTClient) GetTransaction(txID Hash) (*Transaction, error) {
+	return rc.GetTransactionContext(context.Background(), txID)
+}
+
+// GetTransactionContext is like GetTransaction but uses the given context for the request.
+func (rc *RESTClient) GetTransactionContext(ctx context.Context, txID Hash) (*Transaction, error) {
+	var tx Transaction
+	err := rc.getJSON(ctx, &tx, "tx", txID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return &tx, nil
+}
+
+// GetRawTransaction is like GetTransaction but returns the hex-encoded serialized
+// transaction. See DecodeTransactionHex to decode it.
+func (rc *RESTClient) GetRawTransaction(txID Hash) (string, error) {
+	return rc.GetRawTransactionContext(context.Background(), txID)
+}
+
+// GetRawTransactionContext is like GetRawTransaction but uses the given context for the request.
+func (rc *RESTClient) GetRawTransactionContext(ctx context.Context, txID Hash) (string, error) {
+	return rc.getHex(ctx, "tx", txID.String())
+}
+
+// GetRawTransactionBinary is like GetTransaction but returns the serialized
+// transaction. See DecodeTransaction to decode it.
+func (rc *RESTClient) GetRawTransactionBinary(txID Hash) ([]byte, error) {
+	return rc.GetRawTransactionBinaryContext(context.Background(), txID)
+}
+
+// GetRawTransactionBinaryContext is like GetRawTransactionBinary but uses the given context for the request.
+func (rc *RESTClient) GetRawTransactionBinaryContext(ctx context.Context, txID Hash) ([]byte, error) {
+	return rc.get(ctx, RESTFormatBinary, "tx", txID.String())
+}
+
+// UTXOSet represents the response of a REST `getutxos` request.
+type UTXOSet struct {
+	// ChainHeight is the height of the active chain.
+	ChainHeight uint64 `json:"chainHeight"`
+	// ChainTipHash is the hash of the tip of the active chain.
+	ChainTipHash Hash `json:"chaintipHash"`
+	// Bitmap has a '1' for every queried outpoint which is unspent, a '0' otherwise.
+	Bitmap string `json:"bitmap"`
+	// UTXOs are the unspent outputs, in the order of the queried outpoints.
+	UTXOs []*UTXO `json:"utxos"`
+}
+
+// IsUnspent returns true if the i-th queried outpoint is unspent.
+func (set *UTXOSet) IsUnspent(i int) bool {
+	return i >= 0 && i < len(set.Bitmap) && set.Bitmap[i] == '1'
+}
+
+// UTXO represents an unspent transaction output.
+type UTXO struct {
+	// Height is the height of the block containing the transaction,
+	// 2147483647 (MEMPOOL_HEIGHT) for mempool transactions.
+	Height uint64 `json:"height"`
+	// Value is the value of the output.
+	Value Amount `json:"value"`
+	// ScriptPubKey is the PubKey script of the output.
+	ScriptPubKey ScriptPubKey `json:"scriptPubKey"`
+}
+
+// GetUTXOs returns which outpoints are unspent, with their outputs.
+//
+//     checkMempool : Whether to consider the outputs spent or created by mempool transactions.
+//     outPoints    : The queried outpoints, at most 15.
+func (rc *RESTClient) GetUTXOs(checkMempool bool, outPoints []OutPoint) (*UTXOSet, error) {
+	return rc.GetUTXOsContext(context.Background(), checkMempool, outPoints)
+}
+
+// GetUTXOsContext is like GetUTXOs but uses the given context for the request.
+func (rc *RESTClient) GetUTXOsContext(ctx context.Context, checkMempool bool, outPoints []OutPoint) (*UTXOSet, error) {
+	var set UTXOSet
+	err := rc.getJSON(ctx, &set, utxosPath(checkMempool, outPoints)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &set, nil
+}
+
+// GetUTXOsBinary is like GetUTXOs but returns the serialized response.
+// See DecodeUTXOSet to decode it.
+func (rc *RESTClient) GetUTXOsBinary(checkMempool bool, outPoints []OutPoint) ([]byte, error) {
+	return rc.GetUTXOsBinaryContext(context.Background(), checkMempool, outPoints)
+}
+
+// GetUTXOsBinaryContext is like GetUTXOsBinary but uses the given context for the request.
+func (rc *RESTClient) GetUTXOsBinaryContext(ctx context.Context, checkMempool bool, outPoints []OutPoint) ([]byte, error) {
+	return rc.get(ctx, RESTFormatBinary, utxosPath(checkMempool, outPoints)...)
+}
+
+// utxosPath returns the path of a `getutxos` request.
+func utxosPath(checkMempool bool, outPoints []OutPoint) []string {
+	path := []string{"getutxos"}
+	if checkMempool {
+		path = append(path, "checkmempool")
+	}
+	for _, outPoint := range outPoints {
+		path = append(path, outPoint.String())
+	}
+	return path
+}
+
+// GetBlockchainInfo returns the state of the active chain, like BlockchainClient.GetBlockchainInfo.
+func (rc *RESTClient) GetBlockchainInfo() (*BlockchainInfo, error) {
+	return rc.GetBlockchainInfoContext(context.Background())
+}
+
+// GetBlockchainInfoContext is like GetBlockchainInfo but uses the given context for the request.
+func (rc *RESTClient) GetBlockchainInfoContext(ctx context.Context) (*BlockchainInfo, error) {
+	var info BlockchainInfo
+	err := rc.getJSON(ctx, &info, "chaininfo")
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// GetMempoolInfo returns the state of the mempool, like BlockchainClient.GetMempoolInfo.
+func (rc *RESTClient) GetMempoolInfo() (*MempoolInfo, error) {
+	return rc.GetMempoolInfoContext(context.Background())
+}
+
+// GetMempoolInfoContext is like GetMempoolInfo but uses the given context for the request.
+func (rc *RESTClient) GetMempoolInfoContext(ctx context.Context) (*MempoolInfo, error) {
+	var info MempoolInfo
+	err := rc.getJSON(ctx, &info, "mempool", "info")
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// GetRawMempoolFull returns the mempool entries by transaction ID,
+// like BlockchainClient.GetRawMempoolFull.
+func (rc *RESTClient) GetRawMempoolFull() (map[Hash]*MempoolEntry, error) {
+	return rc.GetRawMempoolFullContext(context.Background())
+}
+
+// GetRawMempoolFullContext is like GetRawMempoolFull but uses the given context for the request.
+func (rc *RESTClient) GetRawMempoolFullContext(ctx context.Context) (map[Hash]*MempoolEntry, error) {
+	var rawpool map[Hash]*MempoolEntry
+	err := rc.getJSON(ctx, &rawpool, "mempool", "contents")
+	if err != nil {
+		return nil, err
+	}
+
+	return rawpool, nil
+}
@@ -0,0 +1,176 @@
+package syscoinrpc_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+	"github.com/thebotguys/golang-syscoin-rpc-client/syscoinrpctest"
+)
+
+func TestNewRESTClientInvalid(t *testing.T) {
+	_, err := syscoinrpc.NewRESTClient("127.0.0.1:8370")
+	require.Equal(t, syscoinrpc.ErrInvalidURL, err, "Must error on URLs without scheme")
+
+	_, err = syscoinrpc.NewRESTClient("ftp://127.0.0.1:8370")
+	require.Equal(t, syscoinrpc.ErrInvalidURL, err, "Must error on unsupported schemes")
+
+	_, err = syscoinrpc.NewRESTClient("http://127.0.0.1:8370", syscoinrpc.WithTimeout(0))
+	require.Equal(t, syscoinrpc.ErrInvalidOption, err, "Must error on invalid options")
+}
+
+func TestRESTClientInvalid(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	t.Cleanup(srv.Close)
+	rc, err := syscoinrpc.NewRESTClient(srv.URL+"/", testTimeout)
+	require.NoError(t, err)
+
+	missing := syscoinrpc.MustParseHash("00000000000000000000000000000000000000000000000000000000deadbeef")
+	_, err = rc.GetFullBlock(missing)
+	require.True(t, errors.Is(err, syscoinrpc.ErrResourceNotFound), "Must error on missing blocks, got %v", err)
+	require.True(t, syscoinrpc.IsNotFound(err), "Must be recognized as not found")
+
+	_, err = rc.GetRawTransaction(missing)
+	require.True(t, syscoinrpc.IsNotFound(err), "Must error on missing transactions, got %v", err)
+
+	_, err = rc.GetBlockHeaders(0, srv.BlockHash(0))
+	require.Equal(t, syscoinrpc.ErrInvalidHeaderCount, err, "Must error on invalid header counts")
+	_, err = rc.GetBlockHeadersBinary(2001, srv.BlockHash(0))
+	require.Equal(t, syscoinrpc.ErrInvalidHeaderCount, err, "Must error on invalid header counts")
+
+	outPoints := make([]syscoinrpc.OutPoint, 16)
+	_, err = rc.GetUTXOs(false, outPoints)
+	require.True(t, errors.Is(err, syscoinrpc.ErrBadRequest), "Must error on too many outpoints, got %v", err)
+
+	srv.SetHTTPStatus(http.StatusServiceUnavailable)
+	_, err = rc.GetBlockchainInfo()
+	require.True(t, errors.Is(err, syscoinrpc.ErrWorkQueueExceeded), "Must error on failed requests, got %v", err)
+	srv.ClearErrors()
+
+	// The node answers unknown paths with an empty 404.
+	disabled := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(disabled.Close)
+	rc, err = syscoinrpc.NewRESTClient(disabled.URL)
+	require.NoError(t, err)
+	_, err = rc.GetMempoolInfo()
+	require.True(t, errors.Is(err, syscoinrpc.ErrMethodNotFound), "Must error when REST is disabled, got %v", err)
+	require.False(t, syscoinrpc.IsNotFound(err))
+}
+
+func TestRESTClientOK(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	t.Cleanup(srv.Close)
+	srv.Generate(5)
+	cl, err := srv.Client(testTimeout)
+	require.NoError(t, err)
+	rc, err := syscoinrpc.NewRESTClient(srv.URL, testTimeout)
+	require.NoError(t, err)
+
+	_, rawTx := testWitnessTx()
+	txID := srv.AddRawTransaction(rawTx)
+	mempoolTx, err := rc.GetTransaction(txID)
+	require.NoError(t, err)
+	require.Equal(t, txID, mempoolTx.TxID)
+	require.True(t, mempoolTx.BlockHash.IsZero(), "Mempool transactions have no block")
+
+	outPoints := []syscoinrpc.OutPoint{{TxID: txID, Vout: 0}, {TxID: txID, Vout: 1}, {TxID: txID, Vout: 2}}
+	set, err := rc.GetUTXOs(false, outPoints)
+	require.NoError(t, err)
+	require.Equal(t, "000", set.Bitmap, "Mempool outputs are only seen with checkmempool")
+	set, err = rc.GetUTXOs(true, outPoints)
+	require.NoError(t, err)
+	require.Equal(t, "110", set.Bitmap)
+	require.True(t, set.IsUnspent(1))
+	require.False(t, set.IsUnspent(2))
+	require.Len(t, set.UTXOs, 2)
+	require.Equal(t, uint64(0x7fffffff), set.UTXOs[0].Height)
+	require.Equal(t, mempoolTx.Vout[0].Value, set.UTXOs[0].Value)
+	require.Equal(t, mempoolTx.Vout[1].ScriptPubKey, set.UTXOs[1].ScriptPubKey)
+
+	entries, err := rc.GetRawMempoolFull()
+	require.NoError(t, err)
+	require.Contains(t, entries, txID)
+	mempoolInfo, err := rc.GetMempoolInfo()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), mempoolInfo.Size)
+
+	hash := srv.Generate(1)[0]
+
+	rawBlock, err := rc.GetBlock(hash)
+	require.NoError(t, err)
+	rpcRawBlock, err := cl.Blockchain.GetBlock(hash)
+	require.NoError(t, err)
+	require.Equal(t, rpcRawBlock, rawBlock, "Must return the same hex as the RPC")
+	binBlock, err := rc.GetBlockBinary(hash)
+	require.NoError(t, err)
+	decoded, err := syscoinrpc.DecodeBlock(binBlock, syscoinrpc.RegTest)
+	require.NoError(t, err)
+	require.Equal(t, hash, decoded.Hash)
+
+	fullBlock, err := rc.GetFullBlock(hash)
+	require.NoError(t, err)
+	rpcFullBlock, err := cl.Blockchain.GetFullBlock(hash)
+	require.NoError(t, err)
+	require.Equal(t, rpcFullBlock, fullBlock, "Must return the same block as the RPC")
+
+	blockWithTxs, err := rc.GetBlockWithTransactions(hash)
+	require.NoError(t, err)
+	rpcBlockWithTxs, err := cl.Blockchain.GetBlockWithTransactions(hash)
+	require.NoError(t, err)
+	require.Equal(t, rpcBlockWithTxs, blockWithTxs, "Must return the same block as the RPC")
+
+	tx, err := rc.GetTransaction(txID)
+	require.NoError(t, err)
+	require.Equal(t, hash, tx.BlockHash, "Must find mined transactions")
+	rawTxHex, err := rc.GetRawTransaction(txID)
+	require.NoError(t, err)
+	require.Equal(t, tx.Hex, rawTxHex)
+	binTx, err := rc.GetRawTransactionBinary(txID)
+	require.NoError(t, err)
+	require.Equal(t, rawTx, binTx)
+
+	set, err = rc.GetUTXOs(false, outPoints)
+	require.NoError(t, err)
+	require.Equal(t, "110", set.Bitmap, "Mined outputs are seen without checkmempool")
+	require.Equal(t, uint64(6), set.ChainHeight)
+	require.Equal(t, hash, set.ChainTipHash)
+	require.Equal(t, uint64(6), set.UTXOs[0].Height)
+	binSet, err := rc.GetUTXOsBinary(false, outPoints)
+	require.NoError(t, err)
+	decodedSet, err := syscoinrpc.DecodeUTXOSet(binSet, syscoinrpc.RegTest)
+	require.NoError(t, err)
+	require.Equal(t, "11000000", decodedSet.Bitmap, "The binary bitmap is padded")
+	decodedSet.Bitmap = set.Bitmap
+	require.Equal(t, set, decodedSet, "Must decode the binary response")
+
+	headers, err := rc.GetBlockHeaders(10, srv.BlockHash(3))
+	require.NoError(t, err)
+	require.Len(t, headers, 4, "Must stop at the tip")
+	for i, header := range headers {
+		rpcHeader, err := cl.Blockchain.GetFullBlockHeader(srv.BlockHash(uint64(3 + i)))
+		require.NoError(t, err)
+		require.Equal(t, rpcHeader, header, "Must return the same headers as the RPC")
+	}
+	headersHex, err := rc.GetBlockHeadersHex(2, srv.BlockHash(3))
+	require.NoError(t, err)
+	decodedHeaders, err := syscoinrpc.DecodeBlockHeadersHex(headersHex)
+	require.NoError(t, err)
+	require.Len(t, decodedHeaders, 2)
+	require.Equal(t, headers[1].Hash, decodedHeaders[1].Hash)
+	headersBin, err := rc.GetBlockHeadersBinary(10, srv.BlockHash(3))
+	require.NoError(t, err)
+	decodedHeaders, err = syscoinrpc.DecodeBlockHeaders(headersBin)
+	require.NoError(t, err)
+	require.Len(t, decodedHeaders, 4)
+	require.Equal(t, hash, decodedHeaders[3].Hash)
+
+	info, err := rc.GetBlockchainInfo()
+	require.NoError(t, err)
+	require.Equal(t, hash, info.BestBlockHash)
+	require.Equal(t, uint64(6), info.Blocks)
+}
@@ -0,0 +1,229 @@
+package syscoinrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"syscall"
+	"time"
+)
+
+// RetryableErrors is a set of error classes which can be retried.
+type RetryableErrors uint
+
+const (
+	// RetryConnectionErrors retries when the connection to the node is
+	// refused or dropped (e.g. the node is restarting).
+	RetryConnectionErrors RetryableErrors = 1 << iota
+	// RetryWarmup retries when the node is still warming up (RPCInWarmup).
+	RetryWarmup
+	// RetryWorkQueueExceeded retries when the node has too many
+	// pending requests (ErrWorkQueueExceeded).
+	RetryWorkQueueExceeded
+	// RetryInternalServerErrors retries when the node fails without
+	// sending a JSON-RPC error object (ErrInternalServer).
+	RetryInternalServerErrors
+)
+
+// idempotentMethods are the RPC methods which only read the node state.
+// Any other method is never retried unless listed in RetryPolicy.RetryMutating,
+// as calling it again may apply its change twice or fail as already done.
+var idempotentMethods = map[string]bool{
+	// blockchain
+	"getbestblockhash":      true,
+	"getblock":              true,
+	"getblockchaininfo":     true,
+	"getblockcount":         true,
+	"getblockhash":          true,
+	"getblockheader":        true,
+	"getblockstats":         true,
+	"getchaintips":          true,
+	"getchaintxstats":       true,
+	"getdifficulty":         true,
+	"getmempoolancestors":   true,
+	"getmempooldescendants": true,
+	"getmempoolentry":       true,
+	"getmempoolinfo":        true,
+	"getrawmempool":         true,
+	"gettxout":              true,
+	"gettxoutproof":         true,
+	"gettxoutsetinfo":       true,
+	"verifychain":           true,
+	"verifytxoutproof":      true,
+	// mining
+	"getblocktemplate": true,
+	"getmininginfo":    true,
+	"getnetworkhashps": true,
+	// network
+	"getaddednodeinfo":   true,
+	"getconnectioncount": true,
+	"getnettotals":       true,
+	"getnetworkinfo":     true,
+	"getpeerinfo":        true,
+	"listbanned":         true,
+	"ping":               true,
+	// rawtransactions
+	"createrawtransaction": true,
+	"decoderawtransaction": true,
+	"decodescript":         true,
+	"getrawtransaction":    true,
+	"signrawtransaction":   true,
+	// util
+	"createmultisig":         true,
+	"estimatefee":            true,
+	"estimatepriority":       true,
+	"estimatesmartfee":       true,
+	"signmessagewithprivkey": true,
+	"validateaddress":        true,
+	"verifymessage":          true,
+	// control
+	"getmemoryinfo": true,
+	"help":          true,
+	"uptime":        true,
+	// REST interface
+	"rest/block":     true,
+	"rest/chaininfo": true,
+	"rest/getutxos":  true,
+	"rest/headers":   true,
+	"rest/mempool":   true,
+	"rest/tx":        true,
+}
+
+// RetryPolicy configures the automatic retry of failed calls.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum delay between two attempts.
+	MaxBackoff time.Duration
+	// Multiplier is the factor the delay grows by after every retry.
+	Multiplier float64
+	// Jitter is the fraction (0..1) of the delay which is randomized,
+	// to avoid many clients retrying at the same time.
+	Jitter float64
+	// RetryOn is the set of error classes which are retried.
+	RetryOn RetryableErrors
+	// RetryMutating lists the methods which alter the node state (e.g. `generate`)
+	// which are retried anyway. By default only the read-only methods are retried.
+	RetryMutating []string
+}
+
+// DefaultRetryPolicy is a retry policy suitable for riding out node restarts.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    5,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+	RetryOn:        RetryConnectionErrors | RetryWarmup | RetryWorkQueueExceeded,
+}
+
+// WithRetryPolicy makes the client retry failed calls according to the policy.
+//
+// Without this option calls are never retried.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(opts *clientOptions) error {
+		if policy.MaxAttempts < 1 || policy.InitialBackoff < 0 || policy.MaxBackoff < 0 ||
+			policy.Jitter < 0 || policy.Jitter > 1 {
+			return ErrInvalidOption
+		}
+		opts.retryPolicy = &policy
+		return nil
+	}
+}
+
+// isRetryable returns true if the error belongs to one of the classes.
+func (classes RetryableErrors) isRetryable(err error) bool {
+	if classes&RetryConnectionErrors != 0 && isConnectionError(err) {
+		return true
+	}
+	if classes&RetryWarmup != 0 && IsWarmup(err) {
+		return true
+	}
+	if classes&RetryWorkQueueExceeded != 0 && errors.Is(err, ErrWorkQueueExceeded) {
+		return true
+	}
+	if classes&RetryInternalServerErrors != 0 && errors.Is(err, ErrInternalServer) {
+		return true
+	}
+	return false
+}
+
+// isConnectionError returns true if the connection to the node
+// has been refused or dropped.
+func isConnectionError(err error) bool {
+	return errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// allowsMethods returns true if all the methods can be retried.
+func (policy *RetryPolicy) allowsMethods(methods []string) bool {
+	for _, method := range methods {
+		if !idempotentMethods[method] && !policy.optedIn(method) {
+			return false
+		}
+	}
+	return true
+}
+
+func (policy *RetryPolicy) optedIn(method string) bool {
+	for _, m := range policy.RetryMutating {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the delay before the given retry (starting from 1).
+func (policy *RetryPolicy) backoff(retry int) time.Duration {
+	multiplier := policy.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay := float64(policy.InitialBackoff) * math.Pow(multiplier, float64(retry-1))
+	if policy.MaxBackoff > 0 && delay > float64(policy.MaxBackoff) {
+		delay = float64(policy.MaxBackoff)
+	}
+	delay -= delay * policy.Jitter * rand.Float64()
+
+	return time.Duration(delay)
+}
+
+// retry runs fn until it succeeds, returns a non retryable error,
+// or the attempts of the retry policy of the client are exhausted.
+//
+//     methods : The RPC methods called by fn.
+func (c *Client) retry(ctx context.Context, methods []string, fn func() error) error {
+	return c.retryPolicy.run(ctx, methods, fn)
+}
+
+// run runs fn until it succeeds, returns a non retryable error, or the
+// attempts of the policy are exhausted. A nil policy never retries.
+func (policy *RetryPolicy) run(ctx context.Context, methods []string, fn func() error) error {
+	if policy == nil || !policy.allowsMethods(methods) {
+		return fn()
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn()
+		if err == nil || attempt >= policy.MaxAttempts || ctx.Err() != nil || !policy.RetryOn.isRetryable(err) {
+			return err
+		}
+
+		timer := time.NewTimer(policy.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
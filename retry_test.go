@@ -0,0 +1,110 @@
+package syscoinrpc_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+)
+
+// testHash is the hash answered by the warmup server.
+var testHash = syscoinrpc.MustParseHash("9f362bce7390fb38dfa0f98c11fb9a5158aeb280f29c8f6cb5ef43d916173bf1")
+
+var testRetryPolicy = syscoinrpc.RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     5 * time.Millisecond,
+	Multiplier:     2,
+	Jitter:         0.5,
+	RetryOn:        syscoinrpc.RetryConnectionErrors | syscoinrpc.RetryWarmup | syscoinrpc.RetryWorkQueueExceeded,
+}
+
+// newWarmupServer returns a server answering RPC_IN_WARMUP to the first failures requests.
+func newWarmupServer(failures int32, requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(requests, 1) <= failures {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"result":null,"error":{"code":-28,"message":"Loading block index..."},"id":"1"}`)
+			return
+		}
+		fmt.Fprint(w, `{"result":["9f362bce7390fb38dfa0f98c11fb9a5158aeb280f29c8f6cb5ef43d916173bf1"],"error":null,"id":"1"}`)
+	}))
+}
+
+func TestRetryInvalid(t *testing.T) {
+	_, err := syscoinrpc.NewClient(syscoinrpc.LocalNodeURL, "", "", syscoinrpc.WithRetryPolicy(syscoinrpc.RetryPolicy{}))
+	require.Equal(t, syscoinrpc.ErrInvalidOption, err, "Must error on policy without attempts")
+
+	var requests int32
+	srv := newWarmupServer(10, &requests)
+	defer srv.Close()
+
+	cl, err := syscoinrpc.NewClient(srv.URL, "", "", syscoinrpc.WithRetryPolicy(testRetryPolicy))
+	require.NoError(t, err, "Must have no error on creation")
+
+	_, err = cl.Blockchain.GetRawMempool()
+	require.True(t, syscoinrpc.IsWarmup(err), "Must return the last error when attempts are exhausted")
+	require.Equal(t, int32(3), atomic.LoadInt32(&requests), "Must stop after MaxAttempts")
+
+	atomic.StoreInt32(&requests, 0)
+	_, err = cl.Generating.Generate(1, 0)
+	require.True(t, syscoinrpc.IsWarmup(err), "Generate: must error")
+	require.Equal(t, int32(1), atomic.LoadInt32(&requests), "Must never retry mutating calls by default")
+
+	atomic.StoreInt32(&requests, 0)
+	_, err = cl.Control.Logging(nil, nil)
+	require.True(t, syscoinrpc.IsWarmup(err), "Logging: must error")
+	require.Equal(t, int32(1), atomic.LoadInt32(&requests), "Must only retry read-only calls by default")
+
+	atomic.StoreInt32(&requests, 0)
+	_, err = cl.Mining.PrioritiseTransaction(testHash, 1000)
+	require.True(t, syscoinrpc.IsWarmup(err), "PrioritiseTransaction: must error")
+	require.Equal(t, int32(1), atomic.LoadInt32(&requests), "Must never retry fee deltas by default")
+
+	atomic.StoreInt32(&requests, 0)
+	err = cl.Network.SetBan("192.168.0.1", syscoinrpc.SetBanAdd, 0, false)
+	require.True(t, syscoinrpc.IsWarmup(err), "SetBan: must error")
+	require.Equal(t, int32(1), atomic.LoadInt32(&requests), "Must never retry bans by default")
+
+	atomic.StoreInt32(&requests, 0)
+	_, err = cl.RawTx.SendRawTransaction("00", false)
+	require.True(t, syscoinrpc.IsWarmup(err), "SendRawTransaction: must error")
+	require.Equal(t, int32(1), atomic.LoadInt32(&requests), "Must never retry broadcasts by default")
+
+	atomic.StoreInt32(&requests, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = cl.Blockchain.GetRawMempoolContext(ctx)
+	require.Equal(t, context.Canceled, err, "Must stop retrying on cancellation")
+}
+
+func TestRetryOK(t *testing.T) {
+	var requests int32
+	srv := newWarmupServer(2, &requests)
+	defer srv.Close()
+
+	cl, err := syscoinrpc.NewClient(srv.URL, "", "", syscoinrpc.WithRetryPolicy(testRetryPolicy))
+	require.NoError(t, err, "Must have no error on creation")
+
+	pool, err := cl.Blockchain.GetRawMempool()
+	require.NoError(t, err, "GetRawMempool: must succeed after the node warmed up")
+	require.Equal(t, []syscoinrpc.Hash{testHash}, pool)
+	require.Equal(t, int32(3), atomic.LoadInt32(&requests))
+
+	policy := testRetryPolicy
+	policy.RetryMutating = []string{"generate"}
+	cl, err = syscoinrpc.NewClient(srv.URL, "", "", syscoinrpc.WithRetryPolicy(policy))
+	require.NoError(t, err, "Must have no error on creation")
+
+	atomic.StoreInt32(&requests, 0)
+	hashes, err := cl.Generating.Generate(1, 0)
+	require.NoError(t, err, "Generate: must be retried when opted in")
+	require.Equal(t, []syscoinrpc.Hash{testHash}, hashes)
+	require.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
@@ -0,0 +1,484 @@
+package syscoinrpc
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Script types, as reported in ScriptPubKey.Type.
+const (
+	ScriptTypeNonStandard         = "nonstandard"
+	ScriptTypePubKey              = "pubkey"
+	ScriptTypePubKeyHash          = "pubkeyhash"
+	ScriptTypeScriptHash          = "scripthash"
+	ScriptTypeMultiSig            = "multisig"
+	ScriptTypeNullData            = "nulldata"
+	ScriptTypeWitnessV0KeyHash    = "witness_v0_keyhash"
+	ScriptTypeWitnessV0ScriptHash = "witness_v0_scripthash"
+	ScriptTypeWitnessUnknown      = "witness_unknown"
+)
+
+// Opcodes used to classify scripts.
+const (
+	opPushData1     byte = 0x4c
+	opPushData2     byte = 0x4d
+	opPushData4     byte = 0x4e
+	op1Negate       byte = 0x4f
+	op1             byte = 0x51
+	op16            byte = 0x60
+	opReturn        byte = 0x6a
+	opDup           byte = 0x76
+	opEqual         byte = 0x87
+	opEqualVerify   byte = 0x88
+	opHash160       byte = 0xa9
+	opCheckSig      byte = 0xac
+	opCheckMultiSig byte = 0xae
+)
+
+// ErrUnknownNetwork is returned when decoding data for a network other than
+// MainNet, TestNet or RegTest.
+var ErrUnknownNetwork = errors.New("Unknown network")
+
+// addressParams are the address encoding settings of a network.
+type addressParams struct {
+	pubKeyHash byte   // The version byte of P2PKH addresses.
+	scriptHash byte   // The version byte of P2SH addresses.
+	bech32HRP  string // The human readable part of segwit addresses.
+}
+
+// networkAddressParams are the address encoding settings of every network.
+var networkAddressParams = map[string]addressParams{
+	MainNet: {pubKeyHash: 63, scriptHash: 5, bech32HRP: "sys"},
+	TestNet: {pubKeyHash: 65, scriptHash: 196, bech32HRP: "tsys"},
+	RegTest: {pubKeyHash: 65, scriptHash: 196, bech32HRP: "scrt"},
+}
+
+// opNames are the names of the opcodes, as displayed in ASM scripts.
+var opNames = map[byte]string{
+	opPushData1: "OP_PUSHDATA1", opPushData2: "OP_PUSHDATA2", opPushData4: "OP_PUSHDATA4",
+	op1Negate: "-1", 0x50: "OP_RESERVED", 0x61: "OP_NOP", 0x62: "OP_VER", 0x63: "OP_IF",
+	0x64: "OP_NOTIF", 0x65: "OP_VERIF", 0x66: "OP_VERNOTIF", 0x67: "OP_ELSE", 0x68: "OP_ENDIF",
+	0x69: "OP_VERIFY", opReturn: "OP_RETURN", 0x6b: "OP_TOALTSTACK", 0x6c: "OP_FROMALTSTACK",
+	0x6d: "OP_2DROP", 0x6e: "OP_2DUP", 0x6f: "OP_3DUP", 0x70: "OP_2OVER", 0x71: "OP_2ROT",
+	0x72: "OP_2SWAP", 0x73: "OP_IFDUP", 0x74: "OP_DEPTH", 0x75: "OP_DROP", opDup: "OP_DUP",
+	0x77: "OP_NIP", 0x78: "OP_OVER", 0x79: "OP_PICK", 0x7a: "OP_ROLL", 0x7b: "OP_ROT",
+	0x7c: "OP_SWAP", 0x7d: "OP_TUCK", 0x7e: "OP_CAT", 0x7f: "OP_SUBSTR", 0x80: "OP_LEFT",
+	0x81: "OP_RIGHT", 0x82: "OP_SIZE", 0x83: "OP_INVERT", 0x84: "OP_AND", 0x85: "OP_OR",
+	0x86: "OP_XOR", opEqual: "OP_EQUAL", opEqualVerify: "OP_EQUALVERIFY", 0x89: "OP_RESERVED1",
+	0x8a: "OP_RESERVED2", 0x8b: "OP_1ADD", 0x8c: "OP_1SUB", 0x8d: "OP_2MUL", 0x8e: "OP_2DIV",
+	0x8f: "OP_NEGATE", 0x90: "OP_ABS", 0x91: "OP_NOT", 0x92: "OP_0NOTEQUAL", 0x93: "OP_ADD",
+	0x94: "OP_SUB", 0x95: "OP_MUL", 0x96: "OP_DIV", 0x97: "OP_MOD", 0x98: "OP_LSHIFT",
+	0x99: "OP_RSHIFT", 0x9a: "OP_BOOLAND", 0x9b: "OP_BOOLOR", 0x9c: "OP_NUMEQUAL",
+	0x9d: "OP_NUMEQUALVERIFY", 0x9e: "OP_NUMNOTEQUAL", 0x9f: "OP_LESSTHAN", 0xa0: "OP_GREATERTHAN",
+	0xa1: "OP_LESSTHANOREQUAL", 0xa2: "OP_GREATERTHANOREQUAL", 0xa3: "OP_MIN", 0xa4: "OP_MAX",
+	0xa5: "OP_WITHIN", 0xa6: "OP_RIPEMD160", 0xa7: "OP_SHA1", 0xa8: "OP_SHA256",
+	opHash160: "OP_HASH160", 0xaa: "OP_HASH256", 0xab: "OP_CODESEPARATOR", opCheckSig: "OP_CHECKSIG",
+	0xad: "OP_CHECKSIGVERIFY", opCheckMultiSig: "OP_CHECKMULTISIG", 0xaf: "OP_CHECKMULTISIGVERIFY",
+	0xb0: "OP_NOP1", 0xb1: "OP_CHECKLOCKTIMEVERIFY", 0xb2: "OP_CHECKSEQUENCEVERIFY",
+	0xb3: "OP_NOP4", 0xb4: "OP_NOP5", 0xb5: "OP_NOP6", 0xb6: "OP_NOP7", 0xb7: "OP_NOP8",
+	0xb8: "OP_NOP9", 0xb9: "OP_NOP10", 0xff: "OP_INVALIDOPCODE",
+}
+
+// sigHashTypes are the names of the signature hash types, as displayed in ASM scripts.
+var sigHashTypes = map[byte]string{
+	0x01: "ALL",
+	0x02: "NONE",
+	0x03: "SINGLE",
+	0x81: "ALL|ANYONECANPAY",
+	0x82: "NONE|ANYONECANPAY",
+	0x83: "SINGLE|ANYONECANPAY",
+}
+
+// scriptOp is an operation of a script.
+type scriptOp struct {
+	opcode byte   // The opcode.
+	data   []byte // The pushed data, for push opcodes.
+}
+
+// parseScript splits the script in operations, ok is false if the script is truncated.
+func parseScript(script []byte) (ops []scriptOp, ok bool) {
+	for i := 0; i < len(script); {
+		op := scriptOp{opcode: script[i]}
+		i++
+
+		var size int
+		switch {
+		case op.opcode < opPushData1:
+			size = int(op.opcode)
+		case op.opcode == opPushData1:
+			if i+1 > len(script) {
+				return ops, false
+			}
+			size = int(script[i])
+			i++
+		case op.opcode == opPushData2:
+			if i+2 > len(script) {
+				return ops, false
+			}
+			size = int(binary.LittleEndian.Uint16(script[i:]))
+			i += 2
+		case op.opcode == opPushData4:
+			if i+4 > len(script) {
+				return ops, false
+			}
+			size = int(binary.LittleEndian.Uint32(script[i:]))
+			i += 4
+		}
+		if size < 0 || size > len(script)-i {
+			return ops, false
+		}
+		op.data = script[i : i+size]
+		i += size
+
+		ops = append(ops, op)
+	}
+	return ops, true
+}
+
+// isPush returns true if the operation only pushes data on the stack.
+func (op scriptOp) isPush() bool {
+	return op.opcode <= op16
+}
+
+// smallInt returns the value of OP_0 and OP_1 to OP_16, -1 for other opcodes.
+func (op scriptOp) smallInt() int {
+	switch {
+	case op.opcode == 0:
+		return 0
+	case op.opcode >= op1 && op.opcode <= op16:
+		return int(op.opcode-op1) + 1
+	}
+	return -1
+}
+
+// scriptToAsm returns the ASM representation of the script, like the node does.
+// Signatures are displayed with their hash type if decodeSigHash is true.
+func scriptToAsm(script []byte, decodeSigHash bool) string {
+	unspendable := len(script) > 0 && script[0] == opReturn
+	ops, ok := parseScript(script)
+
+	words := make([]string, 0, len(ops)+1)
+	for _, op := range ops {
+		switch {
+		case op.opcode <= opPushData4 && len(op.data) <= 4:
+			words = append(words, strconv.FormatInt(scriptNumValue(op.data), 10))
+		case op.opcode <= opPushData4:
+			word := hex.EncodeToString(op.data)
+			if decodeSigHash && !unspendable && isValidSignatureEncoding(op.data) {
+				if name, ok := sigHashTypes[op.data[len(op.data)-1]]; ok {
+					word = hex.EncodeToString(op.data[:len(op.data)-1]) + "[" + name + "]"
+				}
+			}
+			words = append(words, word)
+		case op.opcode >= op1 && op.opcode <= op16:
+			words = append(words, strconv.Itoa(op.smallInt()))
+		default:
+			name, known := opNames[op.opcode]
+			if !known {
+				name = "OP_UNKNOWN"
+			}
+			words = append(words, name)
+		}
+	}
+	if !ok {
+		words = append(words, "[error]")
+	}
+	return strings.Join(words, " ")
+}
+
+// scriptNumValue decodes a little endian, sign and magnitude script number.
+func scriptNumValue(data []byte) int64 {
+	if len(data) == 0 {
+		return 0
+	}
+	var value int64
+	for i, b := range data {
+		value |= int64(b) << (8 * uint(i))
+	}
+	last := data[len(data)-1]
+	if last&0x80 != 0 {
+		return -(value &^ (int64(0x80) << (8 * uint(len(data)-1))))
+	}
+	return value
+}
+
+// isValidSignatureEncoding checks the strict DER encoding of a signature
+// followed by its hash type (BIP66).
+func isValidSignatureEncoding(sig []byte) bool {
+	if len(sig) < 9 || len(sig) > 73 {
+		return false
+	}
+	if sig[0] != 0x30 || int(sig[1]) != len(sig)-3 {
+		return false
+	}
+	lenR := int(sig[3])
+	if 5+lenR >= len(sig) {
+		return false
+	}
+	lenS := int(sig[5+lenR])
+	if lenR+lenS+7 != len(sig) {
+		return false
+	}
+	if sig[2] != 0x02 || lenR == 0 || sig[4]&0x80 != 0 {
+		return false
+	}
+	if lenR > 1 && sig[4] == 0x00 && sig[5]&0x80 == 0 {
+		return false
+	}
+	if sig[lenR+4] != 0x02 || lenS == 0 || sig[lenR+6]&0x80 != 0 {
+		return false
+	}
+	if lenS > 1 && sig[lenR+6] == 0x00 && sig[lenR+7]&0x80 == 0 {
+		return false
+	}
+	hashType := sig[len(sig)-1] &^ 0x80
+	return hashType >= 0x01 && hashType <= 0x03
+}
+
+// isValidPubKey checks the size of a serialized public key against its header.
+func isValidPubKey(pubKey []byte) bool {
+	if len(pubKey) == 0 {
+		return false
+	}
+	switch pubKey[0] {
+	case 0x02, 0x03:
+		return len(pubKey) == 33
+	case 0x04, 0x06, 0x07:
+		return len(pubKey) == 65
+	}
+	return false
+}
+
+// decodeScriptPubKey returns the decoded output script, with the addresses
+// encoded for the given network.
+func decodeScriptPubKey(script []byte, params addressParams) ScriptPubKey {
+	spk := ScriptPubKey{
+		Asm:  scriptToAsm(script, false),
+		Hex:  hex.EncodeToString(script),
+		Type: ScriptTypeNonStandard,
+	}
+	ops, ok := parseScript(script)
+
+	switch {
+	// P2SH: OP_HASH160 <20 bytes> OP_EQUAL
+	case len(script) == 23 && script[0] == opHash160 && script[1] == 20 && script[22] == opEqual:
+		spk.Type = ScriptTypeScriptHash
+		spk.Addresses = []string{base58CheckEncode(params.scriptHash, script[2:22])}
+
+	// Witness program: <version> <2 to 40 bytes>
+	case len(script) >= 4 && len(script) <= 42 && (script[0] == 0 || (script[0] >= op1 && script[0] <= op16)) && int(script[1])+2 == len(script):
+		version := ops[0].smallInt()
+		program := script[2:]
+		switch {
+		case version == 0 && len(program) == 20:
+			spk.Type = ScriptTypeWitnessV0KeyHash
+		case version == 0 && len(program) == 32:
+			spk.Type = ScriptTypeWitnessV0ScriptHash
+		case version == 0:
+			return spk
+		default:
+			spk.Type = ScriptTypeWitnessUnknown
+		}
+		spk.Addresses = []string{bech32Encode(params.bech32HRP, byte(version), program)}
+
+	// Null data: OP_RETURN followed by pushes only.
+	case len(script) >= 1 && script[0] == opReturn && ok && isPushOnly(ops[1:]):
+		spk.Type = ScriptTypeNullData
+		return spk
+
+	// P2PK: <pubkey> OP_CHECKSIG
+	case ok && len(ops) == 2 && isValidPubKey(ops[0].data) && ops[1].opcode == opCheckSig:
+		spk.Type = ScriptTypePubKey
+		spk.Addresses = []string{base58CheckEncode(params.pubKeyHash, hash160(ops[0].data))}
+
+	// P2PKH: OP_DUP OP_HASH160 <20 bytes> OP_EQUALVERIFY OP_CHECKSIG
+	case len(script) == 25 && script[0] == opDup && script[1] == opHash160 && script[2] == 20 && script[23] == opEqualVerify && script[24] == opCheckSig:
+		spk.Type = ScriptTypePubKeyHash
+		spk.Addresses = []string{base58CheckEncode(params.pubKeyHash, script[3:23])}
+
+	// Multisig: <m> <pubkey>... <n> OP_CHECKMULTISIG
+	case ok && len(ops) >= 4 && ops[len(ops)-1].opcode == opCheckMultiSig:
+		m, n := ops[0].smallInt(), ops[len(ops)-2].smallInt()
+		pubKeys := ops[1 : len(ops)-2]
+		if m < 1 || n < m || n != len(pubKeys) {
+			return spk
+		}
+		addresses := make([]string, 0, n)
+		for _, pubKey := range pubKeys {
+			if !isValidPubKey(pubKey.data) {
+				return spk
+			}
+			addresses = append(addresses, base58CheckEncode(params.pubKeyHash, hash160(pubKey.data)))
+		}
+		spk.Type = ScriptTypeMultiSig
+		spk.RequiredSignatures = uint64(m)
+		spk.Addresses = addresses
+		return spk
+
+	default:
+		return spk
+	}
+
+	spk.RequiredSignatures = 1
+	return spk
+}
+
+// decodeScript returns the decoded script like `decodescript` does, with the
+// P2SH address of the script and its segwit versions when it can be wrapped.
+func decodeScript(script []byte, params addressParams) *DecodedScript {
+	spk := decodeScriptPubKey(script, params)
+	ds := &DecodedScript{
+		Asm:                spk.Asm,
+		Type:               spk.Type,
+		RequiredSignatures: spk.RequiredSignatures,
+		Addresses:          spk.Addresses,
+	}
+	// P2SH cannot be wrapped in P2SH.
+	if spk.Type == ScriptTypeScriptHash {
+		return ds
+	}
+	ds.P2SH = base58CheckEncode(params.scriptHash, hash160(script))
+
+	// Witness programs cannot be wrapped in P2WSH, nor can uncompressed public keys.
+	var segwitScript []byte
+	ops, _ := parseScript(script)
+	switch spk.Type {
+	case ScriptTypePubKey:
+		if len(ops[0].data) != 33 {
+			return ds
+		}
+		segwitScript = append([]byte{0, 20}, hash160(ops[0].data)...)
+	case ScriptTypePubKeyHash:
+		segwitScript = append([]byte{0, 20}, script[3:23]...)
+	case ScriptTypeMultiSig:
+		for _, op := range ops[1 : len(ops)-2] {
+			if len(op.data) != 33 {
+				return ds
+			}
+		}
+		fallthrough
+	case ScriptTypeNonStandard:
+		witnessScriptHash := sha256.Sum256(script)
+		segwitScript = append([]byte{0, 32}, witnessScriptHash[:]...)
+	default:
+		return ds
+	}
+	segwit := decodeScriptPubKey(segwitScript, params)
+	ds.Segwit = &DecodedSegwitScript{
+		ScriptPubKey: segwit,
+		P2SHSegwit:   base58CheckEncode(params.scriptHash, hash160(segwitScript)),
+	}
+	return ds
+}
+
+func isPushOnly(ops []scriptOp) bool {
+	for _, op := range ops {
+		if !op.isPush() {
+			return false
+		}
+	}
+	return true
+}
+
+func hash160(data []byte) []byte {
+	sha := sha256.Sum256(data)
+	digest := ripemd160Sum(sha[:])
+	return digest[:]
+}
+
+// base58Alphabet is the alphabet of base58 encoded addresses.
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58CheckEncode encodes the payload with its version byte and checksum.
+func base58CheckEncode(version byte, payload []byte) string {
+	data := append([]byte{version}, payload...)
+	checksum := doubleSHA256(data)
+	data = append(data, checksum[:4]...)
+
+	value := new(big.Int).SetBytes(data)
+	base, mod := big.NewInt(58), new(big.Int)
+	var encoded []byte
+	for value.Sign() > 0 {
+		value.DivMod(value, base, mod)
+		encoded = append(encoded, base58Alphabet[mod.Int64()])
+	}
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		encoded = append(encoded, base58Alphabet[0])
+	}
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+	return string(encoded)
+}
+
+// bech32Charset is the alphabet of bech32 encoded addresses.
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// bech32Encode encodes a segwit address (BIP173).
+func bech32Encode(hrp string, version byte, program []byte) string {
+	data := []byte{version}
+	// Convert the program from 8 to 5 bits groups.
+	var acc, bits uint
+	for _, b := range program {
+		acc = acc<<8 | uint(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			data = append(data, byte(acc>>bits&31))
+		}
+	}
+	if bits > 0 {
+		data = append(data, byte(acc<<(5-bits)&31))
+	}
+
+	values := make([]byte, 0, 2*len(hrp)+1+len(data)+6)
+	for i := 0; i < len(hrp); i++ {
+		values = append(values, hrp[i]>>5)
+	}
+	values = append(values, 0)
+	for i := 0; i < len(hrp); i++ {
+		values = append(values, hrp[i]&31)
+	}
+	values = append(values, data...)
+	values = append(values, 0, 0, 0, 0, 0, 0)
+	polymod := bech32Polymod(values) ^ 1
+
+	var sb strings.Builder
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, d := range data {
+		sb.WriteByte(bech32Charset[d])
+	}
+	for i := 0; i < 6; i++ {
+		sb.WriteByte(bech32Charset[polymod>>(5*uint(5-i))&31])
+	}
+	return sb.String()
+}
+
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+func doubleSHA256(data []byte) Hash {
+	first := sha256.Sum256(data)
+	return sha256.Sum256(first[:])
+}
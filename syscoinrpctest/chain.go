@@ -0,0 +1,310 @@
+package syscoinrpctest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+)
+
+const (
+	// GenesisTime is the time of the genesis block of the fake chain.
+	GenesisTime uint32 = 1525175468
+	// BlockInterval is the time elapsed between two blocks of the fake chain, in seconds.
+	BlockInterval uint32 = 60
+	// BlockSubsidy is the reward of every block of the fake chain, in satoshis.
+	BlockSubsidy int64 = 50 * 100000000
+	// DefaultAddress is the address the blocks mined by `generate` are paid to.
+	DefaultAddress = "SaaxXq67HhzPbsKNNJBQeQK5qf5Hpv8qq2"
+	// DefaultPrivKey is the private key of DefaultAddress, the only key of the fake
+	// chain, accepted by `signmessagewithprivkey`.
+	DefaultPrivKey = "KwntMbt59tTsj8xqpqYqRRWufyjGunvhSyeMo3NTYpFYzZbXJ5Hp"
+	// AuxPowChainID is the chain ID of the merge mined blocks of the fake chain.
+	AuxPowChainID uint32 = 0x1000
+
+	blockVersion uint32 = 0x20000000
+	// auxBlockVersion is the version of the merge mined blocks.
+	auxBlockVersion = blockVersion | 1<<8 | AuxPowChainID<<16
+	// txFee is the fee of every mempool transaction, in satoshis.
+	txFee       int64  = 10000
+	regtestBits uint32 = 0x207fffff
+	// regtestDifficulty is the difficulty matching regtestBits.
+	regtestDifficulty = 4.656542373906925e-10
+)
+
+func doubleSHA256(data []byte) syscoinrpc.Hash {
+	first := sha256.Sum256(data)
+	return sha256.Sum256(first[:])
+}
+
+// block is a block of the fake chain.
+type block struct {
+	height  uint64            // The height of the block.
+	hash    syscoinrpc.Hash   // The hash of the header.
+	prev    syscoinrpc.Hash   // The hash of the previous block.
+	header  []byte            // The serialized 80 bytes header.
+	auxPow  []byte            // The serialized AuxPoW following the header of merge mined blocks, nil for others.
+	txs     [][]byte          // The serialized transactions, coinbase first.
+	txIDs   []syscoinrpc.Hash // The IDs of the transactions.
+	version uint32            // The block version.
+	time    uint32            // The block time.
+	nonce   uint32            // The nonce, distinguishing blocks mined at the same height.
+}
+
+// serialize returns the serialized block.
+func (b *block) serialize() []byte {
+	var buf bytes.Buffer
+	buf.Write(b.header)
+	buf.Write(b.auxPow)
+	writeVarInt(&buf, uint64(len(b.txs)))
+	for _, tx := range b.txs {
+		buf.Write(tx)
+	}
+	return buf.Bytes()
+}
+
+// newBlock builds the block at height on top of prev, including txs after the coinbase.
+func newBlock(height uint64, prev syscoinrpc.Hash, version uint32, nonce uint32, address string, txs [][]byte) *block {
+	b := &block{
+		height:  height,
+		prev:    prev,
+		version: version,
+		time:    GenesisTime + uint32(height)*BlockInterval,
+		nonce:   nonce,
+	}
+
+	b.txs = append([][]byte{coinbaseTx(height, address)}, txs...)
+	for _, tx := range b.txs {
+		b.txIDs = append(b.txIDs, doubleSHA256(tx))
+	}
+	merkleRoot := merkleRoot(b.txIDs)
+
+	var header bytes.Buffer
+	binary.Write(&header, binary.LittleEndian, version)
+	header.Write(prev[:])
+	header.Write(merkleRoot[:])
+	binary.Write(&header, binary.LittleEndian, b.time)
+	binary.Write(&header, binary.LittleEndian, regtestBits)
+	binary.Write(&header, binary.LittleEndian, nonce)
+	b.header = header.Bytes()
+	b.hash = doubleSHA256(b.header)
+
+	return b
+}
+
+// coinbaseTx builds the coinbase transaction of the block at height,
+// paying the block subsidy to an OP_RETURN output tagged with the address.
+func coinbaseTx(height uint64, address string) []byte {
+	var heightPush bytes.Buffer
+	heightBytes := scriptNum(height)
+	heightPush.WriteByte(byte(len(heightBytes)))
+	heightPush.Write(heightBytes)
+
+	var tx bytes.Buffer
+	binary.Write(&tx, binary.LittleEndian, uint32(1)) // version
+	writeVarInt(&tx, 1)
+	tx.Write(make([]byte, 32))
+	binary.Write(&tx, binary.LittleEndian, uint32(0xffffffff))
+	writeVarInt(&tx, uint64(heightPush.Len()))
+	tx.Write(heightPush.Bytes())
+	binary.Write(&tx, binary.LittleEndian, uint32(0xffffffff)) // sequence
+	writeVarInt(&tx, 1)
+	binary.Write(&tx, binary.LittleEndian, BlockSubsidy)
+	script := addressScript(address)
+	writeVarInt(&tx, uint64(len(script)))
+	tx.Write(script)
+	binary.Write(&tx, binary.LittleEndian, uint32(0)) // locktime
+	return tx.Bytes()
+}
+
+// addressScript returns the output script paying to the address, an OP_RETURN
+// output tagged with the address as the fake chain has no keys.
+func addressScript(address string) []byte {
+	return append([]byte{0x6a, byte(len(address))}, address...) // OP_RETURN <address>
+}
+
+// scriptNum encodes n as a minimal script number (BIP34).
+func scriptNum(n uint64) []byte {
+	if n == 0 {
+		return []byte{0}
+	}
+	var out []byte
+	for n > 0 {
+		out = append(out, byte(n&0xff))
+		n >>= 8
+	}
+	if out[len(out)-1]&0x80 != 0 {
+		out = append(out, 0)
+	}
+	return out
+}
+
+// merkleRoot computes the merkle root of the transaction IDs.
+func merkleRoot(txIDs []syscoinrpc.Hash) syscoinrpc.Hash {
+	level := append([]syscoinrpc.Hash(nil), txIDs...)
+	for len(level) > 1 {
+		if len(level)%2 == 1 {
+			level = append(level, level[len(level)-1])
+		}
+		next := make([]syscoinrpc.Hash, 0, len(level)/2)
+		for i := 0; i < len(level); i += 2 {
+			next = append(next, doubleSHA256(append(level[i][:], level[i+1][:]...)))
+		}
+		level = next
+	}
+	return level[0]
+}
+
+// merkleProof builds the merkle block proving that the matched transactions
+// are included in the block, as `gettxoutproof` does.
+func merkleProof(b *block, matched map[syscoinrpc.Hash]bool) []byte {
+	total := uint64(len(b.txIDs))
+	width := func(height uint) uint64 {
+		return (total + (1 << height) - 1) >> height
+	}
+	var nodeHash func(height uint, pos uint64) syscoinrpc.Hash
+	nodeHash = func(height uint, pos uint64) syscoinrpc.Hash {
+		if height == 0 {
+			return b.txIDs[pos]
+		}
+		left := nodeHash(height-1, pos*2)
+		right := left
+		if pos*2+1 < width(height-1) {
+			right = nodeHash(height-1, pos*2+1)
+		}
+		return doubleSHA256(append(left[:], right[:]...))
+	}
+
+	var hashes []syscoinrpc.Hash
+	var bits []bool
+	var build func(height uint, pos uint64)
+	build = func(height uint, pos uint64) {
+		parentOfMatch := false
+		for i := pos << height; i < (pos+1)<<height && i < total; i++ {
+			parentOfMatch = parentOfMatch || matched[b.txIDs[i]]
+		}
+		bits = append(bits, parentOfMatch)
+		if height == 0 || !parentOfMatch {
+			hashes = append(hashes, nodeHash(height, pos))
+			return
+		}
+		build(height-1, pos*2)
+		if pos*2+1 < width(height-1) {
+			build(height-1, pos*2+1)
+		}
+	}
+	height := uint(0)
+	for width(height) > 1 {
+		height++
+	}
+	build(height, 0)
+
+	var buf bytes.Buffer
+	buf.Write(b.header)
+	binary.Write(&buf, binary.LittleEndian, uint32(total))
+	writeVarInt(&buf, uint64(len(hashes)))
+	for _, hash := range hashes {
+		buf.Write(hash[:])
+	}
+	flags := make([]byte, (len(bits)+7)/8)
+	for i, bit := range bits {
+		if bit {
+			flags[i/8] |= 1 << uint(i%8)
+		}
+	}
+	writeVarInt(&buf, uint64(len(flags)))
+	buf.Write(flags)
+	return buf.Bytes()
+}
+
+func writeVarInt(buf *bytes.Buffer, n uint64) {
+	switch {
+	case n < 0xfd:
+		buf.WriteByte(byte(n))
+	case n <= 0xffff:
+		buf.WriteByte(0xfd)
+		binary.Write(buf, binary.LittleEndian, uint16(n))
+	case n <= 0xffffffff:
+		buf.WriteByte(0xfe)
+		binary.Write(buf, binary.LittleEndian, uint32(n))
+	default:
+		buf.WriteByte(0xff)
+		binary.Write(buf, binary.LittleEndian, n)
+	}
+}
+
+// chainWork returns the chainwork of the block at height, in hex.
+func chainWork(height uint64) string {
+	// Every regtest block has a work of 2.
+	return fmt.Sprintf("%064x", (height+1)*2)
+}
+
+// medianTime returns the median time of the last 11 blocks up to b.
+func (s *Server) medianTime(b *block) uint32 {
+	var times []uint32
+	for len(times) < 11 {
+		times = append(times, b.time)
+		if b.height == 0 {
+			break
+		}
+		b = s.byHash[b.prev.String()]
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+	return times[len(times)/2]
+}
+
+// isActive returns true if the block is in the active chain.
+func (s *Server) isActive(b *block) bool {
+	return b.height < uint64(len(s.chain)) && s.chain[b.height] == b
+}
+
+// headerJSON returns the verbose `getblockheader` representation of the block.
+func (s *Server) headerJSON(b *block) map[string]interface{} {
+	tip := uint64(len(s.chain) - 1)
+	confirmations := int64(-1)
+	if s.isActive(b) {
+		confirmations = int64(tip - b.height + 1)
+	}
+	merkleRoot := merkleRoot(b.txIDs)
+	res := map[string]interface{}{
+		"hash":          b.hash.String(),
+		"confirmations": confirmations,
+		"height":        b.height,
+		"version":       b.version,
+		"versionHex":    fmt.Sprintf("%08x", b.version),
+		"merkleroot":    merkleRoot.String(),
+		"time":          b.time,
+		"mediantime":    s.medianTime(b),
+		"nonce":         b.nonce,
+		"bits":          fmt.Sprintf("%08x", regtestBits),
+		"difficulty":    regtestDifficulty,
+		"chainwork":     chainWork(b.height),
+		"nTx":           len(b.txs),
+	}
+	if b.height > 0 {
+		res["previousblockhash"] = b.prev.String()
+	}
+	if s.isActive(b) && b.height < tip {
+		res["nextblockhash"] = s.chain[b.height+1].hash.String()
+	}
+	return res
+}
+
+// blockJSON returns the verbose `getblock` representation of the block.
+func (s *Server) blockJSON(b *block) map[string]interface{} {
+	res := s.headerJSON(b)
+	txIDs := make([]string, 0, len(b.txIDs))
+	for _, txID := range b.txIDs {
+		txIDs = append(txIDs, txID.String())
+	}
+	res["tx"] = txIDs
+	res["size"] = len(b.serialize())
+	if b.auxPow != nil {
+		auxPow, _ := syscoinrpc.DecodeAuxPow(b.auxPow, syscoinrpc.RegTest)
+		res["auxpow"] = auxPow
+	}
+	return res
+}
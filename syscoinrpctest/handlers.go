@@ -0,0 +1,821 @@
+package syscoinrpctest
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+)
+
+// handler performs a call with the server lock held.
+type handler func(s *Server, p params) (interface{}, *syscoinrpc.RPCError)
+
+// handlers are the supported methods.
+var handlers map[string]handler
+
+func init() {
+	handlers = map[string]handler{
+		"getbestblockhash":       getBestBlockHash,
+		"getblockcount":          getBlockCount,
+		"getblockhash":           getBlockHash,
+		"getblock":               getBlock,
+		"getblockchaininfo":      getBlockchainInfo,
+		"getblockheader":         getBlockHeader,
+		"getblockstats":          getBlockStats,
+		"getchaintips":           getChainTips,
+		"getchaintxstats":        getChainTxStats,
+		"getdifficulty":          getDifficulty,
+		"getmempoolinfo":         getMempoolInfo,
+		"getrawmempool":          getRawMempool,
+		"gettxout":               getTxOut,
+		"gettxoutproof":          getTxOutProof,
+		"gettxoutsetinfo":        getTxOutSetInfo,
+		"verifychain":            verifyChain,
+		"verifytxoutproof":       verifyTxOutProof,
+		"generate":               generate,
+		"generatetoaddress":      generateToAddress,
+		"getmininginfo":          getMiningInfo,
+		"getnetworkhashps":       getNetworkHashPS,
+		"getblocktemplate":       getBlockTemplate,
+		"submitblock":            submitBlock,
+		"prioritisetransaction":  prioritiseTransaction,
+		"createauxblock":         createAuxBlock,
+		"getauxblock":            getAuxBlock,
+		"submitauxblock":         submitAuxBlock,
+		"addnode":                addNode,
+		"clearbanned":            clearBanned,
+		"disconnectnode":         disconnectNode,
+		"getaddednodeinfo":       getAddedNodeInfo,
+		"getconnectioncount":     getConnectionCount,
+		"getnettotals":           getNetTotals,
+		"getnetworkinfo":         getNetworkInfo,
+		"getpeerinfo":            getPeerInfo,
+		"listbanned":             listBanned,
+		"ping":                   ping,
+		"setban":                 setBan,
+		"setnetworkactive":       setNetworkActive,
+		"createrawtransaction":   createRawTransaction,
+		"decoderawtransaction":   decodeRawTransaction,
+		"decodescript":           decodeScript,
+		"fundrawtransaction":     fundRawTransaction,
+		"getrawtransaction":      getRawTransaction,
+		"sendrawtransaction":     sendRawTransaction,
+		"signrawtransaction":     signRawTransaction,
+		"createmultisig":         createMultisig,
+		"estimatefee":            estimateFee,
+		"estimatesmartfee":       estimateSmartFee,
+		"estimatepriority":       estimatePriority,
+		"validateaddress":        validateAddress,
+		"verifymessage":          verifyMessage,
+		"signmessagewithprivkey": signMessageWithPrivKey,
+		"getmemoryinfo":          getMemoryInfo,
+		"help":                   help,
+		"uptime":                 uptime,
+		"logging":                logging,
+		"stop":                   stop,
+	}
+}
+
+// errInvalidParams is returned on missing or malformed params.
+var errInvalidParams = &syscoinrpc.RPCError{Code: syscoinrpc.RPCMiscError, Message: "Invalid parameters"}
+
+// params are the params of a call.
+type params []json.RawMessage
+
+func (p params) has(i int) bool {
+	return i < len(p) && string(p[i]) != "null"
+}
+
+func (p params) string(i int) (string, *syscoinrpc.RPCError) {
+	var v string
+	if !p.has(i) || json.Unmarshal(p[i], &v) != nil {
+		return "", errInvalidParams
+	}
+	return v, nil
+}
+
+func (p params) strings(i int) ([]string, *syscoinrpc.RPCError) {
+	var v []string
+	if !p.has(i) || json.Unmarshal(p[i], &v) != nil {
+		return nil, errInvalidParams
+	}
+	return v, nil
+}
+
+func (p params) uint64(i int, def uint64) (uint64, *syscoinrpc.RPCError) {
+	if !p.has(i) {
+		return def, nil
+	}
+	var v uint64
+	if json.Unmarshal(p[i], &v) != nil {
+		return 0, &syscoinrpc.RPCError{Code: syscoinrpc.RPCTypeError, Message: "Expected type number"}
+	}
+	return v, nil
+}
+
+func (p params) int64(i int, def int64) (int64, *syscoinrpc.RPCError) {
+	if !p.has(i) {
+		return def, nil
+	}
+	var v int64
+	if json.Unmarshal(p[i], &v) != nil {
+		return 0, &syscoinrpc.RPCError{Code: syscoinrpc.RPCTypeError, Message: "Expected type number"}
+	}
+	return v, nil
+}
+
+func (p params) bool(i int) (bool, *syscoinrpc.RPCError) {
+	var v bool
+	if !p.has(i) || json.Unmarshal(p[i], &v) != nil {
+		return false, &syscoinrpc.RPCError{Code: syscoinrpc.RPCTypeError, Message: "Expected type bool"}
+	}
+	return v, nil
+}
+
+// verbosity accepts both booleans and numbers, like the node does.
+func (p params) verbosity(i int, def uint64) (uint64, *syscoinrpc.RPCError) {
+	if !p.has(i) {
+		return def, nil
+	}
+	var b bool
+	if json.Unmarshal(p[i], &b) == nil {
+		if b {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	return p.uint64(i, def)
+}
+
+func getBestBlockHash(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	return s.chain[len(s.chain)-1].hash.String(), nil
+}
+
+func getBlockCount(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	return len(s.chain) - 1, nil
+}
+
+func getBlockHash(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	if !p.has(0) {
+		return nil, errInvalidParams
+	}
+	height, err := p.uint64(0, 0)
+	if err != nil {
+		return nil, err
+	}
+	if height >= uint64(len(s.chain)) {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParameter, Message: "Block height out of range"}
+	}
+	return s.chain[height].hash.String(), nil
+}
+
+func getBlock(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	hash, err := p.string(0)
+	if err != nil {
+		return nil, err
+	}
+	verbosity, err := p.verbosity(1, 1)
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.blockByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	switch verbosity {
+	case 0:
+		return hex.EncodeToString(b.serialize()), nil
+	case 1:
+		return s.blockJSON(b), nil
+	}
+	decoded, decodeErr := syscoinrpc.DecodeBlock(b.serialize(), syscoinrpc.RegTest)
+	if decodeErr != nil {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCDeserializationError, Message: decodeErr.Error()}
+	}
+	res := s.blockJSON(b)
+	res["tx"] = decoded.Tx
+	return res, nil
+}
+
+func getBlockHeader(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	hash, err := p.string(0)
+	if err != nil {
+		return nil, err
+	}
+	verbosity, err := p.verbosity(1, 1)
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.blockByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	if verbosity == 0 {
+		return hex.EncodeToString(b.header), nil
+	}
+	return s.headerJSON(b), nil
+}
+
+func getBlockchainInfo(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	tip := s.chain[len(s.chain)-1]
+	return map[string]interface{}{
+		"chain":                "regtest",
+		"blocks":               tip.height,
+		"headers":              tip.height,
+		"bestblockhash":        tip.hash.String(),
+		"difficulty":           regtestDifficulty,
+		"mediantime":           s.medianTime(tip),
+		"verificationprogress": 1,
+		"chainwork":            chainWork(tip.height),
+		"pruned":               false,
+		"softforks":            []interface{}{},
+		"bip9_softforks":       map[string]interface{}{},
+	}, nil
+}
+
+// getBlockStats computes the statistics of the block from its transactions,
+// the fee of every transaction of the fake chain is txFee.
+func getBlockStats(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	hash, err := p.string(0)
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.blockByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	var fees, feeRates, sizes []int64
+	ins, outs, utxoIncrease, utxoSizeIncrease := 0, 0, 0, 0
+	var totalOut int64
+	for i, raw := range b.txs {
+		tx, decodeErr := syscoinrpc.DecodeTransaction(raw, syscoinrpc.RegTest)
+		if decodeErr != nil {
+			return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCDeserializationError, Message: decodeErr.Error()}
+		}
+		for _, vout := range tx.Vout {
+			utxoIncrease++
+			utxoSizeIncrease += utxoSize(vout)
+		}
+		if i == 0 {
+			continue // The coinbase is excluded from the statistics.
+		}
+		for _, vin := range tx.Vin {
+			utxoIncrease--
+			if prevRaw, _ := s.findTransaction(vin.TxID.String()); prevRaw != nil {
+				prev, _ := syscoinrpc.DecodeTransaction(prevRaw, syscoinrpc.RegTest)
+				if prev != nil && int(vin.Vout) < len(prev.Vout) {
+					utxoSizeIncrease -= utxoSize(prev.Vout[vin.Vout])
+				}
+			}
+		}
+		ins += len(tx.Vin)
+		outs += len(tx.Vout)
+		for _, vout := range tx.Vout {
+			totalOut += vout.Value.Satoshis()
+		}
+		fees = append(fees, txFee)
+		feeRates = append(feeRates, txFee/int64(len(raw)))
+		sizes = append(sizes, int64(len(raw)))
+	}
+
+	minFee, maxFee, avgFee, medianFee := summarize(fees)
+	minFeeRate, maxFeeRate, avgFeeRate, _ := summarize(feeRates)
+	minSize, maxSize, avgSize, medianSize := summarize(sizes)
+	percentiles := make([]int64, 0, 5)
+	for _, percent := range []int{10, 25, 50, 75, 90} {
+		if len(feeRates) == 0 {
+			percentiles = append(percentiles, 0)
+			continue
+		}
+		percentiles = append(percentiles, feeRates[(len(feeRates)-1)*percent/100])
+	}
+	totalSize := int64(0)
+	for _, size := range sizes {
+		totalSize += size
+	}
+
+	return map[string]interface{}{
+		"avgfee":              avgFee,
+		"avgfeerate":          avgFeeRate,
+		"avgtxsize":           avgSize,
+		"blockhash":           b.hash.String(),
+		"feerate_percentiles": percentiles,
+		"height":              b.height,
+		"ins":                 ins,
+		"maxfee":              maxFee,
+		"maxfeerate":          maxFeeRate,
+		"maxtxsize":           maxSize,
+		"medianfee":           medianFee,
+		"mediantime":          s.medianTime(b),
+		"mediantxsize":        medianSize,
+		"minfee":              minFee,
+		"minfeerate":          minFeeRate,
+		"mintxsize":           minSize,
+		"outs":                outs,
+		"subsidy":             BlockSubsidy,
+		"swtotal_size":        0,
+		"swtotal_weight":      0,
+		"swtxs":               0,
+		"time":                b.time,
+		"total_out":           totalOut,
+		"total_size":          totalSize,
+		"total_weight":        totalSize * 4,
+		"totalfee":            txFee * int64(len(fees)),
+		"txs":                 len(fees),
+		"utxo_increase":       utxoIncrease,
+		"utxo_size_inc":       utxoSizeIncrease,
+	}, nil
+}
+
+// utxoSize returns the size of the output in the UTXO set, like `getblockstats` counts it.
+func utxoSize(vout syscoinrpc.VoutObject) int {
+	const perUTXOOverhead = 41 // The outpoint, height and coinbase flag.
+	script := len(vout.ScriptPubKey.Hex) / 2
+	var varInt bytes.Buffer
+	writeVarInt(&varInt, uint64(script))
+	return 8 + varInt.Len() + script + perUTXOOverhead
+}
+
+// summarize returns the minimum, maximum, average and truncated median of the
+// values, which are sorted in place. It returns zeros if there are no values.
+func summarize(values []int64) (low, high, avg, median int64) {
+	if len(values) == 0 {
+		return 0, 0, 0, 0
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	sum := int64(0)
+	for _, v := range values {
+		sum += v
+	}
+	median = values[len(values)/2]
+	if len(values)%2 == 0 {
+		median = (values[len(values)/2-1] + median) / 2
+	}
+	return values[0], values[len(values)-1], sum / int64(len(values)), median
+}
+
+func getChainTips(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	tip := s.chain[len(s.chain)-1]
+	tips := []map[string]interface{}{
+		{
+			"height":    tip.height,
+			"hash":      tip.hash.String(),
+			"branchlen": 0,
+			"status":    "active",
+		},
+	}
+
+	// The fork tips are the inactive blocks without children.
+	hasChildren := make(map[syscoinrpc.Hash]bool, len(s.byHash))
+	for _, b := range s.byHash {
+		hasChildren[b.prev] = true
+	}
+	var forks []*block
+	for _, b := range s.byHash {
+		if !s.isActive(b) && !hasChildren[b.hash] {
+			forks = append(forks, b)
+		}
+	}
+	sort.Slice(forks, func(i, j int) bool { return forks[i].height > forks[j].height })
+	for _, b := range forks {
+		forkPoint := b
+		for !s.isActive(forkPoint) {
+			forkPoint = s.byHash[forkPoint.prev.String()]
+		}
+		tips = append(tips, map[string]interface{}{
+			"height":    b.height,
+			"hash":      b.hash.String(),
+			"branchlen": b.height - forkPoint.height,
+			"status":    "valid-fork",
+		})
+	}
+	return tips, nil
+}
+
+func getChainTxStats(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	b := s.chain[len(s.chain)-1]
+	if p.has(1) {
+		hash, err := p.string(1)
+		if err != nil {
+			return nil, err
+		}
+		b, err = s.blockByHash(hash)
+		if err != nil {
+			return nil, err
+		}
+		if !s.isActive(b) {
+			return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParameter, Message: "Block is not in main chain"}
+		}
+	}
+
+	// The default window is one month of blocks.
+	nBlocks := uint64(30 * 24 * 60 * 60 / BlockInterval)
+	if b.height <= nBlocks {
+		nBlocks = 0
+		if b.height > 0 {
+			nBlocks = b.height - 1
+		}
+	}
+	if p.has(0) {
+		var err *syscoinrpc.RPCError
+		nBlocks, err = p.uint64(0, 0)
+		if err != nil {
+			return nil, err
+		}
+		if nBlocks > 0 && nBlocks >= b.height {
+			return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParameter, Message: "Invalid block count: should be between 0 and the block's height - 1"}
+		}
+	}
+
+	txCount := make([]int, b.height+1)
+	for height := range txCount {
+		txCount[height] = len(s.chain[height].txs)
+		if height > 0 {
+			txCount[height] += txCount[height-1]
+		}
+	}
+	res := map[string]interface{}{
+		"time":                    b.time,
+		"txcount":                 txCount[b.height],
+		"window_final_block_hash": b.hash.String(),
+		"window_block_count":      nBlocks,
+	}
+	if nBlocks > 0 {
+		start := s.chain[b.height-nBlocks]
+		windowTxCount := txCount[b.height] - txCount[start.height]
+		interval := b.time - start.time
+		res["window_tx_count"] = windowTxCount
+		res["window_interval"] = interval
+		if interval > 0 {
+			res["txrate"] = float64(windowTxCount) / float64(interval)
+		}
+	}
+	return res, nil
+}
+
+func getDifficulty(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	return regtestDifficulty, nil
+}
+
+func getRawMempool(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	verbosity, err := p.verbosity(0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	txIDs := make([]string, 0, len(s.mempool))
+	for txID := range s.mempool {
+		txIDs = append(txIDs, txID)
+	}
+	sort.Strings(txIDs)
+	if verbosity == 0 {
+		return txIDs, nil
+	}
+
+	height := len(s.chain) - 1
+	entries := make(map[string]interface{}, len(txIDs))
+	for _, txID := range txIDs {
+		size := len(s.mempool[txID])
+		modifiedFee := txFee + s.feeDeltas[txID]
+		entries[txID] = map[string]interface{}{
+			"size":             size,
+			"fee":              syscoinrpc.Amount(txFee),
+			"modifiedfee":      syscoinrpc.Amount(modifiedFee),
+			"time":             s.mempoolAt[txID].Unix(),
+			"height":           height,
+			"descendantcount":  1,
+			"descendantsize":   size,
+			"descendantfees":   modifiedFee,
+			"ancestorcount":    1,
+			"ancestorsize":     size,
+			"ancestorfees":     modifiedFee,
+			"depends":          []string{},
+			"instantsend":      false,
+			"instantlock":      false,
+			"startingpriority": 0,
+			"currentpriority":  0,
+		}
+	}
+	return entries, nil
+}
+
+func getMempoolInfo(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	size := 0
+	for _, tx := range s.mempool {
+		size += len(tx)
+	}
+	return map[string]interface{}{
+		"size":          len(s.mempool),
+		"bytes":         size,
+		"usage":         size,
+		"maxmempool":    300000000,
+		"mempoolminfee": syscoinrpc.Amount(1000),
+	}, nil
+}
+
+// getTxOut returns null for spent or unknown outputs, like the node does.
+func getTxOut(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	txID, err := p.string(0)
+	if err != nil {
+		return nil, err
+	}
+	if !p.has(1) {
+		return nil, errInvalidParams
+	}
+	n, err := p.uint64(1, 0)
+	if err != nil {
+		return nil, err
+	}
+	includeMempool := true
+	if p.has(2) {
+		includeMempool, err = p.bool(2)
+		if err != nil {
+			return nil, err
+		}
+	}
+	hash, parseErr := syscoinrpc.ParseHash(txID)
+	if parseErr != nil {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParameter, Message: "txid must be of length 64"}
+	}
+
+	c, ok := s.unspentOutputs(includeMempool, true)[syscoinrpc.OutPoint{TxID: hash, Vout: uint32(n)}]
+	if !ok {
+		return nil, nil
+	}
+	raw, _ := s.findTransaction(txID)
+	tx, decodeErr := syscoinrpc.DecodeTransaction(raw, syscoinrpc.RegTest)
+	if decodeErr != nil {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInternalError, Message: decodeErr.Error()}
+	}
+	tip := s.chain[len(s.chain)-1]
+	confirmations := uint64(0)
+	if c.height != mempoolHeight {
+		confirmations = tip.height - uint64(c.height) + 1
+	}
+	return map[string]interface{}{
+		"bestblock":     tip.hash.String(),
+		"confirmations": confirmations,
+		"value":         c.out.Value,
+		"scriptPubKey":  c.out.ScriptPubKey,
+		"version":       tx.Version,
+		"coinbase":      tx.Vin[0].IsCoinbase(),
+	}, nil
+}
+
+func getTxOutProof(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	txIDs, err := p.strings(0)
+	if err != nil {
+		return nil, err
+	}
+	matched := make(map[syscoinrpc.Hash]bool, len(txIDs))
+	for _, txID := range txIDs {
+		hash, parseErr := syscoinrpc.ParseHash(txID)
+		if parseErr != nil {
+			return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParameter, Message: "txid must be of length 64"}
+		}
+		if matched[hash] {
+			return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParameter, Message: "Invalid parameter, duplicated txid: " + txID}
+		}
+		matched[hash] = true
+	}
+	if len(matched) == 0 {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParameter, Message: "Invalid parameter, empty txids"}
+	}
+
+	var b *block
+	if p.has(1) {
+		hash, err := p.string(1)
+		if err != nil {
+			return nil, err
+		}
+		b, err = s.blockByHash(hash)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Without a transaction index, look for the block containing the first txid.
+		first, _ := syscoinrpc.ParseHash(txIDs[0])
+		for _, candidate := range s.chain {
+			for _, txID := range candidate.txIDs {
+				if txID == first {
+					b = candidate
+				}
+			}
+		}
+		if b == nil {
+			return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidAddressOrKey, Message: "Transaction not yet in block"}
+		}
+	}
+
+	found := 0
+	for _, txID := range b.txIDs {
+		if matched[txID] {
+			found++
+		}
+	}
+	if found != len(matched) {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidAddressOrKey, Message: "Not all transactions found in specified or retrieved block"}
+	}
+	return hex.EncodeToString(merkleProof(b, matched)), nil
+}
+
+func verifyTxOutProof(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	proof, err := p.string(0)
+	if err != nil {
+		return nil, err
+	}
+	mp, parseErr := syscoinrpc.ParseMerkleProofHex(proof)
+	if parseErr != nil {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCDeserializationError, Message: parseErr.Error()}
+	}
+	root, txIDs, extractErr := mp.ExtractMatches()
+	if extractErr != nil || root != mp.Header.MerkleRoot {
+		return []string{}, nil
+	}
+	if _, ok := s.byHash[mp.Header.Hash.String()]; !ok {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidAddressOrKey, Message: "Block not found in chain"}
+	}
+	res := make([]string, 0, len(txIDs))
+	for _, txID := range txIDs {
+		res = append(res, txID.String())
+	}
+	return res, nil
+}
+
+// getTxOutSetInfo hashes the unspent outputs of the active chain, sorted by outpoint.
+func getTxOutSetInfo(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	coins := s.unspentOutputs(false, true)
+	txIDs := make(map[syscoinrpc.Hash]bool)
+	var serialized bytes.Buffer
+	var total int64
+	for _, outPoint := range sortedOutPoints(coins) {
+		c := coins[outPoint]
+		txIDs[outPoint.TxID] = true
+		total += c.out.Value.Satoshis()
+
+		script, _ := hex.DecodeString(c.out.ScriptPubKey.Hex)
+		serialized.Write(outPoint.TxID[:])
+		binary.Write(&serialized, binary.LittleEndian, outPoint.Vout)
+		binary.Write(&serialized, binary.LittleEndian, c.height)
+		binary.Write(&serialized, binary.LittleEndian, c.out.Value.Satoshis())
+		writeVarInt(&serialized, uint64(len(script)))
+		serialized.Write(script)
+	}
+
+	tip := s.chain[len(s.chain)-1]
+	return map[string]interface{}{
+		"height":          tip.height,
+		"bestblock":       tip.hash.String(),
+		"transactions":    len(txIDs),
+		"txouts":          len(coins),
+		"hash_serialized": doubleSHA256(serialized.Bytes()).String(),
+		"disk_size":       serialized.Len(),
+		"total_amount":    syscoinrpc.Amount(total),
+	}, nil
+}
+
+// verifyChain only checks the params, the fake chain is valid by construction.
+func verifyChain(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	if _, err := p.uint64(0, 3); err != nil {
+		return nil, err
+	}
+	if _, err := p.uint64(1, 6); err != nil {
+		return nil, err
+	}
+	return true, nil
+}
+
+func generate(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	if !p.has(0) {
+		return nil, errInvalidParams
+	}
+	n, err := p.uint64(0, 0)
+	if err != nil {
+		return nil, err
+	}
+	return s.generate(int(n), DefaultAddress), nil
+}
+
+func generateToAddress(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	if !p.has(0) {
+		return nil, errInvalidParams
+	}
+	n, err := p.uint64(0, 0)
+	if err != nil {
+		return nil, err
+	}
+	address, err := p.string(1)
+	if err != nil {
+		return nil, err
+	}
+	if address == "" {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidAddressOrKey, Message: "Error: Invalid address"}
+	}
+	return s.generate(int(n), address), nil
+}
+
+// helpTexts are the help texts of the commands, the other supported commands
+// are only described by their name.
+var helpTexts = map[string]string{
+	"help": "help ( \"command\" )\n\nList all commands, or get help for a specified command.\n\nArguments:\n1. \"command\"     (string, optional) The command to get help on\n\nResult:\n\"text\"     (string) The help text\n",
+}
+
+// help lists the supported commands without a command, like the node does.
+func help(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	command := ""
+	if p.has(0) {
+		var err *syscoinrpc.RPCError
+		command, err = p.string(0)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if command == "" {
+		commands := make([]string, 0, len(handlers))
+		for name := range handlers {
+			commands = append(commands, name)
+		}
+		sort.Strings(commands)
+		return strings.Join(commands, "\n"), nil
+	}
+	if text, ok := helpTexts[command]; ok {
+		return text, nil
+	}
+	if _, ok := handlers[command]; ok {
+		return command + "\n", nil
+	}
+	return "help: unknown command: " + command, nil
+}
+
+// getMemoryInfo reports a single locked memory arena, as the fake node has no keys to lock.
+func getMemoryInfo(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	if p.has(0) {
+		mode, err := p.string(0)
+		if err != nil {
+			return nil, err
+		}
+		if mode != "stats" {
+			return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParameter, Message: "unknown mode " + mode}
+		}
+	}
+	return map[string]interface{}{
+		"locked": map[string]interface{}{
+			"used":        0,
+			"free":        262144,
+			"total":       262144,
+			"locked":      262144,
+			"chunks_used": 0,
+			"chunks_free": 1,
+		},
+	}, nil
+}
+
+func uptime(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	return int64(time.Since(s.started).Seconds()), nil
+}
+
+func logging(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	for i, enable := range []bool{true, false} {
+		if !p.has(i) {
+			break
+		}
+		categories, err := p.strings(i)
+		if err != nil {
+			return nil, err
+		}
+		for _, category := range categories {
+			switch category {
+			case "all", "1":
+				for c := range s.logging {
+					s.logging[c] = enable
+				}
+			case "none", "0":
+				for c := range s.logging {
+					s.logging[c] = false
+				}
+			default:
+				if _, ok := s.logging[category]; !ok {
+					return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParameter, Message: "unknown logging category " + category}
+				}
+				s.logging[category] = enable
+			}
+		}
+	}
+
+	status := make(map[string]bool, len(s.logging))
+	for category, enabled := range s.logging {
+		status[category] = enabled
+	}
+	return status, nil
+}
+
+func stop(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	s.stopped = true
+	return "Syscoin server stopping", nil
+}
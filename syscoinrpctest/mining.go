@@ -0,0 +1,388 @@
+package syscoinrpctest
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+)
+
+const (
+	// MasternodePayment is the masternode payment of the block templates, in satoshis.
+	MasternodePayment int64 = BlockSubsidy / 4
+	// MasternodeAddress is the address of the masternode paid by the block templates.
+	MasternodeAddress = "SfSdMz4Cb1sHnYhG1FqnrDrxz1uEHddDrk"
+
+	// difficultyAdjustmentInterval is the number of blocks between difficulty changes.
+	difficultyAdjustmentInterval = 2016
+	// maxFutureBlockTime is the maximum time of a block ahead of the current time, in seconds.
+	maxFutureBlockTime = 2 * 60 * 60
+)
+
+// target returns the 32 bytes big endian target of the compact bits.
+func target(bits uint32) []byte {
+	t := make([]byte, 32)
+	exponent := int(bits >> 24)
+	mantissa := []byte{byte(bits >> 16 & 0x7f), byte(bits >> 8), byte(bits)}
+	for i, b := range mantissa {
+		if pos := 32 - exponent + i; pos >= 0 && pos < 32 {
+			t[pos] = b
+		}
+	}
+	return t
+}
+
+// meetsTarget returns true if the hash is not above the target of the bits.
+func meetsTarget(hash syscoinrpc.Hash, bits uint32) bool {
+	return bytes.Compare(displayBytes(hash), target(bits)) <= 0
+}
+
+// networkHashPS estimates the hashes per second from the lookup blocks up to height,
+// every block of the fake chain has a work of 2.
+func (s *Server) networkHashPS(lookup int64, height int64) float64 {
+	tip := int64(len(s.chain) - 1)
+	if height < 0 || height > tip {
+		height = tip
+	}
+	if height == 0 {
+		return 0
+	}
+	if lookup <= 0 {
+		lookup = height%difficultyAdjustmentInterval + 1
+	}
+	if lookup > height {
+		lookup = height
+	}
+	first, last := s.chain[height-lookup], s.chain[height]
+	return float64(lookup*2) / float64(last.time-first.time)
+}
+
+func getMiningInfo(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	tip := s.chain[len(s.chain)-1]
+	return map[string]interface{}{
+		"blocks":             tip.height,
+		"currentblockweight": len(tip.serialize()) * 4,
+		"currentblocktx":     len(tip.txs) - 1,
+		"difficulty":         regtestDifficulty,
+		"networkhashps":      s.networkHashPS(120, -1),
+		"pooledtx":           len(s.mempool),
+		"chain":              "regtest",
+		"warnings":           "",
+	}, nil
+}
+
+func getNetworkHashPS(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	lookup, err := p.int64(0, 120)
+	if err != nil {
+		return nil, err
+	}
+	height, err := p.int64(1, -1)
+	if err != nil {
+		return nil, err
+	}
+	return s.networkHashPS(lookup, height), nil
+}
+
+func getBlockTemplate(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	var request syscoinrpc.BlockTemplateRequest
+	if p.has(0) && json.Unmarshal(p[0], &request) != nil {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCTypeError, Message: "Expected type object"}
+	}
+	if request.Mode != "" && request.Mode != "template" {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParameter, Message: "Invalid mode"}
+	}
+	segwit := false
+	for _, rule := range request.Rules {
+		segwit = segwit || rule == "segwit"
+	}
+	if !segwit {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParameter, Message: `getblocktemplate must be called with the segwit rule set (call with {"rules": ["segwit"]})`}
+	}
+
+	txIDs := make([]string, 0, len(s.mempool))
+	for txID := range s.mempool {
+		txIDs = append(txIDs, txID)
+	}
+	sort.Strings(txIDs)
+	indexes := make(map[syscoinrpc.Hash]uint64, len(txIDs))
+	txs := []interface{}{}
+	for _, txID := range txIDs {
+		tx, err := syscoinrpc.DecodeTransaction(s.mempool[txID], syscoinrpc.RegTest)
+		if err != nil {
+			return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInternalError, Message: err.Error()}
+		}
+		depends := []uint64{}
+		for _, vin := range tx.Vin {
+			if index, ok := indexes[vin.TxID]; ok {
+				depends = append(depends, index)
+			}
+		}
+		txs = append(txs, syscoinrpc.BlockTemplateTransaction{
+			Data:    tx.Hex,
+			TxID:    tx.TxID,
+			Hash:    tx.Hash,
+			Depends: depends,
+			Fee:     syscoinrpc.SatoshiAmount(txFee),
+			Weight:  tx.VSize * 4,
+		})
+		indexes[tx.TxID] = uint64(len(txs))
+	}
+
+	tip := s.chain[len(s.chain)-1]
+	return map[string]interface{}{
+		"capabilities":      []string{"proposal"},
+		"version":           blockVersion,
+		"rules":             []string{"csv", "!segwit"},
+		"vbavailable":       map[string]uint32{},
+		"vbrequired":        0,
+		"previousblockhash": tip.hash.String(),
+		"transactions":      txs,
+		"coinbaseaux":       map[string]string{"flags": ""},
+		"coinbasevalue":     BlockSubsidy + txFee*int64(len(txIDs)),
+		"longpollid":        fmt.Sprintf("%s%d", tip.hash, len(txIDs)),
+		"target":            hex.EncodeToString(target(regtestBits)),
+		"mintime":           s.medianTime(tip) + 1,
+		"mutable":           []string{"time", "transactions", "prevblock"},
+		"noncerange":        "00000000ffffffff",
+		"sigoplimit":        80000,
+		"sizelimit":         4000000,
+		"weightlimit":       4000000,
+		"curtime":           tip.time + BlockInterval,
+		"bits":              fmt.Sprintf("%08x", regtestBits),
+		"height":            tip.height + 1,
+		"masternode": syscoinrpc.BlockTemplatePayee{
+			Payee:  MasternodeAddress,
+			Script: hex.EncodeToString(addressScript(MasternodeAddress)),
+			Amount: syscoinrpc.SatoshiAmount(MasternodePayment),
+		},
+		"masternode_payments_started":  true,
+		"masternode_payments_enforced": true,
+		"superblock":                   []interface{}{},
+		"superblocks_started":          false,
+		"superblocks_enabled":          false,
+	}, nil
+}
+
+// submitBlock validates the block like the node does, and connects it if it
+// extends the active chain. Blocks extending a fork are stored unvalidated.
+func submitBlock(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	rawHex, err := p.string(0)
+	if err != nil {
+		return nil, err
+	}
+	raw, decodeErr := hex.DecodeString(rawHex)
+	var decoded *syscoinrpc.BlockWithTransactions
+	if decodeErr == nil {
+		decoded, decodeErr = syscoinrpc.DecodeBlock(raw, syscoinrpc.RegTest)
+	}
+	if decodeErr != nil {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCDeserializationError, Message: "Block decode failed"}
+	}
+	if len(decoded.Tx) == 0 || len(decoded.Tx[0].Vin) == 0 || !decoded.Tx[0].Vin[0].IsCoinbase() {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCDeserializationError, Message: "Block does not start with a coinbase"}
+	}
+
+	if known, ok := s.byHash[decoded.Hash.String()]; ok {
+		if s.isActive(known) {
+			return syscoinrpc.BlockRejectDuplicate, nil
+		}
+		return syscoinrpc.BlockRejectDuplicateInconclusive, nil
+	}
+	prev, ok := s.byHash[decoded.PreviousBlockHash.String()]
+	if !ok {
+		return syscoinrpc.BlockRejectPrevNotFound, nil
+	}
+
+	b := &block{
+		height:  prev.height + 1,
+		hash:    decoded.Hash,
+		prev:    prev.hash,
+		header:  raw[:80],
+		version: uint32(decoded.Version),
+		time:    uint32(decoded.Time),
+		nonce:   uint32(decoded.Nonce),
+	}
+	if decoded.Version&(1<<8) != 0 {
+		b.auxPow, _ = decoded.AuxPow.Serialize()
+	}
+	for _, tx := range decoded.Tx {
+		rawTx, _ := hex.DecodeString(tx.Hex)
+		b.txs = append(b.txs, rawTx)
+		b.txIDs = append(b.txIDs, tx.TxID)
+	}
+	if prev != s.chain[len(s.chain)-1] {
+		s.byHash[b.hash.String()] = b
+		return syscoinrpc.BlockRejectInconclusive, nil
+	}
+
+	if decoded.Bits != fmt.Sprintf("%08x", regtestBits) {
+		return syscoinrpc.BlockRejectBadDiffBits, nil
+	}
+	if !meetsTarget(decoded.Hash, regtestBits) {
+		return syscoinrpc.BlockRejectHighHash, nil
+	}
+	if merkleRoot(b.txIDs) != decoded.MerkleRoot {
+		return syscoinrpc.BlockRejectBadMerkleRoot, nil
+	}
+	if b.time <= s.medianTime(prev) {
+		return syscoinrpc.BlockRejectTimeTooOld, nil
+	}
+	if int64(b.time) > time.Now().Unix()+maxFutureBlockTime {
+		return syscoinrpc.BlockRejectTimeTooNew, nil
+	}
+
+	coinbase := decoded.Tx[0]
+	heightPush := scriptNum(b.height)
+	if !strings.HasPrefix(coinbase.Vin[0].Coinbase, hex.EncodeToString(append([]byte{byte(len(heightPush))}, heightPush...))) {
+		return syscoinrpc.BlockRejectBadCoinbaseHeight, nil
+	}
+	var paid syscoinrpc.Amount
+	masternodePaid := false
+	masternodeScript := hex.EncodeToString(addressScript(MasternodeAddress))
+	for _, vout := range coinbase.Vout {
+		paid += vout.Value
+		if vout.ScriptPubKey.Hex == masternodeScript && vout.Value >= syscoinrpc.Amount(MasternodePayment) {
+			masternodePaid = true
+		}
+	}
+	if paid > syscoinrpc.Amount(BlockSubsidy+txFee*int64(len(decoded.Tx)-1)) {
+		return syscoinrpc.BlockRejectBadCoinbaseAmount, nil
+	}
+	if !masternodePaid {
+		return syscoinrpc.BlockRejectBadCoinbasePayee, nil
+	}
+
+	s.connectBlock(b)
+	return nil, nil
+}
+
+func prioritiseTransaction(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	txID, err := p.string(0)
+	if err != nil {
+		return nil, err
+	}
+	if _, parseErr := syscoinrpc.ParseHash(txID); parseErr != nil {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParameter, Message: "txid must be of length 64"}
+	}
+	dummy, err := p.int64(1, 0)
+	if err != nil {
+		return nil, err
+	}
+	if dummy != 0 {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParameter, Message: "Priority is no longer supported, dummy argument to prioritisetransaction must be 0."}
+	}
+	if !p.has(2) {
+		return nil, errInvalidParams
+	}
+	feeDelta, err := p.int64(2, 0)
+	if err != nil {
+		return nil, err
+	}
+	s.feeDeltas[txID] += feeDelta
+	return true, nil
+}
+
+func createAuxBlock(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	address, err := p.string(0)
+	if err != nil {
+		return nil, err
+	}
+	if address == "" {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidAddressOrKey, Message: "Invalid coinbase payout address"}
+	}
+	return s.auxBlock(address), nil
+}
+
+// getAuxBlock creates a block paid to DefaultAddress without params,
+// and submits the AuxPoW of a block with params, like `submitauxblock`.
+func getAuxBlock(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	if p.has(0) {
+		return submitAuxBlock(s, p)
+	}
+	return s.auxBlock(DefaultAddress), nil
+}
+
+// auxBlock creates a block to merge mine on top of the tip, including the
+// mempool transactions.
+func (s *Server) auxBlock(address string) map[string]interface{} {
+	tip := s.chain[len(s.chain)-1]
+	txIDs := make([]string, 0, len(s.mempool))
+	for txID := range s.mempool {
+		txIDs = append(txIDs, txID)
+	}
+	sort.Strings(txIDs)
+	txs := make([][]byte, 0, len(txIDs))
+	for _, txID := range txIDs {
+		txs = append(txs, s.mempool[txID])
+	}
+
+	b := newBlock(tip.height+1, tip.hash, auxBlockVersion, 0, address, txs)
+	s.auxBlocks[b.hash.String()] = b
+	return map[string]interface{}{
+		"hash":              b.hash.String(),
+		"chainid":           AuxPowChainID,
+		"previousblockhash": tip.hash.String(),
+		"coinbasevalue":     BlockSubsidy,
+		"bits":              fmt.Sprintf("%08x", regtestBits),
+		"height":            b.height,
+		"_target":           hex.EncodeToString(reversed(target(regtestBits))),
+	}
+}
+
+// submitAuxBlock connects the block if the AuxPoW proves the work on it.
+// Blocks which no longer extend the active chain are stored as a fork.
+func submitAuxBlock(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	hash, err := p.string(0)
+	if err != nil {
+		return nil, err
+	}
+	rawAuxPowHex, err := p.string(1)
+	if err != nil {
+		return nil, err
+	}
+	b, ok := s.auxBlocks[hash]
+	if !ok {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParameter, Message: "block hash unknown"}
+	}
+	rawAuxPow, decodeErr := hex.DecodeString(rawAuxPowHex)
+	var auxPow *syscoinrpc.AuxPow
+	if decodeErr == nil {
+		auxPow, decodeErr = syscoinrpc.DecodeAuxPow(rawAuxPow, syscoinrpc.RegTest)
+	}
+	if decodeErr != nil {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCDeserializationError, Message: "AuxPow decode failed"}
+	}
+
+	if _, known := s.byHash[hash]; known {
+		return true, nil
+	}
+	if auxPow.Verify(b.hash, AuxPowChainID) != nil {
+		return false, nil
+	}
+	parentHeader, _ := syscoinrpc.DecodeBlockHeaderHex(auxPow.ParentBlock)
+	if !meetsTarget(parentHeader.Hash, regtestBits) {
+		return false, nil
+	}
+
+	b.auxPow = rawAuxPow
+	if b.prev != s.chain[len(s.chain)-1].hash {
+		s.byHash[hash] = b
+		return true, nil
+	}
+	s.connectBlock(b)
+	return true, nil
+}
+
+// reversed returns a reversed copy of b.
+func reversed(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
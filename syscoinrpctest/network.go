@@ -0,0 +1,438 @@
+package syscoinrpctest
+
+import (
+	"encoding/json"
+	"net"
+	"sort"
+	"time"
+
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+)
+
+const (
+	// defaultBanTime is the default ban duration, in seconds (`-bantime`).
+	defaultBanTime = 24 * 60 * 60
+	// peerVersion is the protocol version of the node and its peers.
+	peerVersion = 70227
+	// peerSubVersion is the subversion of the node and its peers.
+	peerSubVersion = "/Syscoin Core:4.1.3/"
+	// pingTime is the ping time of the peers, in seconds.
+	pingTime = 0.001
+)
+
+// peer is a peer connected to the fake node.
+type peer struct {
+	id             uint64    // The node ID.
+	address        string    // The address, as host:port.
+	inbound        bool      // True if the peer connected to the node.
+	addNode        bool      // True if the peer was added with `addnode add`.
+	connTime       time.Time // The connection time.
+	startingHeight uint64    // The height of the chain tip at connection.
+	pinged         bool      // True after a `ping` call.
+}
+
+// ban is a banned subnet.
+type ban struct {
+	subnet  *net.IPNet // The banned subnet.
+	created time.Time  // The start time of the ban.
+	until   time.Time  // The end time of the ban.
+}
+
+// ConnectPeer simulates an inbound connection from a peer with the given
+// address (host:port), and returns its node ID. The connection is refused
+// if the network is not active or the peer is banned.
+func (s *Server) ConnectPeer(address string) (uint64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.connectPeer(address, true, false)
+	if p == nil {
+		return 0, false
+	}
+	return p.id, true
+}
+
+// connectPeer connects the peer, nil if the network is not active or the peer is banned.
+func (s *Server) connectPeer(address string, inbound bool, addNode bool) *peer {
+	if !s.network || s.isBanned(address) {
+		return nil
+	}
+	p := &peer{
+		id:             s.nextPeerID,
+		address:        address,
+		inbound:        inbound,
+		addNode:        addNode,
+		connTime:       time.Now(),
+		startingHeight: uint64(len(s.chain) - 1),
+	}
+	s.nextPeerID++
+	s.peers = append(s.peers, p)
+	return p
+}
+
+// disconnectPeers disconnects the peers matching the filter.
+func (s *Server) disconnectPeers(match func(p *peer) bool) int {
+	peers := s.peers[:0]
+	for _, p := range s.peers {
+		if !match(p) {
+			peers = append(peers, p)
+		}
+	}
+	disconnected := len(s.peers) - len(peers)
+	s.peers = peers
+	return disconnected
+}
+
+// isBanned returns true if the host of the address is in a banned subnet.
+func (s *Server) isBanned(address string) bool {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, b := range s.bans {
+		if time.Now().Before(b.until) && b.subnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseSubnet parses an IP or a subnet, an IP being a subnet of a single address.
+func parseSubnet(subnet string) (*net.IPNet, bool) {
+	if ip := net.ParseIP(subnet); ip != nil {
+		bits := 128
+		if ip.To4() != nil {
+			ip = ip.To4()
+			bits = 32
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, true
+	}
+	_, ipNet, err := net.ParseCIDR(subnet)
+	return ipNet, err == nil
+}
+
+func addNode(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	node, err := p.string(0)
+	if err != nil {
+		return nil, err
+	}
+	command, err := p.string(1)
+	if err != nil {
+		return nil, err
+	}
+
+	switch command {
+	case "onetry":
+		s.connectPeer(node, false, false)
+	case "add":
+		for _, added := range s.addedNodes {
+			if added == node {
+				return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCClientNodeAlreadyAdded, Message: "Error: Node already added"}
+			}
+		}
+		s.addedNodes = append(s.addedNodes, node)
+		s.connectPeer(node, false, true)
+	case "remove":
+		for i, added := range s.addedNodes {
+			if added == node {
+				s.addedNodes = append(s.addedNodes[:i], s.addedNodes[i+1:]...)
+				return nil, nil
+			}
+		}
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCClientNodeNotAdded, Message: "Error: Node has not been added."}
+	default:
+		return nil, errInvalidParams
+	}
+	return nil, nil
+}
+
+func clearBanned(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	s.bans = make(map[string]*ban)
+	return nil, nil
+}
+
+func disconnectNode(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	address := ""
+	if p.has(0) {
+		var err *syscoinrpc.RPCError
+		address, err = p.string(0)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var match func(peer *peer) bool
+	switch {
+	case address != "" && p.has(1):
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParams, Message: "Only one of address and nodeid should be provided."}
+	case address != "":
+		match = func(peer *peer) bool { return peer.address == address }
+	case p.has(1):
+		nodeID, err := p.uint64(1, 0)
+		if err != nil {
+			return nil, err
+		}
+		match = func(peer *peer) bool { return peer.id == nodeID }
+	default:
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParams, Message: "Only one of address and nodeid should be provided."}
+	}
+
+	if s.disconnectPeers(match) == 0 {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCClientNodeNotConnected, Message: "Node not found in connected nodes"}
+	}
+	return nil, nil
+}
+
+func getAddedNodeInfo(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	nodes := s.addedNodes
+	if p.has(0) {
+		node, err := p.string(0)
+		if err != nil {
+			return nil, err
+		}
+		nodes = nil
+		for _, added := range s.addedNodes {
+			if added == node {
+				nodes = append(nodes, added)
+			}
+		}
+		if len(nodes) == 0 {
+			return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCClientNodeNotAdded, Message: "Error: Node has not been added."}
+		}
+	}
+
+	infos := make([]map[string]interface{}, 0, len(nodes))
+	for _, node := range nodes {
+		addresses := []map[string]interface{}{}
+		for _, peer := range s.peers {
+			if peer.address == node {
+				direction := "outbound"
+				if peer.inbound {
+					direction = "inbound"
+				}
+				addresses = append(addresses, map[string]interface{}{"address": peer.address, "connected": direction})
+			}
+		}
+		infos = append(infos, map[string]interface{}{
+			"addednode": node,
+			"connected": len(addresses) > 0,
+			"addresses": addresses,
+		})
+	}
+	return infos, nil
+}
+
+func getConnectionCount(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	return len(s.peers), nil
+}
+
+// bytesSent returns the bytes sent to the peer, by message type: a version message, and a ping once pinged.
+func (pr *peer) bytesSent() map[string]uint64 {
+	sent := map[string]uint64{"version": 126}
+	if pr.pinged {
+		sent["ping"] = 32
+	}
+	return sent
+}
+
+// bytesRecv returns the bytes received from the peer, by message type: a version message, and a pong once pinged.
+func (pr *peer) bytesRecv() map[string]uint64 {
+	recv := map[string]uint64{"version": 126}
+	if pr.pinged {
+		recv["pong"] = 32
+	}
+	return recv
+}
+
+// totalBytes returns the sum of the bytes per message.
+func totalBytes(perMsg map[string]uint64) uint64 {
+	var total uint64
+	for _, n := range perMsg {
+		total += n
+	}
+	return total
+}
+
+func getNetTotals(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	var sent, recv uint64
+	for _, peer := range s.peers {
+		sent += totalBytes(peer.bytesSent())
+		recv += totalBytes(peer.bytesRecv())
+	}
+	return map[string]interface{}{
+		"totalbytesrecv": recv,
+		"totalbytessent": sent,
+		"timemillis":     time.Now().UnixNano() / int64(time.Millisecond),
+		"uploadtarget": map[string]interface{}{
+			"timeframe":               defaultBanTime,
+			"target":                  0,
+			"target_reached":          false,
+			"serve_historical_blocks": true,
+			"bytes_left_in_cycle":     0,
+			"time_left_in_cycle":      0,
+		},
+	}, nil
+}
+
+func getNetworkInfo(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	networks := make([]map[string]interface{}, 0, 3)
+	for _, name := range []string{"ipv4", "ipv6", "onion"} {
+		networks = append(networks, map[string]interface{}{
+			"name":                        name,
+			"limited":                     false,
+			"reachable":                   name != "onion",
+			"proxy":                       "",
+			"proxy_randomize_credentials": false,
+		})
+	}
+	return map[string]interface{}{
+		"version":         4010300,
+		"subversion":      peerSubVersion,
+		"protocolversion": peerVersion,
+		"localservices":   "000000000000040d",
+		"localrelay":      true,
+		"timeoffset":      0,
+		"networkactive":   s.network,
+		"connections":     len(s.peers),
+		"networks":        networks,
+		"relayfee":        json.Number("0.00001000"),
+		"incrementalfee":  json.Number("0.00001000"),
+		"localaddresses":  []map[string]interface{}{{"address": "127.0.0.1", "port": 18369, "score": 1}},
+		"warnings":        "",
+	}, nil
+}
+
+func getPeerInfo(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	height := uint64(len(s.chain) - 1)
+	infos := make([]map[string]interface{}, 0, len(s.peers))
+	for _, peer := range s.peers {
+		info := map[string]interface{}{
+			"id":                peer.id,
+			"addr":              peer.address,
+			"services":          "000000000000040d",
+			"relaytxes":         true,
+			"lastsend":          peer.connTime.Unix(),
+			"lastrecv":          peer.connTime.Unix(),
+			"bytessent":         totalBytes(peer.bytesSent()),
+			"bytesrecv":         totalBytes(peer.bytesRecv()),
+			"conntime":          peer.connTime.Unix(),
+			"timeoffset":        0,
+			"version":           peerVersion,
+			"subver":            peerSubVersion,
+			"inbound":           peer.inbound,
+			"addnode":           peer.addNode,
+			"startingheight":    peer.startingHeight,
+			"banscore":          0,
+			"synced_headers":    height,
+			"synced_blocks":     height,
+			"inflight":          []uint64{},
+			"whitelisted":       false,
+			"bytessent_per_msg": peer.bytesSent(),
+			"bytesrecv_per_msg": peer.bytesRecv(),
+		}
+		if peer.pinged {
+			info["pingtime"] = pingTime
+			info["minping"] = pingTime
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+func listBanned(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	subnets := make([]string, 0, len(s.bans))
+	for subnet, b := range s.bans {
+		if time.Now().Before(b.until) {
+			subnets = append(subnets, subnet)
+		}
+	}
+	sort.Strings(subnets)
+
+	banned := make([]map[string]interface{}, 0, len(subnets))
+	for _, subnet := range subnets {
+		b := s.bans[subnet]
+		banned = append(banned, map[string]interface{}{
+			"address":      subnet,
+			"banned_until": b.until.Unix(),
+			"ban_created":  b.created.Unix(),
+			"ban_reason":   string(syscoinrpc.BanReasonManuallyAdded),
+		})
+	}
+	return banned, nil
+}
+
+// ping pings the peers, which reply immediately.
+func ping(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	for _, peer := range s.peers {
+		peer.pinged = true
+	}
+	return nil, nil
+}
+
+func setBan(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	subnetParam, err := p.string(0)
+	if err != nil {
+		return nil, err
+	}
+	command, err := p.string(1)
+	if err != nil {
+		return nil, err
+	}
+	if command != "add" && command != "remove" {
+		return nil, errInvalidParams
+	}
+	subnet, ok := parseSubnet(subnetParam)
+	if !ok {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCClientInvalidIPOrSubnet, Message: "Error: Invalid IP/Subnet"}
+	}
+
+	key := subnet.String()
+	if command == "remove" {
+		if _, banned := s.bans[key]; !banned {
+			return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCClientInvalidIPOrSubnet, Message: "Error: Unban failed. Requested address/subnet was not previously banned."}
+		}
+		delete(s.bans, key)
+		return nil, nil
+	}
+
+	if b, banned := s.bans[key]; banned && time.Now().Before(b.until) {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCClientNodeAlreadyAdded, Message: "Error: IP/Subnet already banned"}
+	}
+	banTime, err := p.int64(2, 0)
+	if err != nil {
+		return nil, err
+	}
+	absolute := false
+	if p.has(3) {
+		absolute, err = p.bool(3)
+		if err != nil {
+			return nil, err
+		}
+	}
+	now := time.Now()
+	until := now.Add(defaultBanTime * time.Second)
+	switch {
+	case absolute:
+		until = time.Unix(banTime, 0)
+	case banTime > 0:
+		until = now.Add(time.Duration(banTime) * time.Second)
+	}
+	s.bans[key] = &ban{subnet: subnet, created: now, until: until}
+	s.disconnectPeers(func(peer *peer) bool { return s.isBanned(peer.address) })
+	return nil, nil
+}
+
+func setNetworkActive(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	active, err := p.bool(0)
+	if err != nil {
+		return nil, err
+	}
+	s.network = active
+	if !active {
+		s.disconnectPeers(func(peer *peer) bool { return true })
+	}
+	return s.network, nil
+}
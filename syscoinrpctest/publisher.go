@@ -0,0 +1,300 @@
+package syscoinrpctest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync"
+)
+
+// Publisher is a fake ZMQ publisher of the node, speaking ZMTP 3.0 over TCP
+// with the NULL security mechanism, like the `zmqpub<topic>` endpoints do.
+//
+// Every message is made of the topic, the body and the little endian
+// sequence number of the message in the topic:
+//
+//     pub := syscoinrpctest.NewPublisher()
+//     defer pub.Close()
+//
+//     srv.SetPublisher(pub)
+//     sub, err := cl.Blockchain.Subscribe(ctx, syscoinrpc.SubscriberConfig{
+//         Endpoints: map[string]string{syscoinrpc.TopicHashBlock: pub.Endpoint},
+//     })
+type Publisher struct {
+	// Endpoint is the ZMQ endpoint of the publisher, like tcp://127.0.0.1:12345.
+	Endpoint string
+
+	listener net.Listener   // The listener of the subscribers.
+	wg       sync.WaitGroup // Waits for the goroutines of the publisher.
+
+	mu        sync.Mutex         // The lock of the fields below.
+	peers     map[*zmtpPeer]bool // The connected subscribers.
+	sequences map[string]uint32  // The next sequence number of each topic.
+	closed    bool               // True once the publisher is closed.
+}
+
+// zmtpPeer is a subscriber connected to a Publisher.
+type zmtpPeer struct {
+	conn net.Conn // The connection of the subscriber.
+
+	mu            sync.Mutex // The lock of the fields below, held while writing to conn.
+	subscriptions []string   // The subscribed topic prefixes.
+}
+
+// NewPublisher starts a new publisher, listening on a local TCP port.
+func NewPublisher() *Publisher {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic("syscoinrpctest: failed to listen: " + err.Error())
+	}
+	p := &Publisher{
+		Endpoint:  "tcp://" + listener.Addr().String(),
+		listener:  listener,
+		peers:     make(map[*zmtpPeer]bool),
+		sequences: make(map[string]uint32),
+	}
+	p.wg.Add(1)
+	go p.accept()
+	return p
+}
+
+// Publish sends the message to the subscribers of the topic.
+func (p *Publisher) Publish(topic string, body []byte) {
+	p.mu.Lock()
+	sequence := p.sequences[topic]
+	p.sequences[topic]++
+	peers := make([]*zmtpPeer, 0, len(p.peers))
+	for peer := range p.peers {
+		peers = append(peers, peer)
+	}
+	p.mu.Unlock()
+
+	var rawSequence [4]byte
+	binary.LittleEndian.PutUint32(rawSequence[:], sequence)
+	for _, peer := range peers {
+		peer.send([]byte(topic), body, rawSequence[:])
+	}
+}
+
+// Skip drops the next n messages of the topic, as the node does for
+// the subscribers which are too slow.
+func (p *Publisher) Skip(topic string, n uint32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sequences[topic] += n
+}
+
+// Subscribed returns true if a subscriber is subscribed to the topic.
+// Messages published before the subscription are not received,
+// tests should wait for it.
+func (p *Publisher) Subscribed(topic string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for peer := range p.peers {
+		if peer.subscribed(topic) {
+			return true
+		}
+	}
+	return false
+}
+
+// Disconnect closes the connections of all the subscribers.
+func (p *Publisher) Disconnect() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for peer := range p.peers {
+		peer.conn.Close()
+		delete(p.peers, peer)
+	}
+}
+
+// Close stops the publisher and closes the connections of the subscribers.
+func (p *Publisher) Close() {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+	p.listener.Close()
+	p.Disconnect()
+	p.wg.Wait()
+}
+
+// accept serves the subscribers until the listener is closed.
+func (p *Publisher) accept() {
+	defer p.wg.Done()
+	for {
+		conn, err := p.listener.Accept()
+		if err != nil {
+			return
+		}
+		p.wg.Add(1)
+		go p.serve(conn)
+	}
+}
+
+// serve performs the handshake with the subscriber, then reads its subscriptions.
+func (p *Publisher) serve(conn net.Conn) {
+	defer p.wg.Done()
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	peer := &zmtpPeer{conn: conn}
+	if peer.handshake(r) != nil {
+		return
+	}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.peers[peer] = true
+	p.mu.Unlock()
+	defer func() {
+		p.mu.Lock()
+		delete(p.peers, peer)
+		p.mu.Unlock()
+	}()
+
+	for {
+		flags, body, err := readZMTPFrame(r)
+		if err != nil {
+			return
+		}
+		if flags&zmtpFlagCommand != 0 || len(body) == 0 {
+			continue
+		}
+		peer.mu.Lock()
+		switch body[0] {
+		case 1:
+			peer.subscriptions = append(peer.subscriptions, string(body[1:]))
+		case 0:
+			for i, prefix := range peer.subscriptions {
+				if prefix == string(body[1:]) {
+					peer.subscriptions = append(peer.subscriptions[:i], peer.subscriptions[i+1:]...)
+					break
+				}
+			}
+		}
+		peer.mu.Unlock()
+	}
+}
+
+// handshake exchanges the greetings and READY commands with the subscriber.
+func (peer *zmtpPeer) handshake(r *bufio.Reader) error {
+	greeting := make([]byte, 64)
+	greeting[0] = 0xff
+	greeting[9] = 0x7f
+	greeting[10] = 3
+	copy(greeting[12:32], "NULL")
+	_, err := peer.conn.Write(greeting)
+	if err != nil {
+		return err
+	}
+
+	peerGreeting := make([]byte, 64)
+	_, err = io.ReadFull(r, peerGreeting)
+	if err != nil {
+		return err
+	}
+	if peerGreeting[0] != 0xff || peerGreeting[9] != 0x7f || peerGreeting[10] < 3 {
+		return errors.New("invalid greeting")
+	}
+
+	flags, body, err := readZMTPFrame(r)
+	if err != nil {
+		return err
+	}
+	if flags&zmtpFlagCommand == 0 || !bytes.HasPrefix(body, []byte("\x05READY")) {
+		return errors.New("expected a READY command")
+	}
+
+	var ready bytes.Buffer
+	ready.WriteString("\x05READY\x0bSocket-Type")
+	binary.Write(&ready, binary.BigEndian, uint32(3))
+	ready.WriteString("PUB")
+	return writeZMTPFrame(peer.conn, zmtpFlagCommand, ready.Bytes())
+}
+
+// subscribed returns true if the subscriber is subscribed to the topic.
+func (peer *zmtpPeer) subscribed(topic string) bool {
+	peer.mu.Lock()
+	defer peer.mu.Unlock()
+	for _, prefix := range peer.subscriptions {
+		if strings.HasPrefix(topic, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// send sends the message if the subscriber is subscribed to its topic.
+func (peer *zmtpPeer) send(topic []byte, parts ...[]byte) {
+	if !peer.subscribed(string(topic)) {
+		return
+	}
+	peer.mu.Lock()
+	defer peer.mu.Unlock()
+	parts = append([][]byte{topic}, parts...)
+	for i, part := range parts {
+		var flags byte
+		if i < len(parts)-1 {
+			flags = zmtpFlagMore
+		}
+		if writeZMTPFrame(peer.conn, flags, part) != nil {
+			peer.conn.Close()
+			return
+		}
+	}
+}
+
+// ZMTP 3.0 frame flags.
+const (
+	zmtpFlagMore    byte = 0x01
+	zmtpFlagLong    byte = 0x02
+	zmtpFlagCommand byte = 0x04
+)
+
+// writeZMTPFrame writes a single frame.
+func writeZMTPFrame(w io.Writer, flags byte, body []byte) error {
+	var header []byte
+	if len(body) > 255 {
+		header = make([]byte, 9)
+		header[0] = flags | zmtpFlagLong
+		binary.BigEndian.PutUint64(header[1:], uint64(len(body)))
+	} else {
+		header = []byte{flags, byte(len(body))}
+	}
+	_, err := w.Write(append(header, body...))
+	return err
+}
+
+// readZMTPFrame reads a single frame.
+func readZMTPFrame(r *bufio.Reader) (byte, []byte, error) {
+	flags, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	var size uint64
+	if flags&zmtpFlagLong != 0 {
+		var long [8]byte
+		_, err = io.ReadFull(r, long[:])
+		size = binary.BigEndian.Uint64(long[:])
+	} else {
+		var short byte
+		short, err = r.ReadByte()
+		size = uint64(short)
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	if size > 1<<20 {
+		return 0, nil, errors.New("frame too large")
+	}
+	body := make([]byte, size)
+	_, err = io.ReadFull(r, body)
+	return flags, body, err
+}
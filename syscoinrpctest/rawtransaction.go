@@ -0,0 +1,443 @@
+package syscoinrpctest
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+)
+
+const (
+	// rawTxVersion is the version of the transactions created by `createrawtransaction`.
+	rawTxVersion uint32 = 2
+	// absurdFee is the fee above which transactions are rejected, unless allowing high fees.
+	absurdFee int64 = 10000000
+	// sequenceFinal is the sequence number of inputs disabling the lock time.
+	sequenceFinal uint32 = 0xffffffff
+	// sequenceReplaceable is the sequence number of inputs signaling replaceability (BIP 125).
+	sequenceReplaceable uint32 = 0xfffffffd
+)
+
+// errTxDecode is returned for transactions which cannot be decoded.
+var errTxDecode = &syscoinrpc.RPCError{Code: syscoinrpc.RPCDeserializationError, Message: "TX decode failed"}
+
+// txIn is an input of a transaction to serialize.
+type txIn struct {
+	prev      syscoinrpc.OutPoint // The spent output.
+	scriptSig []byte              // The signature script.
+	sequence  uint32              // The sequence number.
+}
+
+// txOut is an output of a transaction to serialize.
+type txOut struct {
+	value  int64  // The amount, in satoshis.
+	script []byte // The output script.
+}
+
+// serializeTx returns the serialized transaction, without witness.
+func serializeTx(version uint32, ins []txIn, outs []txOut, lockTime uint32) []byte {
+	var tx bytes.Buffer
+	binary.Write(&tx, binary.LittleEndian, version)
+	writeVarInt(&tx, uint64(len(ins)))
+	for _, in := range ins {
+		tx.Write(in.prev.TxID[:])
+		binary.Write(&tx, binary.LittleEndian, in.prev.Vout)
+		writeVarInt(&tx, uint64(len(in.scriptSig)))
+		tx.Write(in.scriptSig)
+		binary.Write(&tx, binary.LittleEndian, in.sequence)
+	}
+	writeVarInt(&tx, uint64(len(outs)))
+	for _, out := range outs {
+		binary.Write(&tx, binary.LittleEndian, out.value)
+		writeVarInt(&tx, uint64(len(out.script)))
+		tx.Write(out.script)
+	}
+	binary.Write(&tx, binary.LittleEndian, lockTime)
+	return tx.Bytes()
+}
+
+// txParts returns the inputs and outputs of the decoded transaction, to serialize it again.
+func txParts(tx *syscoinrpc.Transaction) ([]txIn, []txOut) {
+	ins := make([]txIn, 0, len(tx.Vin))
+	for _, vin := range tx.Vin {
+		in := txIn{prev: syscoinrpc.OutPoint{TxID: vin.TxID, Vout: vin.Vout}, sequence: uint32(vin.Sequence)}
+		if vin.ScriptSig != nil {
+			in.scriptSig, _ = hex.DecodeString(vin.ScriptSig.Hex)
+		}
+		ins = append(ins, in)
+	}
+	outs := make([]txOut, 0, len(tx.Vout))
+	for _, vout := range tx.Vout {
+		script, _ := hex.DecodeString(vout.ScriptPubKey.Hex)
+		outs = append(outs, txOut{value: vout.Value.Satoshis(), script: script})
+	}
+	return ins, outs
+}
+
+// transaction decodes the hex encoded transaction of the param i.
+func (p params) transaction(i int) (*syscoinrpc.Transaction, *syscoinrpc.RPCError) {
+	rawTxHex, rpcErr := p.string(i)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	tx, err := syscoinrpc.DecodeTransactionHex(rawTxHex, syscoinrpc.RegTest)
+	if err != nil {
+		return nil, errTxDecode
+	}
+	return tx, nil
+}
+
+// sortedOutPoints returns the outpoints of the coins, by transaction ID and index.
+func sortedOutPoints(coins map[syscoinrpc.OutPoint]coin) []syscoinrpc.OutPoint {
+	outPoints := make([]syscoinrpc.OutPoint, 0, len(coins))
+	for outPoint := range coins {
+		outPoints = append(outPoints, outPoint)
+	}
+	sort.Slice(outPoints, func(i, j int) bool {
+		if outPoints[i].TxID != outPoints[j].TxID {
+			return outPoints[i].TxID.String() < outPoints[j].TxID.String()
+		}
+		return outPoints[i].Vout < outPoints[j].Vout
+	})
+	return outPoints
+}
+
+func createRawTransaction(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	var inputs []struct {
+		TxID     *syscoinrpc.Hash `json:"txid"`
+		Vout     *uint32          `json:"vout"`
+		Sequence *uint32          `json:"sequence"`
+	}
+	if !p.has(0) || json.Unmarshal(p[0], &inputs) != nil {
+		return nil, errInvalidParams
+	}
+	var outputs []map[string]json.RawMessage
+	if !p.has(1) || json.Unmarshal(p[1], &outputs) != nil {
+		return nil, errInvalidParams
+	}
+	lockTime, rpcErr := p.uint64(2, 0)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if lockTime > 0xffffffff {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParameter, Message: "Invalid parameter, locktime out of range"}
+	}
+	replaceable := false
+	if p.has(3) {
+		if replaceable, rpcErr = p.bool(3); rpcErr != nil {
+			return nil, rpcErr
+		}
+	}
+
+	sequence := sequenceFinal
+	if replaceable {
+		sequence = sequenceReplaceable
+	} else if lockTime != 0 {
+		sequence = sequenceFinal - 1
+	}
+	ins := make([]txIn, 0, len(inputs))
+	for _, input := range inputs {
+		if input.TxID == nil {
+			return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParameter, Message: "Invalid parameter, missing txid key"}
+		}
+		if input.Vout == nil {
+			return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParameter, Message: "Invalid parameter, missing vout key"}
+		}
+		in := txIn{prev: syscoinrpc.OutPoint{TxID: *input.TxID, Vout: *input.Vout}, sequence: sequence}
+		if input.Sequence != nil {
+			in.sequence = *input.Sequence
+		}
+		ins = append(ins, in)
+	}
+
+	addresses := make(map[string]bool)
+	outs := make([]txOut, 0, len(outputs))
+	for _, output := range outputs {
+		for key, value := range output {
+			if key == "data" {
+				var dataHex string
+				err := json.Unmarshal(value, &dataHex)
+				data, hexErr := hex.DecodeString(dataHex)
+				if err != nil || hexErr != nil {
+					return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParameter, Message: "Data must be hexadecimal string"}
+				}
+				var script bytes.Buffer
+				script.WriteByte(0x6a) // OP_RETURN
+				writePush(&script, data)
+				outs = append(outs, txOut{script: script.Bytes()})
+				continue
+			}
+			if addresses[key] {
+				return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParameter, Message: "Invalid parameter, duplicated address: " + key}
+			}
+			addresses[key] = true
+			var amount syscoinrpc.Amount
+			if json.Unmarshal(value, &amount) != nil || amount < 0 {
+				return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCTypeError, Message: "Invalid amount"}
+			}
+			outs = append(outs, txOut{value: amount.Satoshis(), script: addressScript(key)})
+		}
+	}
+
+	return hex.EncodeToString(serializeTx(rawTxVersion, ins, outs, uint32(lockTime))), nil
+}
+
+// writePush writes the push of data to the script, with the smallest push opcode.
+func writePush(script *bytes.Buffer, data []byte) {
+	switch {
+	case len(data) < 0x4c:
+		script.WriteByte(byte(len(data)))
+	case len(data) <= 0xff:
+		script.WriteByte(0x4c) // OP_PUSHDATA1
+		script.WriteByte(byte(len(data)))
+	case len(data) <= 0xffff:
+		script.WriteByte(0x4d) // OP_PUSHDATA2
+		binary.Write(script, binary.LittleEndian, uint16(len(data)))
+	default:
+		script.WriteByte(0x4e) // OP_PUSHDATA4
+		binary.Write(script, binary.LittleEndian, uint32(len(data)))
+	}
+	script.Write(data)
+}
+
+func decodeRawTransaction(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	tx, rpcErr := p.transaction(0)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	tx.Hex = ""
+	return tx, nil
+}
+
+func decodeScript(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	scriptHex, rpcErr := p.string(0)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	script, err := syscoinrpc.DecodeScriptHex(scriptHex, syscoinrpc.RegTest)
+	if err != nil {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParameter, Message: fmt.Sprintf("argument must be hexadecimal string (not '%s')", scriptHex)}
+	}
+	return script, nil
+}
+
+func fundRawTransaction(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	tx, rpcErr := p.transaction(0)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	var options struct {
+		ChangeAddress  string `json:"changeAddress"`
+		ChangePosition *int   `json:"changePosition"`
+	}
+	if p.has(1) && json.Unmarshal(p[1], &options) != nil {
+		return nil, errInvalidParams
+	}
+	if len(tx.Vout) == 0 {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParameter, Message: "TX must have at least one output"}
+	}
+	changePosition := len(tx.Vout)
+	if options.ChangePosition != nil {
+		changePosition = *options.ChangePosition
+		if changePosition < 0 || changePosition > len(tx.Vout) {
+			return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParameter, Message: "changePosition out of bounds"}
+		}
+	}
+	changeAddress := DefaultAddress
+	if options.ChangeAddress != "" {
+		changeAddress = options.ChangeAddress
+	}
+
+	// The wallet owns the unspent outputs paying DefaultAddress.
+	coins := s.unspentOutputs(true, true)
+	ins, outs := txParts(tx)
+	needed := txFee
+	for _, out := range outs {
+		needed += out.value
+	}
+	for _, in := range ins {
+		c, ok := coins[in.prev]
+		if !ok {
+			return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCWalletInsufficientFunds, Message: "Insufficient funds"}
+		}
+		needed -= c.out.Value.Satoshis()
+		delete(coins, in.prev)
+	}
+	walletScript := hex.EncodeToString(addressScript(DefaultAddress))
+	for _, outPoint := range sortedOutPoints(coins) {
+		if needed <= 0 {
+			break
+		}
+		if c := coins[outPoint]; c.out.ScriptPubKey.Hex == walletScript {
+			ins = append(ins, txIn{prev: outPoint, sequence: sequenceFinal})
+			needed -= c.out.Value.Satoshis()
+		}
+	}
+	if needed > 0 {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCWalletInsufficientFunds, Message: "Insufficient funds"}
+	}
+
+	if needed < 0 {
+		change := txOut{value: -needed, script: addressScript(changeAddress)}
+		outs = append(outs[:changePosition], append([]txOut{change}, outs[changePosition:]...)...)
+	} else {
+		changePosition = -1
+	}
+
+	return map[string]interface{}{
+		"hex":       hex.EncodeToString(serializeTx(uint32(tx.Version), ins, outs, uint32(tx.LockTime))),
+		"fee":       syscoinrpc.Amount(txFee),
+		"changepos": changePosition,
+	}, nil
+}
+
+func getRawTransaction(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	txID, rpcErr := p.string(0)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	verbose, rpcErr := p.verbosity(1, 0)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	raw, b := s.findTransaction(txID)
+	if raw == nil {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidAddressOrKey, Message: "No such mempool or blockchain transaction. Use gettransaction for wallet transactions."}
+	}
+	if verbose == 0 {
+		return hex.EncodeToString(raw), nil
+	}
+
+	tx, err := syscoinrpc.DecodeTransaction(raw, syscoinrpc.RegTest)
+	if err != nil {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInternalError, Message: err.Error()}
+	}
+	rawTx := syscoinrpc.RawTransaction{Transaction: *tx}
+	if b != nil {
+		rawTx.BlockHash = b.hash
+		rawTx.Confirmations = uint64(len(s.chain)) - b.height
+		rawTx.Time = uint64(b.time)
+		rawTx.BlockTime = uint64(b.time)
+	}
+	return rawTx, nil
+}
+
+func sendRawTransaction(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	tx, rpcErr := p.transaction(0)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	allowHighFees := false
+	if p.has(1) {
+		if allowHighFees, rpcErr = p.bool(1); rpcErr != nil {
+			return nil, rpcErr
+		}
+	}
+
+	raw, b := s.findTransaction(tx.TxID.String())
+	switch {
+	case b != nil:
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCVerifyAlreadyInChain, Message: "transaction already in block chain"}
+	case raw != nil:
+		return tx.TxID, nil
+	}
+
+	coins := s.unspentOutputs(true, true)
+	chainCoins := s.unspentOutputs(false, true)
+	fee := int64(0)
+	for _, vin := range tx.Vin {
+		outPoint := syscoinrpc.OutPoint{TxID: vin.TxID, Vout: vin.Vout}
+		c, ok := coins[outPoint]
+		if !ok {
+			if _, ok := chainCoins[outPoint]; ok {
+				return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCVerifyRejected, Message: "txn-mempool-conflict (code 18)"}
+			}
+			return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCVerifyError, Message: "Missing inputs"}
+		}
+		fee += c.out.Value.Satoshis()
+	}
+	for _, vout := range tx.Vout {
+		fee -= vout.Value.Satoshis()
+	}
+	switch {
+	case fee < txFee:
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCVerifyRejected, Message: fmt.Sprintf("min relay fee not met, %d < %d (code 66)", fee, txFee)}
+	case fee > absurdFee && !allowHighFees:
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCVerifyRejected, Message: fmt.Sprintf("absurdly-high-fee, %d > %d (code 256)", fee, absurdFee)}
+	}
+
+	rawTx, _ := hex.DecodeString(tx.Hex)
+	s.mempool[tx.TxID.String()] = rawTx
+	s.mempoolAt[tx.TxID.String()] = time.Now()
+	s.publishTransaction(tx.TxID, rawTx)
+	return tx.TxID, nil
+}
+
+// sigHashTypes are the valid signature hash types.
+var sigHashTypes = map[string]bool{
+	"ALL": true, "NONE": true, "SINGLE": true,
+	"ALL|ANYONECANPAY": true, "NONE|ANYONECANPAY": true, "SINGLE|ANYONECANPAY": true,
+}
+
+func signRawTransaction(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	tx, rpcErr := p.transaction(0)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	var prevTxs []syscoinrpc.PrevTx
+	if p.has(1) && json.Unmarshal(p[1], &prevTxs) != nil {
+		return nil, errInvalidParams
+	}
+	if p.has(2) {
+		if _, rpcErr = p.strings(2); rpcErr != nil {
+			return nil, rpcErr
+		}
+	}
+	if p.has(3) {
+		sigHashType, rpcErr := p.string(3)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		if !sigHashTypes[sigHashType] {
+			return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParameter, Message: "Invalid sighash param"}
+		}
+	}
+
+	// The fake chain has no keys: the inputs spending known outputs are
+	// considered signed as they are.
+	coins := s.unspentOutputs(true, true)
+	for _, prevTx := range prevTxs {
+		coins[syscoinrpc.OutPoint{TxID: prevTx.TxID, Vout: prevTx.Vout}] = coin{}
+	}
+	var sigErrors []map[string]interface{}
+	for _, vin := range tx.Vin {
+		if _, ok := coins[syscoinrpc.OutPoint{TxID: vin.TxID, Vout: vin.Vout}]; ok {
+			continue
+		}
+		scriptSig := ""
+		if vin.ScriptSig != nil {
+			scriptSig = vin.ScriptSig.Hex
+		}
+		sigErrors = append(sigErrors, map[string]interface{}{
+			"txid":      vin.TxID,
+			"vout":      vin.Vout,
+			"scriptSig": scriptSig,
+			"sequence":  vin.Sequence,
+			"error":     "Input not found or already spent",
+		})
+	}
+
+	result := map[string]interface{}{
+		"hex":      tx.Hex,
+		"complete": len(sigErrors) == 0,
+	}
+	if len(sigErrors) > 0 {
+		result["errors"] = sigErrors
+	}
+	return result, nil
+}
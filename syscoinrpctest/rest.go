@@ -0,0 +1,309 @@
+package syscoinrpctest
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+)
+
+// mempoolHeight is the height of the mempool outputs in `getutxos` responses.
+const mempoolHeight = 0x7fffffff
+
+// maxGetUTXOsOutPoints is the maximum number of outpoints of a `getutxos` request.
+const maxGetUTXOsOutPoints = 15
+
+// restError is an error of a REST request, sent as a text body.
+type restError struct {
+	status  int
+	message string
+}
+
+// restResponse is the response of a REST request, in every format:
+// json is encoded as JSON, raw is sent as is or hex encoded.
+type restResponse struct {
+	json interface{}
+	raw  []byte
+}
+
+// serveREST serves the REST interface, which needs no authentication.
+func (s *Server) serveREST(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.httpStatus != 0 {
+		w.WriteHeader(s.httpStatus)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/rest/")
+	format := ""
+	if i := strings.LastIndexByte(path, '.'); i >= 0 {
+		path, format = path[:i], path[i+1:]
+	}
+	parts := strings.Split(path, "/")
+
+	var resp *restResponse
+	var err *restError
+	switch {
+	case len(parts) == 2 && parts[0] == "block":
+		resp, err = s.restBlock(parts[1], true)
+	case len(parts) == 3 && parts[0] == "block" && parts[1] == "notxdetails":
+		resp, err = s.restBlock(parts[2], false)
+	case len(parts) == 3 && parts[0] == "headers":
+		resp, err = s.restHeaders(parts[1], parts[2])
+	case len(parts) == 2 && parts[0] == "tx":
+		resp, err = s.restTransaction(parts[1])
+	case len(parts) >= 1 && parts[0] == "getutxos":
+		resp, err = s.restUTXOs(parts[1:])
+	case path == "chaininfo":
+		info, _ := getBlockchainInfo(s, nil)
+		resp = &restResponse{json: info}
+	case path == "mempool/info":
+		info, _ := getMempoolInfo(s, nil)
+		resp = &restResponse{json: info}
+	case path == "mempool/contents":
+		contents, _ := getRawMempool(s, params{json.RawMessage("true")})
+		resp = &restResponse{json: contents}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		w.WriteHeader(err.status)
+		fmt.Fprintf(w, "%s\r\n", err.message)
+		return
+	}
+
+	switch {
+	case format == "json":
+		writeJSON(w, http.StatusOK, resp.json)
+	case format == "hex" && resp.raw != nil:
+		fmt.Fprintf(w, "%x\n", resp.raw)
+	case format == "bin" && resp.raw != nil:
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write(resp.raw)
+	default:
+		available := ".bin, .hex, .json"
+		if resp.raw == nil {
+			available = ".json"
+		}
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, "output format not found (available: %s)\r\n", available)
+	}
+}
+
+// parseRESTHash parses a hash of a REST path.
+func parseRESTHash(hash string) (syscoinrpc.Hash, *restError) {
+	parsed, err := syscoinrpc.ParseHash(hash)
+	if err != nil {
+		return parsed, &restError{http.StatusBadRequest, "Invalid hash: " + hash}
+	}
+	return parsed, nil
+}
+
+func (s *Server) restBlock(hash string, txDetails bool) (*restResponse, *restError) {
+	if _, err := parseRESTHash(hash); err != nil {
+		return nil, err
+	}
+	b, ok := s.byHash[hash]
+	if !ok {
+		return nil, &restError{http.StatusNotFound, hash + " not found"}
+	}
+
+	verbosity := "1"
+	if txDetails {
+		verbosity = "2"
+	}
+	res, rpcErr := getBlock(s, params{json.RawMessage(strconv.Quote(hash)), json.RawMessage(verbosity)})
+	if rpcErr != nil {
+		return nil, &restError{http.StatusInternalServerError, rpcErr.Message}
+	}
+	return &restResponse{json: res, raw: b.serialize()}, nil
+}
+
+func (s *Server) restHeaders(count string, hash string) (*restResponse, *restError) {
+	n, err := strconv.Atoi(count)
+	if err != nil || n < 1 || n > 2000 {
+		return nil, &restError{http.StatusBadRequest, "Header count out of range: " + count}
+	}
+	if _, err := parseRESTHash(hash); err != nil {
+		return nil, err
+	}
+
+	headers := []interface{}{}
+	var raw bytes.Buffer
+	b, ok := s.byHash[hash]
+	for ok && s.isActive(b) && len(headers) < n {
+		headers = append(headers, s.headerJSON(b))
+		raw.Write(b.header)
+		if b.height+1 >= uint64(len(s.chain)) {
+			break
+		}
+		b = s.chain[b.height+1]
+	}
+	return &restResponse{json: headers, raw: raw.Bytes()}, nil
+}
+
+func (s *Server) restTransaction(txID string) (*restResponse, *restError) {
+	if _, err := parseRESTHash(txID); err != nil {
+		return nil, err
+	}
+
+	raw, b := s.findTransaction(txID)
+	if raw == nil {
+		return nil, &restError{http.StatusNotFound, txID + " not found"}
+	}
+	tx, err := syscoinrpc.DecodeTransaction(raw, syscoinrpc.RegTest)
+	if err != nil {
+		return nil, &restError{http.StatusInternalServerError, err.Error()}
+	}
+	if b != nil {
+		tx.BlockHash = b.hash
+	}
+	return &restResponse{json: tx, raw: raw}, nil
+}
+
+// findTransaction returns the serialized transaction from the mempool or the
+// active chain, with the block including it, nil if not found.
+func (s *Server) findTransaction(txID string) ([]byte, *block) {
+	if raw, ok := s.mempool[txID]; ok {
+		return raw, nil
+	}
+	for _, b := range s.chain {
+		for i, id := range b.txIDs {
+			if id.String() == txID {
+				return b.txs[i], b
+			}
+		}
+	}
+	return nil, nil
+}
+
+// coin is an unspent output.
+type coin struct {
+	height uint32                // The height of the block of the output, mempoolHeight for the mempool.
+	out    syscoinrpc.VoutObject // The output.
+}
+
+func (s *Server) restUTXOs(parts []string) (*restResponse, *restError) {
+	checkMempool := len(parts) > 0 && parts[0] == "checkmempool"
+	if checkMempool {
+		parts = parts[1:]
+	}
+	if len(parts) == 0 {
+		return nil, &restError{http.StatusBadRequest, "Error: empty request"}
+	}
+	if len(parts) > maxGetUTXOsOutPoints {
+		return nil, &restError{http.StatusBadRequest, fmt.Sprintf("Error: max outpoints exceeded (max: %d, tried: %d)", maxGetUTXOsOutPoints, len(parts))}
+	}
+
+	var outPoints []syscoinrpc.OutPoint
+	for _, part := range parts {
+		i := strings.IndexByte(part, '-')
+		if i < 0 {
+			return nil, &restError{http.StatusBadRequest, "Parse error"}
+		}
+		txID, err := syscoinrpc.ParseHash(part[:i])
+		vout, voutErr := strconv.ParseUint(part[i+1:], 10, 32)
+		if err != nil || voutErr != nil {
+			return nil, &restError{http.StatusBadRequest, "Parse error"}
+		}
+		outPoints = append(outPoints, syscoinrpc.OutPoint{TxID: txID, Vout: uint32(vout)})
+	}
+
+	coins := s.unspentOutputs(checkMempool, false)
+	tip := s.chain[len(s.chain)-1]
+	bitmap := make([]byte, (len(outPoints)+7)/8)
+	bitmapString := ""
+	utxos := []interface{}{}
+	var rawCoins bytes.Buffer
+	found := 0
+	for i, outPoint := range outPoints {
+		c, ok := coins[outPoint]
+		if !ok {
+			bitmapString += "0"
+			continue
+		}
+		bitmapString += "1"
+		bitmap[i/8] |= 1 << uint(i%8)
+		found++
+		utxos = append(utxos, map[string]interface{}{
+			"height":       c.height,
+			"value":        c.out.Value,
+			"scriptPubKey": c.out.ScriptPubKey,
+		})
+		script, _ := hex.DecodeString(c.out.ScriptPubKey.Hex)
+		binary.Write(&rawCoins, binary.LittleEndian, uint32(0))
+		binary.Write(&rawCoins, binary.LittleEndian, c.height)
+		binary.Write(&rawCoins, binary.LittleEndian, int64(c.out.Value))
+		writeVarInt(&rawCoins, uint64(len(script)))
+		rawCoins.Write(script)
+	}
+
+	var raw bytes.Buffer
+	binary.Write(&raw, binary.LittleEndian, uint32(tip.height))
+	raw.Write(tip.hash[:])
+	writeVarInt(&raw, uint64(len(bitmap)))
+	raw.Write(bitmap)
+	writeVarInt(&raw, uint64(found))
+	raw.Write(rawCoins.Bytes())
+
+	return &restResponse{
+		json: map[string]interface{}{
+			"chainHeight":  tip.height,
+			"chaintipHash": tip.hash.String(),
+			"bitmap":       bitmapString,
+			"utxos":        utxos,
+		},
+		raw: raw.Bytes(),
+	}, nil
+}
+
+// unspentOutputs returns the spendable unspent outputs of the active chain, and
+// of the mempool if checkMempool is set, by outpoint. The null data outputs are
+// included if withNullData is set, as they tag the outputs paying addresses on
+// the fake chain.
+func (s *Server) unspentOutputs(checkMempool bool, withNullData bool) map[syscoinrpc.OutPoint]coin {
+	coins := make(map[syscoinrpc.OutPoint]coin)
+	apply := func(raw []byte, height uint32) {
+		tx, err := syscoinrpc.DecodeTransaction(raw, syscoinrpc.RegTest)
+		if err != nil {
+			return
+		}
+		for _, vin := range tx.Vin {
+			if !vin.IsCoinbase() {
+				delete(coins, syscoinrpc.OutPoint{TxID: vin.TxID, Vout: vin.Vout})
+			}
+		}
+		for _, vout := range tx.Vout {
+			if withNullData || vout.ScriptPubKey.Type != syscoinrpc.ScriptTypeNullData {
+				coins[syscoinrpc.OutPoint{TxID: tx.TxID, Vout: vout.N}] = coin{height: height, out: vout}
+			}
+		}
+	}
+
+	for _, b := range s.chain {
+		for _, tx := range b.txs {
+			apply(tx, uint32(b.height))
+		}
+	}
+	if checkMempool {
+		txIDs := make([]string, 0, len(s.mempool))
+		for txID := range s.mempool {
+			txIDs = append(txIDs, txID)
+		}
+		sort.Strings(txIDs)
+		for _, txID := range txIDs {
+			apply(s.mempool[txID], mempoolHeight)
+		}
+	}
+	return coins
+}
@@ -0,0 +1,411 @@
+// Package syscoinrpctest provides an in-process fake syscoind node for tests.
+//
+// The fake node speaks the JSON-RPC dialect of syscoind over HTTP and keeps
+// an in-memory regtest-like chain, so tests can run without a live node:
+//
+//     srv := syscoinrpctest.NewServer("user", "pass")
+//     defer srv.Close()
+//
+//     cl, err := srv.Client()
+//     hashes, err := cl.Generating.Generate(10, 0)
+package syscoinrpctest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+)
+
+// loggingCategories are the logging categories of the node.
+var loggingCategories = []string{
+	"net", "tor", "mempool", "http", "bench", "zmq", "db", "rpc", "estimatefee",
+	"addrman", "selectcoins", "reindex", "cmpctblock", "rand", "prune", "proxy",
+	"mempoolrej", "libevent", "coindb", "qt", "leveldb", "threadpool", "masternode",
+	"gobject", "mnpayments", "mnsync", "spork", "syscoin",
+}
+
+// Server is a fake syscoind node, serving JSON-RPC over HTTP.
+//
+// The supported methods are getbestblockhash, getblockcount, getblockhash,
+// getblock (with verbosity 0, 1 and 2), getblockchaininfo, getblockheader,
+// getblockstats, getchaintips, getchaintxstats, getdifficulty, getmempoolinfo,
+// getrawmempool, gettxout, gettxoutproof, gettxoutsetinfo, verifychain,
+// verifytxoutproof, generate, generatetoaddress, getmininginfo, getnetworkhashps,
+// getblocktemplate, submitblock, prioritisetransaction, createauxblock,
+// getauxblock, submitauxblock, addnode, clearbanned, disconnectnode,
+// getaddednodeinfo, getconnectioncount, getnettotals, getnetworkinfo, getpeerinfo,
+// listbanned, ping, setban, setnetworkactive, createrawtransaction,
+// decoderawtransaction, decodescript, fundrawtransaction, getrawtransaction,
+// sendrawtransaction, signrawtransaction, createmultisig, estimatefee,
+// estimatesmartfee, estimatepriority, validateaddress, verifymessage,
+// signmessagewithprivkey, getmemoryinfo, help, uptime, logging and stop.
+// Notifications are published with SetPublisher, and inbound peers are
+// simulated with ConnectPeer.
+//
+// The fake chain has no keys: the outputs pay OP_RETURN scripts tagged with
+// their address, the wallet owns the outputs paying DefaultAddress, and the
+// message signatures are deterministic values, only made with DefaultPrivKey.
+// The fee and priority estimates need as many blocks as the confirmation target.
+//
+// The REST interface is served too, without authentication, like with `-rest`.
+type Server struct {
+	*httptest.Server // The underlying HTTP server, its URL is the node URL.
+
+	user     string // The RPC Username.
+	password string // The RPC Password.
+
+	mu         sync.Mutex                      // The lock of the fields below.
+	chain      []*block                        // The active chain, by height.
+	byHash     map[string]*block               // The blocks, by display hash.
+	mempool    map[string][]byte               // The raw mempool transactions, by ID.
+	mempoolAt  map[string]time.Time            // The time transactions entered the mempool.
+	feeDeltas  map[string]int64                // The fee deltas set by `prioritisetransaction`, by ID.
+	auxBlocks  map[string]*block               // The blocks created by `createauxblock`, by display hash.
+	peers      []*peer                         // The connected peers.
+	nextPeerID uint64                          // The node ID of the next peer.
+	addedNodes []string                        // The nodes added with `addnode add`.
+	bans       map[string]*ban                 // The banned subnets, by subnet.
+	network    bool                            // True if the P2P network is active.
+	logging    map[string]bool                 // The logging categories status.
+	errors     map[string]*syscoinrpc.RPCError // The errors injected per method.
+	httpStatus int                             // The injected HTTP status, 0 for none.
+	started    time.Time                       // The start time of the server.
+	stopped    bool                            // True after a `stop` call.
+	forks      uint32                          // The number of reorganizations, the nonce of new blocks.
+	publisher  *Publisher                      // The ZMQ publisher of the notifications, nil for none.
+}
+
+// NewServer starts a new fake node, with a chain made of the genesis block only.
+//
+// Requests must authenticate with the given RPC Username and Password.
+func NewServer(user string, password string) *Server {
+	s := &Server{
+		user:      user,
+		password:  password,
+		byHash:    make(map[string]*block),
+		mempool:   make(map[string][]byte),
+		mempoolAt: make(map[string]time.Time),
+		feeDeltas: make(map[string]int64),
+		auxBlocks: make(map[string]*block),
+		bans:      make(map[string]*ban),
+		network:   true,
+		logging:   make(map[string]bool),
+		errors:    make(map[string]*syscoinrpc.RPCError),
+		started:   time.Now(),
+	}
+	for _, category := range loggingCategories {
+		s.logging[category] = false
+	}
+	s.appendBlock(DefaultAddress)
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns a new client connected to the server.
+func (s *Server) Client(options ...syscoinrpc.ClientOption) (*syscoinrpc.Client, error) {
+	return syscoinrpc.NewClient(s.URL, s.user, s.password, options...)
+}
+
+// Height returns the height of the chain tip.
+func (s *Server) Height() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return uint64(len(s.chain) - 1)
+}
+
+// BlockHash returns the hash of the block at height, zero if out of range.
+func (s *Server) BlockHash(height uint64) syscoinrpc.Hash {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if height >= uint64(len(s.chain)) {
+		return syscoinrpc.Hash{}
+	}
+	return s.chain[height].hash
+}
+
+// Generate mines n blocks, including the mempool transactions in the first one.
+// It returns the hashes of the new blocks.
+func (s *Server) Generate(n int) []syscoinrpc.Hash {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.generate(n, DefaultAddress)
+}
+
+// Reorganize replaces the last depth blocks of the active chain with n new blocks,
+// like `invalidateblock` followed by `generate` do, and returns the hashes of the
+// new blocks.
+//
+// The disconnected blocks stay known as a fork, and their transactions go back to
+// the mempool, to be included in the first new block. The genesis block is never
+// disconnected.
+func (s *Server) Reorganize(depth int, n int) []syscoinrpc.Hash {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if depth > len(s.chain)-1 {
+		depth = len(s.chain) - 1
+	}
+	disconnected := s.chain[len(s.chain)-depth:]
+	s.chain = s.chain[:len(s.chain)-depth]
+	for _, b := range disconnected {
+		for i, tx := range b.txs[1:] {
+			txID := b.txIDs[i+1].String()
+			s.mempool[txID] = tx
+			s.mempoolAt[txID] = time.Now()
+		}
+	}
+	s.forks++
+
+	return s.generate(n, DefaultAddress)
+}
+
+// AddRawTransaction adds the serialized transaction to the mempool and returns its ID.
+func (s *Server) AddRawTransaction(rawTx []byte) syscoinrpc.Hash {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	txID := doubleSHA256(rawTx)
+	s.mempool[txID.String()] = rawTx
+	s.mempoolAt[txID.String()] = time.Now()
+	s.publishTransaction(txID, rawTx)
+	return txID
+}
+
+// SetPublisher makes the node publish its notifications on pub, like the
+// `zmqpub<topic>` options do: the hashblock and rawblock topics for every
+// new block, the hashtx and rawtx topics for every transaction added to the
+// mempool or included in a new block.
+func (s *Server) SetPublisher(pub *Publisher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.publisher = pub
+}
+
+// publishBlock publishes the notifications of a new block.
+func (s *Server) publishBlock(b *block) {
+	if s.publisher == nil {
+		return
+	}
+	s.publisher.Publish(syscoinrpc.TopicHashBlock, displayBytes(b.hash))
+	s.publisher.Publish(syscoinrpc.TopicRawBlock, b.serialize())
+	for i, tx := range b.txs {
+		s.publishTransaction(b.txIDs[i], tx)
+	}
+}
+
+// publishTransaction publishes the notifications of a transaction.
+func (s *Server) publishTransaction(txID syscoinrpc.Hash, rawTx []byte) {
+	if s.publisher == nil {
+		return
+	}
+	s.publisher.Publish(syscoinrpc.TopicHashTx, displayBytes(txID))
+	s.publisher.Publish(syscoinrpc.TopicRawTx, rawTx)
+}
+
+// displayBytes returns the bytes of the hash in display byte order.
+func displayBytes(hash syscoinrpc.Hash) []byte {
+	raw, _ := hex.DecodeString(hash.String())
+	return raw
+}
+
+// SetError makes every following call to method fail with the given error,
+// until ClearErrors is called.
+func (s *Server) SetError(method string, code syscoinrpc.RPCErrorCode, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors[method] = &syscoinrpc.RPCError{Code: code, Message: message}
+}
+
+// SetHTTPStatus makes every following request fail with the given HTTP status
+// and an empty body, until ClearErrors is called.
+func (s *Server) SetHTTPStatus(status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.httpStatus = status
+}
+
+// ClearErrors removes all the injected errors.
+func (s *Server) ClearErrors() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors = make(map[string]*syscoinrpc.RPCError)
+	s.httpStatus = 0
+}
+
+// Stopped returns true if the node received a `stop` call.
+func (s *Server) Stopped() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stopped
+}
+
+// appendBlock mines a block on top of the tip, including the mempool transactions.
+func (s *Server) appendBlock(address string) *block {
+	var prev syscoinrpc.Hash
+	if len(s.chain) > 0 {
+		prev = s.chain[len(s.chain)-1].hash
+	}
+
+	txIDs := make([]string, 0, len(s.mempool))
+	for txID := range s.mempool {
+		txIDs = append(txIDs, txID)
+	}
+	sort.Strings(txIDs)
+	txs := make([][]byte, 0, len(txIDs))
+	for _, txID := range txIDs {
+		txs = append(txs, s.mempool[txID])
+	}
+
+	b := newBlock(uint64(len(s.chain)), prev, blockVersion, s.forks, address, txs)
+	s.connectBlock(b)
+	return b
+}
+
+// connectBlock appends the block to the active chain, removing its transactions
+// from the mempool.
+func (s *Server) connectBlock(b *block) {
+	for _, txID := range b.txIDs {
+		delete(s.mempool, txID.String())
+		delete(s.mempoolAt, txID.String())
+		delete(s.feeDeltas, txID.String())
+	}
+	s.chain = append(s.chain, b)
+	s.byHash[b.hash.String()] = b
+	s.publishBlock(b)
+}
+
+func (s *Server) generate(n int, address string) []syscoinrpc.Hash {
+	hashes := make([]syscoinrpc.Hash, 0, n)
+	for i := 0; i < n; i++ {
+		hashes = append(hashes, s.appendBlock(address).hash)
+	}
+	return hashes
+}
+
+// request is a JSON-RPC request received by the server.
+type request struct {
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+	ID     json.RawMessage   `json:"id"`
+}
+
+// response is a JSON-RPC response sent by the server.
+type response struct {
+	Result interface{}          `json:"result"`
+	Error  *syscoinrpc.RPCError `json:"error"`
+	ID     json.RawMessage      `json:"id"`
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/rest/") {
+		s.serveREST(w, r)
+		return
+	}
+
+	user, password, ok := r.BasicAuth()
+	if !ok || user != s.user || password != s.password {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	s.mu.Lock()
+	httpStatus := s.httpStatus
+	s.mu.Unlock()
+	if httpStatus != 0 {
+		w.WriteHeader(httpStatus)
+		return
+	}
+
+	var body json.RawMessage
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, response{
+			Error: &syscoinrpc.RPCError{Code: syscoinrpc.RPCParseError, Message: "Parse error"},
+		})
+		return
+	}
+
+	// Batch request.
+	var requests []request
+	if json.Unmarshal(body, &requests) == nil {
+		responses := make([]response, 0, len(requests))
+		for _, req := range requests {
+			responses = append(responses, s.handle(req))
+		}
+		writeJSON(w, http.StatusOK, responses)
+		return
+	}
+
+	var req request
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, response{
+			Error: &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidRequest, Message: "Invalid Request object"},
+		})
+		return
+	}
+
+	resp := s.handle(req)
+	status := http.StatusOK
+	if resp.Error != nil {
+		status = http.StatusInternalServerError
+		if resp.Error.Code == syscoinrpc.RPCMethodNotFound {
+			status = http.StatusNotFound
+		}
+	}
+	writeJSON(w, status, resp)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// handle performs the call and builds its response.
+func (s *Server) handle(req request) response {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := response{ID: req.ID}
+	if injected, ok := s.errors[req.Method]; ok {
+		resp.Error = &syscoinrpc.RPCError{Code: injected.Code, Message: injected.Message}
+		return resp
+	}
+
+	handler, ok := handlers[req.Method]
+	if !ok {
+		resp.Error = &syscoinrpc.RPCError{Code: syscoinrpc.RPCMethodNotFound, Message: "Method not found"}
+		return resp
+	}
+
+	result, err := handler(s, params(req.Params))
+	if err != nil {
+		resp.Error = err
+		return resp
+	}
+	resp.Result = result
+	return resp
+}
+
+// blockByHash returns the block with the given display hash.
+func (s *Server) blockByHash(hash string) (*block, *syscoinrpc.RPCError) {
+	if len(hash) != 64 {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParameter, Message: "blockhash must be of length 64"}
+	}
+	if _, err := hex.DecodeString(hash); err != nil {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParameter, Message: "blockhash must be hexadecimal string"}
+	}
+	b, ok := s.byHash[hash]
+	if !ok {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidAddressOrKey, Message: "Block not found"}
+	}
+	return b, nil
+}
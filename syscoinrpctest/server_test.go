@@ -0,0 +1,180 @@
+package syscoinrpctest_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+	"github.com/thebotguys/golang-syscoin-rpc-client/syscoinrpctest"
+)
+
+func TestServerAuthInvalid(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	defer srv.Close()
+
+	cl, err := syscoinrpc.NewClient(srv.URL, "user", "wrong")
+	require.NoError(t, err, "Must have no error on creation")
+
+	_, err = cl.Blockchain.GetBlockCount()
+	require.True(t, syscoinrpc.IsUnauthorized(err), "Must enforce basic auth, got %v", err)
+}
+
+func TestServerChainOK(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	defer srv.Close()
+
+	cl, err := srv.Client()
+	require.NoError(t, err, "Must have no error on creation")
+
+	count, err := cl.Blockchain.GetBlockCount()
+	require.NoError(t, err, "GetBlockCount: must not error")
+	require.Equal(t, uint64(0), count, "Must start with the genesis block only")
+
+	hashes, err := cl.Generating.Generate(3, 0)
+	require.NoError(t, err, "Generate: must not error")
+	require.Len(t, hashes, 3)
+
+	hashes2, err := cl.Generating.GenerateToAddress(2, "SaaxXq67HhzPbsKNNJBQeQK5qf5Hpv8qq2", 0)
+	require.NoError(t, err, "GenerateToAddress: must not error")
+	require.Len(t, hashes2, 2)
+	require.Equal(t, uint64(5), srv.Height())
+
+	best, err := cl.Blockchain.GetBestBlockHash()
+	require.NoError(t, err, "GetBestBlockHash: must not error")
+	require.Equal(t, hashes2[1], best)
+
+	header, err := cl.Blockchain.GetFullBlockHeader(hashes[1])
+	require.NoError(t, err, "GetFullBlockHeader: must not error")
+	require.Equal(t, uint64(2), header.Height)
+	require.Equal(t, 4, header.Confirmations)
+	require.Equal(t, hashes[0], header.PreviousBlockHash)
+	require.Equal(t, hashes[2], header.NextBlockHash)
+
+	block, err := cl.Blockchain.GetFullBlock(hashes[1])
+	require.NoError(t, err, "GetFullBlock: must not error")
+	require.Len(t, block.Tx, 1, "Must contain the coinbase")
+	require.Equal(t, block.MerkleRoot, block.Tx[0], "Merkle root of a single transaction is its ID")
+
+	raw, err := cl.Blockchain.GetBlock(hashes[1])
+	require.NoError(t, err, "GetBlock: must not error")
+	require.NotEmpty(t, raw)
+
+	withTxs, err := cl.Blockchain.GetBlockWithTransactions(hashes[1])
+	require.NoError(t, err, "GetBlockWithTransactions: must not error")
+	require.Len(t, withTxs.Tx, 1)
+	require.Equal(t, block.Tx[0], withTxs.Tx[0].TxID)
+	require.True(t, withTxs.Tx[0].Vin[0].IsCoinbase())
+
+	tips, err := cl.Blockchain.GetChainTips()
+	require.NoError(t, err, "GetChainTips: must not error")
+	require.Len(t, tips, 1)
+	require.Equal(t, best, tips[0].Hash)
+	require.Equal(t, "active", tips[0].Status)
+
+	_, err = cl.Blockchain.GetFullBlock(syscoinrpc.Hash{})
+	require.True(t, syscoinrpc.IsNotFound(err), "Must error on unknown blocks")
+}
+
+func TestServerMempoolOK(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	defer srv.Close()
+
+	cl, err := srv.Client()
+	require.NoError(t, err, "Must have no error on creation")
+
+	txID := srv.AddRawTransaction([]byte{0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00})
+
+	pool, err := cl.Blockchain.GetRawMempool()
+	require.NoError(t, err, "GetRawMempool: must not error")
+	require.Equal(t, []syscoinrpc.Hash{txID}, pool)
+
+	poolFull, err := cl.Blockchain.GetRawMempoolFull()
+	require.NoError(t, err, "GetRawMempoolFull: must not error")
+	require.Contains(t, poolFull, txID)
+
+	hashes := srv.Generate(1)
+	block, err := cl.Blockchain.GetFullBlock(hashes[0])
+	require.NoError(t, err, "GetFullBlock: must not error")
+	require.Equal(t, txID, block.Tx[1], "Must mine the mempool transactions")
+
+	pool, err = cl.Blockchain.GetRawMempool()
+	require.NoError(t, err, "GetRawMempool: must not error")
+	require.Empty(t, pool, "Must empty the mempool")
+}
+
+func TestServerReorganizeOK(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	defer srv.Close()
+
+	cl, err := srv.Client()
+	require.NoError(t, err, "Must have no error on creation")
+
+	srv.Generate(3)
+	txID := srv.AddRawTransaction([]byte{0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00})
+	stale := srv.Generate(1)[0]
+
+	hashes := srv.Reorganize(2, 3)
+	require.Len(t, hashes, 3)
+	require.Equal(t, uint64(5), srv.Height())
+	require.Equal(t, hashes[0], srv.BlockHash(3))
+
+	header, err := cl.Blockchain.GetFullBlockHeader(stale)
+	require.NoError(t, err, "Must keep the disconnected blocks")
+	require.Equal(t, -1, header.Confirmations, "Disconnected blocks must not be confirmed")
+	require.True(t, header.NextBlockHash.IsZero())
+
+	block, err := cl.Blockchain.GetFullBlock(hashes[0])
+	require.NoError(t, err)
+	require.Contains(t, block.Tx, txID, "Must mine the disconnected transactions again")
+
+	tips, err := cl.Blockchain.GetChainTips()
+	require.NoError(t, err, "GetChainTips: must not error")
+	require.Len(t, tips, 2)
+	require.Equal(t, "valid-fork", tips[1].Status)
+	require.Equal(t, stale, tips[1].Hash)
+	require.Equal(t, uint64(2), tips[1].BranchLen)
+}
+
+func TestServerControlOK(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	defer srv.Close()
+
+	cl, err := srv.Client()
+	require.NoError(t, err, "Must have no error on creation")
+
+	_, err = cl.Control.GetUptime()
+	require.NoError(t, err, "GetUptime: must not error")
+
+	loggings, err := cl.Control.Logging([]string{"syscoin"}, []string{"tor"})
+	require.NoError(t, err, "Logging: must not error")
+	require.True(t, loggings["syscoin"])
+	require.False(t, loggings["tor"])
+
+	require.NoError(t, cl.Control.StopServer(), "StopServer: must not error")
+	require.True(t, srv.Stopped())
+}
+
+func TestServerErrorsOK(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	defer srv.Close()
+
+	cl, err := srv.Client()
+	require.NoError(t, err, "Must have no error on creation")
+
+	srv.SetError("getblockcount", syscoinrpc.RPCInWarmup, "Loading block index...")
+	_, err = cl.Blockchain.GetBlockCount()
+	require.True(t, syscoinrpc.IsWarmup(err), "Must return the injected error")
+
+	srv.SetHTTPStatus(http.StatusServiceUnavailable)
+	_, err = cl.Blockchain.GetBestBlockHash()
+	require.True(t, errors.Is(err, syscoinrpc.ErrWorkQueueExceeded), "Must return the injected status")
+
+	srv.ClearErrors()
+	_, err = cl.Blockchain.GetBlockCount()
+	require.NoError(t, err, "Must not error after clearing the errors")
+
+	err = cl.Blockchain.SaveMempool()
+	require.True(t, syscoinrpc.IsMethodNotFound(err), "Must error on unsupported methods")
+}
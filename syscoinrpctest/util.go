@@ -0,0 +1,370 @@
+package syscoinrpctest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+)
+
+const (
+	// estimateFeeRate is the conservative fee rate estimated by the fake node, in satoshis per kB.
+	estimateFeeRate int64 = 20000
+	// priorityEstimate is the priority estimated by the fake node, the minimum priority of free transactions.
+	priorityEstimate = 57600000.0
+	// maxConfTarget is the highest confirmation target of `estimatesmartfee`.
+	maxConfTarget = 1008
+	// maxMultisigKeys is the maximum number of keys of a multisignature address.
+	maxMultisigKeys = 16
+)
+
+// Address version bytes and segwit prefixes, of both the main and the regtest
+// networks as DefaultAddress is a main network address.
+var (
+	pubKeyHashVersions = map[byte]bool{63: true, 65: true}
+	scriptHashVersions = map[byte]bool{5: true, 196: true}
+	bech32HRPs         = map[string]bool{"sys": true, "scrt": true}
+)
+
+// decodedAddress is a decoded address.
+type decodedAddress struct {
+	script         []byte // The output script paying the address.
+	isScript       bool   // True for P2SH and P2WSH addresses.
+	witnessVersion int    // The witness version of segwit addresses, -1 for others.
+	witnessProgram []byte // The witness program of segwit addresses.
+}
+
+// decodeAddress returns the decoded address, nil if it is not valid.
+func decodeAddress(address string) *decodedAddress {
+	if i := strings.LastIndexByte(address, '1'); i > 0 && bech32HRPs[strings.ToLower(address[:i])] {
+		return decodeBech32Address(address)
+	}
+
+	payload := decodeBase58Check(address)
+	if len(payload) != 21 {
+		return nil
+	}
+	hash := payload[1:]
+	switch {
+	case pubKeyHashVersions[payload[0]]:
+		script := append(append([]byte{0x76, 0xa9, 20}, hash...), 0x88, 0xac) // OP_DUP OP_HASH160 <hash> OP_EQUALVERIFY OP_CHECKSIG
+		return &decodedAddress{script: script, witnessVersion: -1}
+	case scriptHashVersions[payload[0]]:
+		script := append(append([]byte{0xa9, 20}, hash...), 0x87) // OP_HASH160 <hash> OP_EQUAL
+		return &decodedAddress{script: script, isScript: true, witnessVersion: -1}
+	}
+	return nil
+}
+
+// base58Alphabet is the alphabet of base58 encoded addresses.
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// decodeBase58Check returns the payload with its version byte, nil if the
+// encoding or the checksum is not valid.
+func decodeBase58Check(encoded string) []byte {
+	value := new(big.Int)
+	base := big.NewInt(58)
+	for i := 0; i < len(encoded); i++ {
+		digit := strings.IndexByte(base58Alphabet, encoded[i])
+		if digit < 0 {
+			return nil
+		}
+		value.Mul(value, base)
+		value.Add(value, big.NewInt(int64(digit)))
+	}
+	zeros := 0
+	for zeros < len(encoded) && encoded[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+	data := append(make([]byte, zeros), value.Bytes()...)
+	if len(data) < 5 {
+		return nil
+	}
+	payload, checksum := data[:len(data)-4], data[len(data)-4:]
+	expected := doubleSHA256(payload)
+	if !bytes.Equal(expected[:4], checksum) {
+		return nil
+	}
+	return payload
+}
+
+// bech32Charset is the alphabet of bech32 encoded addresses.
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// decodeBech32Address returns the decoded segwit address (BIP173), nil if it is not valid.
+func decodeBech32Address(address string) *decodedAddress {
+	if strings.ToLower(address) != address && strings.ToUpper(address) != address {
+		return nil
+	}
+	address = strings.ToLower(address)
+	sep := strings.LastIndexByte(address, '1')
+	hrp, encoded := address[:sep], address[sep+1:]
+	if len(encoded) < 7 {
+		return nil
+	}
+
+	values := make([]byte, 0, 2*len(hrp)+1+len(encoded))
+	for i := 0; i < len(hrp); i++ {
+		values = append(values, hrp[i]>>5)
+	}
+	values = append(values, 0)
+	for i := 0; i < len(hrp); i++ {
+		values = append(values, hrp[i]&31)
+	}
+	data := make([]byte, 0, len(encoded))
+	for i := 0; i < len(encoded); i++ {
+		d := strings.IndexByte(bech32Charset, encoded[i])
+		if d < 0 {
+			return nil
+		}
+		data = append(data, byte(d))
+	}
+	if bech32Polymod(append(values, data...)) != 1 {
+		return nil
+	}
+	data = data[:len(data)-6]
+
+	// Convert the program from 5 to 8 bits groups, without padding.
+	version := data[0]
+	var program []byte
+	var acc, bits uint
+	for _, d := range data[1:] {
+		acc = (acc<<5 | uint(d)) & 0xfff
+		bits += 5
+		if bits >= 8 {
+			bits -= 8
+			program = append(program, byte(acc>>bits))
+		}
+	}
+	if bits >= 5 || acc&(1<<bits-1) != 0 {
+		return nil
+	}
+	if version > 16 || len(program) < 2 || len(program) > 40 || (version == 0 && len(program) != 20 && len(program) != 32) {
+		return nil
+	}
+
+	opVersion := version
+	if version > 0 {
+		opVersion += 0x50 // OP_1 to OP_16
+	}
+	return &decodedAddress{
+		script:         append([]byte{opVersion, byte(len(program))}, program...),
+		isScript:       version == 0 && len(program) == 32,
+		witnessVersion: int(version),
+		witnessProgram: program,
+	}
+}
+
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+// messageSignature returns the signature of the message by the key of the
+// address, a deterministic value in place of a real compact signature.
+func messageSignature(address string, message string) string {
+	first := doubleSHA256([]byte(address + "\n" + message))
+	second := doubleSHA256([]byte(message + "\n" + address))
+	signature := append(append([]byte{0x1f}, first[:]...), second[:]...)
+	return base64.StdEncoding.EncodeToString(signature)
+}
+
+func createMultisig(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	nRequired, rpcErr := p.int64(0, 0)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	keys, rpcErr := p.strings(1)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	switch {
+	case nRequired < 1:
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCMiscError, Message: "a multisignature address must require at least one key to redeem"}
+	case int64(len(keys)) < nRequired:
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCMiscError, Message: fmt.Sprintf("not enough keys supplied (got %d keys, but need at least %d to redeem)", len(keys), nRequired)}
+	case len(keys) > maxMultisigKeys:
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCMiscError, Message: "Number of keys involved in the multisignature address creation > 16\nReduce the number"}
+	}
+
+	var script bytes.Buffer
+	script.WriteByte(0x50 + byte(nRequired)) // OP_m
+	for _, key := range keys {
+		pubKey, err := hex.DecodeString(key)
+		if err != nil || !(len(pubKey) == 33 && (pubKey[0] == 2 || pubKey[0] == 3)) && !(len(pubKey) == 65 && pubKey[0] == 4) {
+			return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidAddressOrKey, Message: "Invalid public key: " + key}
+		}
+		writePush(&script, pubKey)
+	}
+	script.WriteByte(0x50 + byte(len(keys))) // OP_n
+	script.WriteByte(0xae)                   // OP_CHECKMULTISIG
+
+	decoded, err := syscoinrpc.DecodeScript(script.Bytes(), syscoinrpc.RegTest)
+	if err != nil {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInternalError, Message: err.Error()}
+	}
+	return map[string]interface{}{
+		"address":      decoded.P2SH,
+		"redeemScript": hex.EncodeToString(script.Bytes()),
+	}, nil
+}
+
+// hasEstimate returns true if the fake node has seen enough blocks to
+// estimate for the confirmation target.
+func (s *Server) hasEstimate(nBlocks int64) bool {
+	return nBlocks <= int64(len(s.chain)-1)
+}
+
+func estimateFee(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	if !p.has(0) {
+		return nil, errInvalidParams
+	}
+	nBlocks, rpcErr := p.int64(0, 0)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if nBlocks < 1 {
+		nBlocks = 1
+	}
+	if !s.hasEstimate(nBlocks) {
+		return -1, nil
+	}
+	return syscoinrpc.Amount(estimateFeeRate), nil
+}
+
+func estimateSmartFee(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	if !p.has(0) {
+		return nil, errInvalidParams
+	}
+	confTarget, rpcErr := p.int64(0, 0)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if confTarget < 1 || confTarget > maxConfTarget {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParameter, Message: fmt.Sprintf("Invalid conf_target, must be between %d - %d", 1, maxConfTarget)}
+	}
+	feeRate := estimateFeeRate
+	if p.has(1) {
+		mode, rpcErr := p.string(1)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		switch syscoinrpc.EstimateMode(mode) {
+		case syscoinrpc.EstimateModeEconomical:
+			feeRate /= 2
+		case syscoinrpc.EstimateModeUnset, syscoinrpc.EstimateModeConservative:
+		default:
+			return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidParameter, Message: "Invalid estimate_mode parameter"}
+		}
+	}
+
+	if !s.hasEstimate(confTarget) {
+		return map[string]interface{}{
+			"errors": []string{"Insufficient data or no feerate found"},
+			"blocks": 0,
+		}, nil
+	}
+	return map[string]interface{}{
+		"feerate": syscoinrpc.Amount(feeRate),
+		"blocks":  confTarget,
+	}, nil
+}
+
+func estimatePriority(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	if !p.has(0) {
+		return nil, errInvalidParams
+	}
+	nBlocks, rpcErr := p.int64(0, 0)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if nBlocks < 1 {
+		nBlocks = 1
+	}
+	if !s.hasEstimate(nBlocks) {
+		return -1, nil
+	}
+	return priorityEstimate, nil
+}
+
+func validateAddress(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	address, rpcErr := p.string(0)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	decoded := decodeAddress(address)
+	if decoded == nil {
+		return map[string]interface{}{"isvalid": false}, nil
+	}
+
+	result := map[string]interface{}{
+		"isvalid":      true,
+		"address":      address,
+		"scriptPubKey": hex.EncodeToString(decoded.script),
+		"ismine":       address == DefaultAddress,
+		"iswatchonly":  false,
+		"isscript":     decoded.isScript,
+		"iswitness":    decoded.witnessVersion >= 0,
+	}
+	if decoded.witnessVersion >= 0 {
+		result["witness_version"] = decoded.witnessVersion
+		result["witness_program"] = hex.EncodeToString(decoded.witnessProgram)
+	}
+	return result, nil
+}
+
+func verifyMessage(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	address, rpcErr := p.string(0)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	signature, rpcErr := p.string(1)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	message, rpcErr := p.string(2)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	decoded := decodeAddress(address)
+	switch {
+	case decoded == nil:
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCTypeError, Message: "Invalid address"}
+	case decoded.isScript || decoded.witnessVersion >= 0:
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCTypeError, Message: "Address does not refer to key"}
+	}
+	if _, err := base64.StdEncoding.DecodeString(signature); err != nil {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidAddressOrKey, Message: "Malformed base64 encoding"}
+	}
+	return signature == messageSignature(address, message), nil
+}
+
+func signMessageWithPrivKey(s *Server, p params) (interface{}, *syscoinrpc.RPCError) {
+	privKey, rpcErr := p.string(0)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	message, rpcErr := p.string(1)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if privKey != DefaultPrivKey {
+		return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCInvalidAddressOrKey, Message: "Invalid private key"}
+	}
+	return messageSignature(DefaultAddress, message), nil
+}
@@ -0,0 +1,87 @@
+package syscoinrpc
+
+import "fmt"
+
+// Transaction represents a fully decoded transaction, as returned by
+// verbose `getblock` and `getrawtransaction` calls.
+type Transaction struct {
+	// Hex is the Hex representation of the Tx.
+	Hex string `json:"hex,required"`
+	// TxID is the transaction ID.
+	TxID Hash `json:"txid,required"`
+	// Hash is the transaction hash, it differs from TxID for witness transactions.
+	Hash Hash `json:"hash"`
+	// Size is the serialized transaction size.
+	Size uint64 `json:"size,required"`
+	// VSize is the virtual transaction size, it differs from Size for witness transactions.
+	VSize uint64 `json:"vsize"`
+	// Version is the transaction version.
+	Version uint64 `json:"version,required"`
+	// LockTime is the time (expressed as UNIX Timestamp) or block height
+	// until which the transaction is locked.
+	LockTime uint64 `json:"locktime,required"`
+	// Vin is the array of transaction vin objects.
+	Vin []VinObject `json:"vin,required"`
+	// Vout is the array of transaction vout objects.
+	Vout []VoutObject `json:"vout,required"`
+	// BlockHash is the hash of the block containing the transaction,
+	// only set for the AuxPoW coinbase and `getrawtransaction` calls.
+	BlockHash Hash `json:"blockhash"`
+}
+
+// AuxPowTx represents a block reward transaction.
+//
+// Deprecated: use Transaction, AuxPowTx is kept for compatibility.
+type AuxPowTx = Transaction
+
+// VinObject represents a vin (value input) object of a transaction.
+type VinObject struct {
+	// Coinbase is the coinbase script in hex, only set for coinbase inputs.
+	Coinbase string `json:"coinbase,omitempty"`
+	// TxID is the ID of the transaction of the spent output, zero for coinbase inputs.
+	TxID Hash `json:"txid"`
+	// Vout is the index of the spent output in its transaction.
+	Vout uint32 `json:"vout"`
+	// ScriptSig is the signature script, nil for coinbase inputs.
+	ScriptSig *ScriptSig `json:"scriptSig,omitempty"`
+	// Witness is the hex-encoded witness stack, empty for non witness inputs.
+	Witness []string `json:"txinwitness,omitempty"`
+	// Sequence is the sequence number of the vin object.
+	Sequence uint64 `json:"sequence,required"`
+}
+
+// IsCoinbase returns true if the input is the input of a coinbase transaction.
+func (vin *VinObject) IsCoinbase() bool {
+	return vin.Coinbase != ""
+}
+
+// ScriptSig represents the signature script of an input.
+type ScriptSig struct {
+	// Asm is the ASM code of the script.
+	Asm string `json:"asm,required"`
+	// Hex is the Hex of the script.
+	Hex string `json:"hex,required"`
+}
+
+// VoutObject represents a vout (value output) object of a transaction.
+type VoutObject struct {
+	// Value is the value of the output.
+	Value Amount `json:"value,required"`
+	// N is the index of the output in the transaction.
+	N uint32 `json:"n,required"`
+	// ScriptPubKey is the PubKey script in the output.
+	ScriptPubKey ScriptPubKey `json:"scriptPubKey,required"`
+}
+
+// OutPoint references an output of a transaction.
+type OutPoint struct {
+	// TxID is the ID of the transaction.
+	TxID Hash `json:"txid"`
+	// Vout is the index of the output in the transaction.
+	Vout uint32 `json:"vout"`
+}
+
+// String returns the outpoint as `txid-vout`, like the REST interface expects it.
+func (op OutPoint) String() string {
+	return fmt.Sprintf("%s-%d", op.TxID, op.Vout)
+}
@@ -0,0 +1,63 @@
+package syscoinrpc
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Transport performs the RPC calls of a Client.
+//
+// The default transport speaks JSON-RPC over HTTP with the node, custom
+// transports (e.g. fakes or recorders) can be used with NewClientWithTransport.
+type Transport interface {
+	// Call performs the RPC call and returns its raw JSON result.
+	// Errors returned by the node should be *RPCError.
+	Call(ctx context.Context, method string, params []interface{}) (json.RawMessage, error)
+}
+
+// TransportFunc is an adapter to use ordinary functions as Transport.
+type TransportFunc func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error)
+
+// Call calls f(ctx, method, params).
+func (f TransportFunc) Call(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+	return f(ctx, method, params)
+}
+
+// RPCCall represents a single call of a batch.
+type RPCCall struct {
+	// Method is the name of the called method.
+	Method string
+	// Params are the params of the call.
+	Params []interface{}
+	// Result is the raw JSON result, valid only if Err is nil.
+	Result json.RawMessage
+	// Err is the error of the call.
+	Err error
+}
+
+// BatchTransport is a Transport able to send multiple calls at once.
+//
+// Batches sent through transports not implementing it are performed
+// one call at a time.
+type BatchTransport interface {
+	Transport
+	// CallBatch performs all the calls, setting their Result or Err.
+	// The returned error only concerns the whole batch.
+	CallBatch(ctx context.Context, calls []*RPCCall) error
+}
+
+// callBatch performs the calls through the transport, one at a time if
+// it does not support batches.
+func callBatch(ctx context.Context, transport Transport, calls []*RPCCall) error {
+	if bt, ok := transport.(BatchTransport); ok {
+		return bt.CallBatch(ctx, calls)
+	}
+
+	for _, call := range calls {
+		call.Result, call.Err = transport.Call(ctx, call.Method, call.Params)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+	return nil
+}
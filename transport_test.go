@@ -0,0 +1,66 @@
+package syscoinrpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+)
+
+// fakeTransport answers with canned results, keyed by method.
+func fakeTransport(results map[string]string, calls *[]string) syscoinrpc.TransportFunc {
+	return func(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+		*calls = append(*calls, method)
+		result, ok := results[method]
+		if !ok {
+			return nil, &syscoinrpc.RPCError{Code: syscoinrpc.RPCMethodNotFound, Message: "Method not found"}
+		}
+		return json.RawMessage(result), nil
+	}
+}
+
+func TestNewClientWithTransportInvalid(t *testing.T) {
+	_, err := syscoinrpc.NewClientWithTransport(nil)
+	require.Equal(t, syscoinrpc.ErrNilTransport, err, "Must error without transport")
+
+	var calls []string
+	cl, err := syscoinrpc.NewClientWithTransport(fakeTransport(nil, &calls))
+	require.NoError(t, err, "Must have no error on creation")
+
+	_, err = cl.Control.GetUptime()
+	require.True(t, syscoinrpc.IsMethodNotFound(err), "Must return the transport error")
+
+	var rpcErr *syscoinrpc.RPCError
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, "uptime", rpcErr.Method, "Must set the method of the error")
+}
+
+func TestNewClientWithTransportOK(t *testing.T) {
+	var calls []string
+	cl, err := syscoinrpc.NewClientWithTransport(fakeTransport(map[string]string{
+		"uptime":       "3600",
+		"getblockhash": `"9f362bce7390fb38dfa0f98c11fb9a5158aeb280f29c8f6cb5ef43d916173bf1"`,
+	}, &calls))
+	require.NoError(t, err, "Must have no error on creation")
+
+	uptime, err := cl.Control.GetUptime()
+	require.NoError(t, err, "GetUptime: must not error")
+	require.Equal(t, uint64(3600), uptime)
+
+	// Transports without batch support perform the calls one at a time.
+	batch := cl.NewBatch()
+	hash := batch.Blockchain.GetBlockHash(1)
+	count := batch.Blockchain.GetBlockCount()
+	require.NoError(t, batch.Send(), "Send: must not error")
+
+	h, err := hash.Result()
+	require.NoError(t, err, "GetBlockHash: must not error")
+	require.Equal(t, syscoinrpc.MustParseHash("9f362bce7390fb38dfa0f98c11fb9a5158aeb280f29c8f6cb5ef43d916173bf1"), h)
+
+	_, err = count.Result()
+	require.True(t, syscoinrpc.IsMethodNotFound(err), "GetBlockCount: must report its own error")
+
+	require.Equal(t, []string{"uptime", "getblockhash", "getblockcount"}, calls)
+}
@@ -0,0 +1,283 @@
+package syscoinrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// UtilClient wraps all `util` related functions.
+type UtilClient struct {
+	c *Client // The binded client, must not be nil.
+}
+
+func (uc *UtilClient) do(ctx context.Context, method string, params ...interface{}) (json.RawMessage, error) {
+	return uc.c.do(ctx, method, params...)
+}
+
+// ErrNoEstimate is returned by EstimateFee and EstimatePriority when the node
+// has not seen enough blocks and transactions to estimate.
+var ErrNoEstimate = errors.New("Insufficient data to estimate")
+
+// MultisigAddress represents a multisignature address, as returned by a `createmultisig` call.
+type MultisigAddress struct {
+	// Address is the P2SH address of the multisignature script.
+	Address string `json:"address,required"`
+	// RedeemScript is the multisignature script, in hex.
+	RedeemScript string `json:"redeemScript,required"`
+}
+
+// CreateMultisig creates a multisignature address requiring nRequired of the keys to spend.
+// The address is not added to the wallet.
+//
+//     nRequired : The number of required signatures.
+//     keys      : The public keys, in hex.
+func (uc *UtilClient) CreateMultisig(nRequired uint32, keys []string) (*MultisigAddress, error) {
+	return uc.CreateMultisigContext(context.Background(), nRequired, keys)
+}
+
+// CreateMultisigContext is like CreateMultisig but uses the given context for the call.
+func (uc *UtilClient) CreateMultisigContext(ctx context.Context, nRequired uint32, keys []string) (*MultisigAddress, error) {
+	if keys == nil {
+		keys = []string{}
+	}
+
+	response, err := uc.do(ctx, "createmultisig", nRequired, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	var multisig MultisigAddress
+	err = json.Unmarshal(response, &multisig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &multisig, nil
+}
+
+// EstimateFee returns the fee per kB a transaction needs to begin confirmation
+// within nBlocks blocks.
+//
+// It returns ErrNoEstimate if the node has not enough data to estimate.
+//
+//     nBlocks : The confirmation target, in blocks.
+func (uc *UtilClient) EstimateFee(nBlocks uint32) (Amount, error) {
+	return uc.EstimateFeeContext(context.Background(), nBlocks)
+}
+
+// EstimateFeeContext is like EstimateFee but uses the given context for the call.
+func (uc *UtilClient) EstimateFeeContext(ctx context.Context, nBlocks uint32) (Amount, error) {
+	response, err := uc.do(ctx, "estimatefee", nBlocks)
+	if err != nil {
+		return 0, err
+	}
+
+	var feeRate Amount
+	err = json.Unmarshal(response, &feeRate)
+	if err != nil {
+		return 0, err
+	}
+	// The node returns -1 when it cannot estimate.
+	if feeRate < 0 {
+		return 0, ErrNoEstimate
+	}
+
+	return feeRate, nil
+}
+
+// EstimateMode is the fee estimation mode of `estimatesmartfee` calls.
+type EstimateMode string
+
+const (
+	// EstimateModeUnset selects the default mode of the node, EstimateModeConservative.
+	EstimateModeUnset EstimateMode = "UNSET"
+	// EstimateModeEconomical estimates from the recent blocks only, responding
+	// faster to fee drops but possibly too low.
+	EstimateModeEconomical EstimateMode = "ECONOMICAL"
+	// EstimateModeConservative estimates from a longer history of blocks, more
+	// likely to meet the confirmation target.
+	EstimateModeConservative EstimateMode = "CONSERVATIVE"
+)
+
+// SmartFeeEstimate represents the result of an `estimatesmartfee` call.
+type SmartFeeEstimate struct {
+	// FeeRate is the estimated fee per kB, zero if no estimate was found.
+	FeeRate Amount `json:"feerate,omitempty"`
+	// Errors are the errors encountered during the estimation, if any.
+	Errors []string `json:"errors,omitempty"`
+	// Blocks is the confirmation target of the estimate, which may differ
+	// from the requested one when no estimate was found for it.
+	Blocks uint64 `json:"blocks,required"`
+}
+
+// EstimateSmartFee returns the fee per kB a transaction needs to begin
+// confirmation within confTarget blocks, and the confirmation target of the estimate.
+//
+//     confTarget : The confirmation target, in blocks (1 - 1008).
+//     mode       : The estimation mode, empty for the default one.
+func (uc *UtilClient) EstimateSmartFee(confTarget uint32, mode EstimateMode) (*SmartFeeEstimate, error) {
+	return uc.EstimateSmartFeeContext(context.Background(), confTarget, mode)
+}
+
+// EstimateSmartFeeContext is like EstimateSmartFee but uses the given context for the call.
+func (uc *UtilClient) EstimateSmartFeeContext(ctx context.Context, confTarget uint32, mode EstimateMode) (*SmartFeeEstimate, error) {
+	params := []interface{}{confTarget}
+	if mode != "" {
+		params = append(params, mode)
+	}
+
+	response, err := uc.do(ctx, "estimatesmartfee", params...)
+	if err != nil {
+		return nil, err
+	}
+
+	var estimate SmartFeeEstimate
+	err = json.Unmarshal(response, &estimate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &estimate, nil
+}
+
+// EstimatePriority returns the priority a zero fee transaction needs to begin
+// confirmation within nBlocks blocks.
+//
+// It returns ErrNoEstimate if the node has not enough data to estimate.
+//
+//     nBlocks : The confirmation target, in blocks.
+func (uc *UtilClient) EstimatePriority(nBlocks uint32) (float64, error) {
+	return uc.EstimatePriorityContext(context.Background(), nBlocks)
+}
+
+// EstimatePriorityContext is like EstimatePriority but uses the given context for the call.
+func (uc *UtilClient) EstimatePriorityContext(ctx context.Context, nBlocks uint32) (float64, error) {
+	response, err := uc.do(ctx, "estimatepriority", nBlocks)
+	if err != nil {
+		return 0, err
+	}
+
+	var priority float64
+	err = json.Unmarshal(response, &priority)
+	if err != nil {
+		return 0, err
+	}
+	// The node returns -1 when it cannot estimate.
+	if priority < 0 {
+		return 0, ErrNoEstimate
+	}
+
+	return priority, nil
+}
+
+// ValidatedAddress represents the result of a `validateaddress` call.
+//
+// Only IsValid is set for invalid addresses, and the fields about the keys
+// and scripts of the wallet are only set for addresses of the wallet.
+type ValidatedAddress struct {
+	// IsValid is true if the address is valid.
+	IsValid bool `json:"isvalid,required"`
+	// Address is the validated address.
+	Address string `json:"address"`
+	// ScriptPubKey is the output script paying the address, in hex.
+	ScriptPubKey string `json:"scriptPubKey"`
+	// IsMine is true if the address belongs to the wallet.
+	IsMine bool `json:"ismine"`
+	// IsWatchOnly is true if the address is watched by the wallet.
+	IsWatchOnly bool `json:"iswatchonly"`
+	// IsScript is true if the address pays a script (P2SH or P2WSH).
+	IsScript bool `json:"isscript"`
+	// IsWitness is true if the address is a segwit address.
+	IsWitness bool `json:"iswitness"`
+	// WitnessVersion is the witness version of segwit addresses.
+	WitnessVersion uint32 `json:"witness_version,omitempty"`
+	// WitnessProgram is the witness program of segwit addresses, in hex.
+	WitnessProgram string `json:"witness_program,omitempty"`
+	// Script is the type of the redeem script of the P2SH addresses of the wallet.
+	Script string `json:"script,omitempty"`
+	// Hex is the redeem script of the P2SH addresses of the wallet, in hex.
+	Hex string `json:"hex,omitempty"`
+	// Addresses are the addresses of the redeem script of the P2SH addresses of the wallet.
+	Addresses []string `json:"addresses,omitempty"`
+	// SigsRequired is the number of signatures required to spend multisignature addresses of the wallet.
+	SigsRequired uint32 `json:"sigsrequired,omitempty"`
+	// PubKey is the public key of the P2PKH addresses of the wallet, in hex.
+	PubKey string `json:"pubkey,omitempty"`
+	// IsCompressed is true if PubKey is compressed.
+	IsCompressed bool `json:"iscompressed,omitempty"`
+}
+
+// ValidateAddress returns information about the given address.
+//
+//     address : The address to validate.
+func (uc *UtilClient) ValidateAddress(address string) (*ValidatedAddress, error) {
+	return uc.ValidateAddressContext(context.Background(), address)
+}
+
+// ValidateAddressContext is like ValidateAddress but uses the given context for the call.
+func (uc *UtilClient) ValidateAddressContext(ctx context.Context, address string) (*ValidatedAddress, error) {
+	response, err := uc.do(ctx, "validateaddress", address)
+	if err != nil {
+		return nil, err
+	}
+
+	var validated ValidatedAddress
+	err = json.Unmarshal(response, &validated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &validated, nil
+}
+
+// VerifyMessage returns true if the signature of the message was made by the
+// key of the address.
+//
+//     address   : The P2PKH address of the key.
+//     signature : The signature, in base64, see SignMessageWithPrivKey.
+//     message   : The signed message.
+func (uc *UtilClient) VerifyMessage(address string, signature string, message string) (bool, error) {
+	return uc.VerifyMessageContext(context.Background(), address, signature, message)
+}
+
+// VerifyMessageContext is like VerifyMessage but uses the given context for the call.
+func (uc *UtilClient) VerifyMessageContext(ctx context.Context, address string, signature string, message string) (bool, error) {
+	response, err := uc.do(ctx, "verifymessage", address, signature, message)
+	if err != nil {
+		return false, err
+	}
+
+	var verified bool
+	err = json.Unmarshal(response, &verified)
+	if err != nil {
+		return false, err
+	}
+
+	return verified, nil
+}
+
+// SignMessageWithPrivKey signs the message with the private key, and returns
+// the signature in base64.
+//
+//     privKey : The private key (WIF).
+//     message : The message to sign.
+func (uc *UtilClient) SignMessageWithPrivKey(privKey string, message string) (string, error) {
+	return uc.SignMessageWithPrivKeyContext(context.Background(), privKey, message)
+}
+
+// SignMessageWithPrivKeyContext is like SignMessageWithPrivKey but uses the given context for the call.
+func (uc *UtilClient) SignMessageWithPrivKeyContext(ctx context.Context, privKey string, message string) (string, error) {
+	response, err := uc.do(ctx, "signmessagewithprivkey", privKey, message)
+	if err != nil {
+		return "", err
+	}
+
+	var signature string
+	err = json.Unmarshal(response, &signature)
+	if err != nil {
+		return "", err
+	}
+
+	return signature, nil
+}
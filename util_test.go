@@ -0,0 +1,264 @@
+package syscoinrpc_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+	"github.com/thebotguys/golang-syscoin-rpc-client/syscoinrpctest"
+)
+
+var testPubKeys = []string{
+	"02" + strings.Repeat("44", 32),
+	"03" + strings.Repeat("55", 32),
+}
+
+// testKeyHashScript returns the decoded P2PKH script of a test key hash,
+// whose addresses and segwit versions are valid regtest addresses.
+func testKeyHashScript(t *testing.T) *syscoinrpc.DecodedScript {
+	script, err := syscoinrpc.DecodeScriptHex("76a914"+strings.Repeat("22", 20)+"88ac", syscoinrpc.RegTest)
+	require.NoError(t, err)
+	return script
+}
+
+func TestCreateMultisigInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.Util.CreateMultisig(1, testPubKeys)
+	require.Error(t, err, "Must error on any method with invalid URL")
+
+	cl = newFakeClient(t)
+	_, err = cl.Util.CreateMultisig(0, testPubKeys)
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCMiscError), "Must error without required signatures, got %v", err)
+	_, err = cl.Util.CreateMultisig(3, testPubKeys)
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCMiscError), "Must error on missing keys, got %v", err)
+	_, err = cl.Util.CreateMultisig(1, nil)
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCMiscError), "Must error on missing keys, got %v", err)
+	_, err = cl.Util.CreateMultisig(1, []string{"02"})
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCInvalidAddressOrKey), "Must error on invalid public keys, got %v", err)
+}
+
+func TestCreateMultisigOK(t *testing.T) {
+	cl := newFakeClient(t)
+
+	multisig, err := cl.Util.CreateMultisig(1, testPubKeys)
+	require.NoError(t, err, "CreateMultisig : must not error")
+	require.Equal(t, "51"+"21"+testPubKeys[0]+"21"+testPubKeys[1]+"52"+"ae", multisig.RedeemScript)
+
+	script, err := syscoinrpc.DecodeScriptHex(multisig.RedeemScript, syscoinrpc.RegTest)
+	require.NoError(t, err)
+	require.Equal(t, syscoinrpc.ScriptTypeMultiSig, script.Type)
+	require.Equal(t, uint64(1), script.RequiredSignatures)
+	require.Equal(t, script.P2SH, multisig.Address, "Must return the P2SH address of the redeem script")
+
+	t.Log("CreateMultisig :", multisig)
+}
+
+func TestEstimateFeeInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.Util.EstimateFee(6)
+	require.Error(t, err, "Must error on any method with invalid URL")
+
+	cl = newFakeClient(t)
+	_, err = cl.Util.EstimateFee(25)
+	require.True(t, errors.Is(err, syscoinrpc.ErrNoEstimate), "Must error without enough blocks, got %v", err)
+}
+
+func TestEstimateFeeOK(t *testing.T) {
+	cl := newFakeClient(t)
+
+	feeRate, err := cl.Util.EstimateFee(6)
+	require.NoError(t, err, "EstimateFee : must not error")
+	require.Equal(t, syscoinrpc.Amount(20000), feeRate)
+
+	t.Log("EstimateFee :", feeRate)
+}
+
+func TestEstimateSmartFeeInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.Util.EstimateSmartFee(6, "")
+	require.Error(t, err, "Must error on any method with invalid URL")
+
+	cl = newFakeClient(t)
+	_, err = cl.Util.EstimateSmartFee(0, "")
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCInvalidParameter), "Must error on invalid targets, got %v", err)
+	_, err = cl.Util.EstimateSmartFee(1009, syscoinrpc.EstimateModeEconomical)
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCInvalidParameter), "Must error on invalid targets, got %v", err)
+	_, err = cl.Util.EstimateSmartFee(6, "FAST")
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCInvalidParameter), "Must error on invalid modes, got %v", err)
+}
+
+func TestEstimateSmartFeeOK(t *testing.T) {
+	cl := newFakeClient(t)
+
+	estimate, err := cl.Util.EstimateSmartFee(6, "")
+	require.NoError(t, err, "EstimateSmartFee : must not error")
+	require.Equal(t, syscoinrpc.Amount(20000), estimate.FeeRate)
+	require.Equal(t, uint64(6), estimate.Blocks)
+	require.Empty(t, estimate.Errors)
+
+	conservative, err := cl.Util.EstimateSmartFee(6, syscoinrpc.EstimateModeConservative)
+	require.NoError(t, err, "EstimateSmartFee : must not error")
+	require.Equal(t, estimate, conservative, "Must default to the conservative mode")
+	economical, err := cl.Util.EstimateSmartFee(6, syscoinrpc.EstimateModeEconomical)
+	require.NoError(t, err, "EstimateSmartFee : must not error")
+	require.Equal(t, syscoinrpc.Amount(10000), economical.FeeRate)
+
+	noEstimate, err := cl.Util.EstimateSmartFee(25, syscoinrpc.EstimateModeUnset)
+	require.NoError(t, err, "Must not error without estimate")
+	require.Zero(t, noEstimate.FeeRate)
+	require.NotEmpty(t, noEstimate.Errors, "Must report why there is no estimate")
+
+	t.Log("EstimateSmartFee :", estimate)
+}
+
+func TestEstimatePriorityInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.Util.EstimatePriority(6)
+	require.Error(t, err, "Must error on any method with invalid URL")
+
+	cl = newFakeClient(t)
+	_, err = cl.Util.EstimatePriority(25)
+	require.True(t, errors.Is(err, syscoinrpc.ErrNoEstimate), "Must error without enough blocks, got %v", err)
+}
+
+func TestEstimatePriorityOK(t *testing.T) {
+	cl := newFakeClient(t)
+
+	priority, err := cl.Util.EstimatePriority(6)
+	require.NoError(t, err, "EstimatePriority : must not error")
+	require.Equal(t, 57600000.0, priority)
+
+	t.Log("EstimatePriority :", priority)
+}
+
+func TestValidateAddressInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.Util.ValidateAddress(syscoinrpctest.DefaultAddress)
+	require.Error(t, err, "Must error on any method with invalid URL")
+}
+
+func TestValidateAddressOK(t *testing.T) {
+	cl := newFakeClient(t)
+
+	validated, err := cl.Util.ValidateAddress(syscoinrpctest.DefaultAddress)
+	require.NoError(t, err, "ValidateAddress : must not error")
+	require.True(t, validated.IsValid)
+	require.Equal(t, syscoinrpctest.DefaultAddress, validated.Address)
+	require.True(t, validated.IsMine, "Must own the default address")
+	require.False(t, validated.IsScript)
+	require.False(t, validated.IsWitness)
+	require.True(t, strings.HasPrefix(validated.ScriptPubKey, "76a914"), "Must pay a P2PKH script")
+
+	script := testKeyHashScript(t)
+	validated, err = cl.Util.ValidateAddress(script.Addresses[0])
+	require.NoError(t, err, "ValidateAddress : must not error")
+	require.True(t, validated.IsValid)
+	require.False(t, validated.IsMine)
+	require.Equal(t, "76a914"+strings.Repeat("22", 20)+"88ac", validated.ScriptPubKey)
+
+	validated, err = cl.Util.ValidateAddress(script.P2SH)
+	require.NoError(t, err, "ValidateAddress : must not error")
+	require.True(t, validated.IsValid)
+	require.True(t, validated.IsScript)
+	require.False(t, validated.IsWitness)
+
+	validated, err = cl.Util.ValidateAddress(script.Segwit.Addresses[0])
+	require.NoError(t, err, "ValidateAddress : must not error")
+	require.True(t, validated.IsValid)
+	require.True(t, validated.IsWitness)
+	require.False(t, validated.IsScript)
+	require.Equal(t, uint32(0), validated.WitnessVersion)
+	require.Equal(t, strings.Repeat("22", 20), validated.WitnessProgram)
+	require.Equal(t, script.Segwit.Hex, validated.ScriptPubKey)
+
+	multisig, err := syscoinrpc.DecodeScriptHex("5121"+testPubKeys[0]+"51ae", syscoinrpc.RegTest)
+	require.NoError(t, err)
+	validated, err = cl.Util.ValidateAddress(multisig.Segwit.Addresses[0])
+	require.NoError(t, err, "ValidateAddress : must not error")
+	require.True(t, validated.IsWitness)
+	require.True(t, validated.IsScript, "P2WSH addresses pay scripts")
+
+	for _, address := range []string{"", "notanaddress", syscoinrpctest.DefaultAddress[:len(syscoinrpctest.DefaultAddress)-1] + "3", strings.ToUpper(script.Segwit.Addresses[0][:6]) + script.Segwit.Addresses[0][6:]} {
+		validated, err = cl.Util.ValidateAddress(address)
+		require.NoError(t, err, "Must not error on invalid addresses")
+		require.False(t, validated.IsValid, "Must not validate %q", address)
+		require.Empty(t, validated.ScriptPubKey)
+	}
+
+	t.Log("ValidateAddress :", validated)
+}
+
+func TestVerifyMessageInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.Util.VerifyMessage(syscoinrpctest.DefaultAddress, "", "message")
+	require.Error(t, err, "Must error on any method with invalid URL")
+
+	cl = newFakeClient(t)
+	_, err = cl.Util.VerifyMessage("notanaddress", "", "message")
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCTypeError), "Must error on invalid addresses, got %v", err)
+	_, err = cl.Util.VerifyMessage(testKeyHashScript(t).P2SH, "", "message")
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCTypeError), "Must error on script addresses, got %v", err)
+	_, err = cl.Util.VerifyMessage(syscoinrpctest.DefaultAddress, "not base64!", "message")
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCInvalidAddressOrKey), "Must error on malformed signatures, got %v", err)
+}
+
+func TestVerifyMessageOK(t *testing.T) {
+	cl := newFakeClient(t)
+
+	signature, err := cl.Util.SignMessageWithPrivKey(syscoinrpctest.DefaultPrivKey, "message")
+	require.NoError(t, err)
+
+	verified, err := cl.Util.VerifyMessage(syscoinrpctest.DefaultAddress, signature, "message")
+	require.NoError(t, err, "VerifyMessage : must not error")
+	require.True(t, verified)
+
+	verified, err = cl.Util.VerifyMessage(syscoinrpctest.DefaultAddress, signature, "other message")
+	require.NoError(t, err, "VerifyMessage : must not error")
+	require.False(t, verified, "Must not verify other messages")
+
+	verified, err = cl.Util.VerifyMessage(testKeyHashScript(t).Addresses[0], signature, "message")
+	require.NoError(t, err, "VerifyMessage : must not error")
+	require.False(t, verified, "Must not verify other addresses")
+
+	t.Log("VerifyMessage :", verified)
+}
+
+func TestSignMessageWithPrivKeyInvalid(t *testing.T) {
+	cl, err := syscoinrpc.NewClient(invalidURL, "", "", testTimeout)
+	require.NoError(t, err, "Must have no error on creation, even with invalid URL")
+
+	_, err = cl.Util.SignMessageWithPrivKey(syscoinrpctest.DefaultPrivKey, "message")
+	require.Error(t, err, "Must error on any method with invalid URL")
+
+	cl = newFakeClient(t)
+	_, err = cl.Util.SignMessageWithPrivKey("notakey", "message")
+	require.True(t, syscoinrpc.IsRPCError(err, syscoinrpc.RPCInvalidAddressOrKey), "Must error on invalid keys, got %v", err)
+}
+
+func TestSignMessageWithPrivKeyOK(t *testing.T) {
+	cl := newFakeClient(t)
+
+	signature, err := cl.Util.SignMessageWithPrivKey(syscoinrpctest.DefaultPrivKey, "message")
+	require.NoError(t, err, "SignMessageWithPrivKey : must not error")
+	require.NotEmpty(t, signature)
+
+	again, err := cl.Util.SignMessageWithPrivKey(syscoinrpctest.DefaultPrivKey, "message")
+	require.NoError(t, err, "SignMessageWithPrivKey : must not error")
+	require.Equal(t, signature, again, "Must sign deterministically")
+
+	t.Log("SignMessageWithPrivKey :", signature)
+}
@@ -0,0 +1,327 @@
+package syscoinrpc
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ZMQ topics published by the node, enabled with the `zmqpub<topic>` options.
+const (
+	// TopicHashBlock is the topic of the hashes of the new tips of the active chain.
+	TopicHashBlock = "hashblock"
+	// TopicHashTx is the topic of the IDs of the transactions added to the mempool or connected in a block.
+	TopicHashTx = "hashtx"
+	// TopicRawBlock is the topic of the serialized new tips of the active chain.
+	TopicRawBlock = "rawblock"
+	// TopicRawTx is the topic of the serialized transactions added to the mempool or connected in a block.
+	TopicRawTx = "rawtx"
+)
+
+// ErrUnknownTopic is returned when subscribing to a topic the node does not publish.
+var ErrUnknownTopic = errors.New("Unknown ZMQ topic")
+
+// ErrInvalidNotification is sent on the Errors channel of a Subscriber
+// when a message of the node is malformed.
+var ErrInvalidNotification = errors.New("Invalid ZMQ notification")
+
+// Subscriber defaults.
+const (
+	// DefaultPollInterval is the default interval of the polling of a Subscriber without ZMQ endpoints.
+	DefaultPollInterval = 10 * time.Second
+	// DefaultReconnectInterval is the default delay before a Subscriber reconnects to an endpoint.
+	DefaultReconnectInterval = time.Second
+)
+
+// Notification represents a message published by the node.
+type Notification struct {
+	// Topic is the topic of the message, one of the Topic constants.
+	Topic string
+	// Sequence is the sequence number of the message in the topic.
+	Sequence uint32
+	// Missed is the number of messages of the topic that have been dropped
+	// before this one, because the subscriber was too slow or disconnected.
+	// When it is not 0, the state of the node should be queried again.
+	Missed uint32
+	// Hash is the hash of the block or the ID of the transaction.
+	Hash Hash
+	// Block is the decoded block, for TopicRawBlock only.
+	Block *BlockWithTransactions
+	// Transaction is the decoded transaction, for TopicRawTx only.
+	Transaction *Transaction
+	// Raw is the body of the message: the hash in display byte order
+	// or the serialized block or transaction.
+	Raw []byte
+}
+
+// SubscriberConfig configures a Subscriber.
+type SubscriberConfig struct {
+	// Endpoints are the ZMQ endpoints of the topics to subscribe to, the values
+	// of the `zmqpub<topic>` options of the node (e.g. TopicHashBlock: "tcp://127.0.0.1:28332").
+	// Topics published on the same endpoint share the connection.
+	//
+	// When empty, the subscriber polls GetBestBlockHash instead,
+	// publishing TopicHashBlock notifications only.
+	Endpoints map[string]string
+	// Network is the network of the node, used to decode the raw topics.
+	Network string
+	// PollInterval is the interval of the polling without endpoints, default DefaultPollInterval.
+	PollInterval time.Duration
+	// ReconnectInterval is the delay before reconnecting to an endpoint, default DefaultReconnectInterval.
+	ReconnectInterval time.Duration
+	// BufferSize is the size of the buffer of the Notifications channel.
+	BufferSize int
+}
+
+// Subscriber receives the notifications published by the node on its ZMQ endpoints.
+//
+// The connections are re-established when lost, the notifications published
+// meanwhile are lost, as reported by Notification.Missed.
+type Subscriber struct {
+	notifications chan *Notification // The delivered notifications.
+	errs          chan error         // The reported errors.
+	cancel        context.CancelFunc // Stops the subscriber.
+	wg            sync.WaitGroup     // Waits for the goroutines of the subscriber.
+}
+
+// Subscribe starts receiving the notifications of the node, until ctx is
+// cancelled or the subscriber is closed.
+//
+//     ctx : The context of the subscriber, cancelling it closes the subscriber.
+//     cfg : The configuration of the subscriber.
+func (bic *BlockchainClient) Subscribe(ctx context.Context, cfg SubscriberConfig) (*Subscriber, error) {
+	endpoints := make(map[string][]string)
+	for topic, endpoint := range cfg.Endpoints {
+		switch topic {
+		case TopicHashBlock, TopicHashTx:
+		case TopicRawBlock, TopicRawTx:
+			if _, ok := networkAddressParams[cfg.Network]; !ok {
+				return nil, fmt.Errorf("%w: %q", ErrUnknownNetwork, cfg.Network)
+			}
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownTopic, topic)
+		}
+		_, _, err := zmtpEndpointAddress(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		endpoints[endpoint] = append(endpoints[endpoint], topic)
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+	if cfg.ReconnectInterval <= 0 {
+		cfg.ReconnectInterval = DefaultReconnectInterval
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Subscriber{
+		notifications: make(chan *Notification, cfg.BufferSize),
+		errs:          make(chan error, 16),
+		cancel:        cancel,
+	}
+	if len(endpoints) == 0 {
+		s.wg.Add(1)
+		go s.poll(ctx, bic, cfg.PollInterval)
+	}
+	for endpoint, topics := range endpoints {
+		s.wg.Add(1)
+		go s.receive(ctx, endpoint, topics, cfg)
+	}
+	go func() {
+		s.wg.Wait()
+		close(s.notifications)
+		close(s.errs)
+	}()
+
+	return s, nil
+}
+
+// Notifications returns the channel of the notifications, closed once the subscriber is closed.
+// The notifications of a topic are delivered in order.
+func (s *Subscriber) Notifications() <-chan *Notification {
+	return s.notifications
+}
+
+// Errors returns the channel of the errors met by the subscriber, like lost connections
+// and malformed messages, closed once the subscriber is closed. They do not stop the
+// subscriber. The errors are dropped while the channel is full.
+func (s *Subscriber) Errors() <-chan error {
+	return s.errs
+}
+
+// Close stops the subscriber and waits for its connections to be closed.
+func (s *Subscriber) Close() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+// report sends err on the Errors channel, unless it is full.
+func (s *Subscriber) report(err error) {
+	select {
+	case s.errs <- err:
+	default:
+	}
+}
+
+// deliver sends the notification, it returns false once ctx is done.
+func (s *Subscriber) deliver(ctx context.Context, n *Notification) bool {
+	select {
+	case s.notifications <- n:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// receive receives the topics published on the endpoint, reconnecting until ctx is done.
+func (s *Subscriber) receive(ctx context.Context, endpoint string, topics []string, cfg SubscriberConfig) {
+	defer s.wg.Done()
+
+	sequences := make(map[string]uint32) // The last sequence number of each topic.
+	for {
+		err := s.receiveConn(ctx, endpoint, topics, cfg.Network, sequences)
+		if ctx.Err() != nil {
+			return
+		}
+		s.report(fmt.Errorf("%s: %w", endpoint, err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(cfg.ReconnectInterval):
+		}
+	}
+}
+
+// receiveConn receives the topics on a single connection to the endpoint.
+func (s *Subscriber) receiveConn(ctx context.Context, endpoint string, topics []string, network string, sequences map[string]uint32) error {
+	conn, err := dialZMTP(ctx, endpoint, "SUB")
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-stop:
+		}
+	}()
+
+	for _, topic := range topics {
+		err = conn.subscribe(topic)
+		if err != nil {
+			return err
+		}
+	}
+
+	for {
+		parts, err := conn.readMessage()
+		if err != nil {
+			return err
+		}
+		n, err := parseNotification(parts, network)
+		if err != nil {
+			s.report(fmt.Errorf("%s: %w", endpoint, err))
+			continue
+		}
+		if last, ok := sequences[n.Topic]; ok && n.Sequence > last {
+			n.Missed = n.Sequence - last - 1
+		}
+		sequences[n.Topic] = n.Sequence
+		if !s.deliver(ctx, n) {
+			return ctx.Err()
+		}
+	}
+}
+
+// parseNotification parses a message of the node, made of the topic,
+// the body and the little endian sequence number.
+func parseNotification(parts [][]byte, network string) (*Notification, error) {
+	if len(parts) != 3 || len(parts[2]) != 4 {
+		return nil, fmt.Errorf("%w: expected topic, body and sequence number, got %d parts", ErrInvalidNotification, len(parts))
+	}
+	n := &Notification{
+		Topic:    string(parts[0]),
+		Sequence: binary.LittleEndian.Uint32(parts[2]),
+		Raw:      parts[1],
+	}
+
+	var err error
+	switch n.Topic {
+	case TopicHashBlock, TopicHashTx:
+		if len(n.Raw) != HashSize {
+			return nil, fmt.Errorf("%w: %s: %d bytes hash", ErrInvalidNotification, n.Topic, len(n.Raw))
+		}
+		for i := range n.Hash {
+			n.Hash[i] = n.Raw[HashSize-1-i]
+		}
+	case TopicRawBlock:
+		n.Block, err = DecodeBlock(n.Raw, network)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidNotification, n.Topic, err)
+		}
+		n.Hash = n.Block.Hash
+	case TopicRawTx:
+		n.Transaction, err = DecodeTransaction(n.Raw, network)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidNotification, n.Topic, err)
+		}
+		n.Hash = n.Transaction.TxID
+	default:
+		return nil, fmt.Errorf("%w: unexpected topic %q", ErrInvalidNotification, n.Topic)
+	}
+
+	return n, nil
+}
+
+// poll publishes a TopicHashBlock notification every time the best block changes.
+func (s *Subscriber) poll(ctx context.Context, bic *BlockchainClient, interval time.Duration) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var (
+		best     Hash
+		sequence uint32
+		started  bool
+	)
+	for {
+		hash, err := bic.GetBestBlockHashContext(ctx)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			s.report(err)
+		case !started:
+			// The current tip is the starting point, like for the node
+			// which only publishes the following blocks.
+			best, started = hash, true
+		case hash != best:
+			best = hash
+			n := &Notification{Topic: TopicHashBlock, Sequence: sequence, Hash: hash}
+			n.Raw = make([]byte, HashSize)
+			for i := range hash {
+				n.Raw[i] = hash[HashSize-1-i]
+			}
+			sequence++
+			if !s.deliver(ctx, n) {
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package syscoinrpc_test
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	syscoinrpc "github.com/thebotguys/golang-syscoin-rpc-client"
+	"github.com/thebotguys/golang-syscoin-rpc-client/syscoinrpctest"
+)
+
+// nextNotification returns the next notification of the subscriber, failing after a timeout.
+func nextNotification(t *testing.T, sub *syscoinrpc.Subscriber) *syscoinrpc.Notification {
+	select {
+	case n, ok := <-sub.Notifications():
+		require.True(t, ok, "The notifications must not be closed")
+		return n
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "Timed out waiting for a notification")
+	}
+	return nil
+}
+
+func TestSubscribeInvalid(t *testing.T) {
+	cl := newFakeClient(t)
+	ctx := context.Background()
+
+	_, err := cl.Blockchain.Subscribe(ctx, syscoinrpc.SubscriberConfig{
+		Endpoints: map[string]string{"hashwallettx": "tcp://127.0.0.1:28332"},
+	})
+	require.True(t, errors.Is(err, syscoinrpc.ErrUnknownTopic), "Must error on unknown topics, got %v", err)
+
+	_, err = cl.Blockchain.Subscribe(ctx, syscoinrpc.SubscriberConfig{
+		Endpoints: map[string]string{syscoinrpc.TopicRawBlock: "tcp://127.0.0.1:28332"},
+	})
+	require.True(t, errors.Is(err, syscoinrpc.ErrUnknownNetwork), "Must error on raw topics without network, got %v", err)
+
+	_, err = cl.Blockchain.Subscribe(ctx, syscoinrpc.SubscriberConfig{
+		Endpoints: map[string]string{syscoinrpc.TopicHashBlock: "http://127.0.0.1:28332"},
+	})
+	require.Error(t, err, "Must error on unsupported endpoints")
+
+	pub := syscoinrpctest.NewPublisher()
+	t.Cleanup(pub.Close)
+	sub, err := cl.Blockchain.Subscribe(ctx, syscoinrpc.SubscriberConfig{
+		Endpoints: map[string]string{syscoinrpc.TopicRawTx: pub.Endpoint},
+		Network:   syscoinrpc.RegTest,
+	})
+	require.NoError(t, err)
+	defer sub.Close()
+	require.Eventually(t, func() bool { return pub.Subscribed(syscoinrpc.TopicRawTx) }, 5*time.Second, time.Millisecond)
+
+	pub.Publish(syscoinrpc.TopicRawTx, []byte{0x01, 0x02})
+	select {
+	case err = <-sub.Errors():
+		require.True(t, errors.Is(err, syscoinrpc.ErrInvalidNotification), "Must report malformed messages, got %v", err)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "Timed out waiting for an error")
+	}
+
+	// The subscriber reconnects after a lost connection.
+	pub.Disconnect()
+	select {
+	case err = <-sub.Errors():
+		require.Error(t, err, "Must report lost connections")
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "Timed out waiting for an error")
+	}
+	require.Eventually(t, func() bool { return pub.Subscribed(syscoinrpc.TopicRawTx) }, 5*time.Second, time.Millisecond)
+
+	sub.Close()
+	_, ok := <-sub.Notifications()
+	require.False(t, ok, "The notifications must be closed once the subscriber is closed")
+}
+
+func TestSubscribeOK(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	t.Cleanup(srv.Close)
+	cl, err := srv.Client(testTimeout)
+	require.NoError(t, err)
+	pub := syscoinrpctest.NewPublisher()
+	t.Cleanup(pub.Close)
+	srv.SetPublisher(pub)
+
+	sub, err := cl.Blockchain.Subscribe(context.Background(), syscoinrpc.SubscriberConfig{
+		Endpoints: map[string]string{
+			syscoinrpc.TopicHashBlock: pub.Endpoint,
+			syscoinrpc.TopicRawBlock:  pub.Endpoint,
+			syscoinrpc.TopicRawTx:     pub.Endpoint,
+		},
+		Network: syscoinrpc.RegTest,
+	})
+	require.NoError(t, err)
+	defer sub.Close()
+	for _, topic := range []string{syscoinrpc.TopicHashBlock, syscoinrpc.TopicRawBlock, syscoinrpc.TopicRawTx} {
+		require.Eventually(t, func() bool { return pub.Subscribed(topic) }, 5*time.Second, time.Millisecond)
+	}
+
+	_, rawTx := testWitnessTx()
+	txID := srv.AddRawTransaction(rawTx)
+	n := nextNotification(t, sub)
+	require.Equal(t, syscoinrpc.TopicRawTx, n.Topic)
+	require.Equal(t, txID, n.Hash)
+	require.Equal(t, txID, n.Transaction.TxID, "Must decode raw transactions")
+	require.Equal(t, rawTx, n.Raw)
+
+	hash := srv.Generate(1)[0]
+	n = nextNotification(t, sub)
+	require.Equal(t, syscoinrpc.TopicHashBlock, n.Topic)
+	require.Equal(t, uint32(0), n.Sequence)
+	require.Equal(t, hash, n.Hash)
+	n = nextNotification(t, sub)
+	require.Equal(t, syscoinrpc.TopicRawBlock, n.Topic)
+	require.Equal(t, hash, n.Hash)
+	require.Equal(t, hash, n.Block.Hash, "Must decode raw blocks")
+	require.Len(t, n.Block.Tx, 2)
+	require.Equal(t, txID, n.Block.Tx[1].TxID)
+	for _, tx := range n.Block.Tx {
+		n = nextNotification(t, sub)
+		require.Equal(t, syscoinrpc.TopicRawTx, n.Topic)
+		require.Equal(t, tx.TxID, n.Hash)
+	}
+
+	pub.Skip(syscoinrpc.TopicHashBlock, 2)
+	hash = srv.Generate(1)[0]
+	n = nextNotification(t, sub)
+	require.Equal(t, syscoinrpc.TopicHashBlock, n.Topic)
+	require.Equal(t, uint32(3), n.Sequence)
+	require.Equal(t, uint32(2), n.Missed, "Must detect the dropped messages")
+	require.Equal(t, hash, n.Hash)
+}
+
+func TestSubscribePollingOK(t *testing.T) {
+	srv := syscoinrpctest.NewServer("user", "pass")
+	t.Cleanup(srv.Close)
+	cl, err := srv.Client(testTimeout)
+	require.NoError(t, err)
+
+	sub, err := cl.Blockchain.Subscribe(context.Background(), syscoinrpc.SubscriberConfig{
+		PollInterval: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer sub.Close()
+
+	// Wait for the first poll, which is the starting point.
+	time.Sleep(50 * time.Millisecond)
+	for i := uint32(0); i < 2; i++ {
+		hash := srv.Generate(1)[0]
+		n := nextNotification(t, sub)
+		require.Equal(t, syscoinrpc.TopicHashBlock, n.Topic)
+		require.Equal(t, i, n.Sequence)
+		require.Equal(t, hash, n.Hash, "Must notify the new tips")
+		require.Equal(t, hash.String(), hex.EncodeToString(n.Raw), "The body must be in display byte order")
+	}
+}
@@ -0,0 +1,219 @@
+package syscoinrpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// ErrZMTPProtocol is returned when a ZeroMQ peer does not follow the ZMTP protocol.
+var ErrZMTPProtocol = errors.New("ZMTP protocol error")
+
+// ZMTP 3.0 framing, see https://rfc.zeromq.org/spec/23/.
+const (
+	zmtpFlagMore    byte = 0x01 // More frames follow in the message.
+	zmtpFlagLong    byte = 0x02 // The frame size is encoded on 8 bytes.
+	zmtpFlagCommand byte = 0x04 // The frame is a command.
+
+	zmtpGreetingSize = 64
+	// zmtpMaxFrameSize bounds the frames read, the largest being the raw blocks.
+	zmtpMaxFrameSize = 64 << 20
+	// zmtpHandshakeTimeout bounds the time taken by the greeting and READY exchange.
+	zmtpHandshakeTimeout = 10 * time.Second
+)
+
+// zmtpConn is a ZMTP 3.0 connection using the NULL security mechanism,
+// the one used by the node.
+type zmtpConn struct {
+	conn net.Conn      // The underlying connection.
+	r    *bufio.Reader // The buffered reader of conn.
+}
+
+// zmtpEndpointAddress returns the network and address of a ZeroMQ
+// endpoint, like tcp://127.0.0.1:28332 or ipc:///tmp/syscoind.sock.
+func zmtpEndpointAddress(endpoint string) (network string, address string, err error) {
+	switch {
+	case strings.HasPrefix(endpoint, "tcp://"):
+		return "tcp", strings.TrimPrefix(endpoint, "tcp://"), nil
+	case strings.HasPrefix(endpoint, "ipc://"):
+		return "unix", strings.TrimPrefix(endpoint, "ipc://"), nil
+	}
+	return "", "", fmt.Errorf("unsupported ZeroMQ endpoint %q: must be tcp:// or ipc://", endpoint)
+}
+
+// dialZMTP connects to the endpoint and performs the handshake, as a socket of the given type.
+func dialZMTP(ctx context.Context, endpoint string, socketType string) (*zmtpConn, error) {
+	network, address, err := zmtpEndpointAddress(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	zc := newZMTPConn(conn)
+	err = zc.handshake(socketType)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return zc, nil
+}
+
+func newZMTPConn(conn net.Conn) *zmtpConn {
+	return &zmtpConn{conn: conn, r: bufio.NewReader(conn)}
+}
+
+// handshake exchanges the greetings and READY commands with the peer.
+func (zc *zmtpConn) handshake(socketType string) error {
+	zc.conn.SetDeadline(time.Now().Add(zmtpHandshakeTimeout))
+	defer zc.conn.SetDeadline(time.Time{})
+
+	greeting := make([]byte, zmtpGreetingSize)
+	greeting[0] = 0xff
+	greeting[9] = 0x7f
+	greeting[10] = 3 // Major version.
+	greeting[11] = 0 // Minor version.
+	copy(greeting[12:32], "NULL")
+	_, err := zc.conn.Write(greeting)
+	if err != nil {
+		return err
+	}
+
+	peerGreeting := make([]byte, zmtpGreetingSize)
+	_, err = io.ReadFull(zc.r, peerGreeting)
+	if err != nil {
+		return err
+	}
+	if peerGreeting[0] != 0xff || peerGreeting[9] != 0x7f {
+		return fmt.Errorf("%w: invalid greeting signature", ErrZMTPProtocol)
+	}
+	if peerGreeting[10] < 3 {
+		return fmt.Errorf("%w: unsupported version %d", ErrZMTPProtocol, peerGreeting[10])
+	}
+	if mechanism := string(bytes.TrimRight(peerGreeting[12:32], "\x00")); mechanism != "NULL" {
+		return fmt.Errorf("%w: unsupported security mechanism %q", ErrZMTPProtocol, mechanism)
+	}
+
+	err = zc.writeFrame(zmtpFlagCommand, zmtpReadyCommand(socketType))
+	if err != nil {
+		return err
+	}
+
+	flags, body, err := zc.readFrame()
+	if err != nil {
+		return err
+	}
+	if flags&zmtpFlagCommand == 0 || len(body) < 6 || string(body[:6]) != "\x05READY" {
+		return fmt.Errorf("%w: expected a READY command", ErrZMTPProtocol)
+	}
+	return nil
+}
+
+// zmtpReadyCommand returns the body of the READY command of a socket type.
+func zmtpReadyCommand(socketType string) []byte {
+	var body bytes.Buffer
+	body.WriteString("\x05READY")
+	body.WriteString("\x0bSocket-Type")
+	binary.Write(&body, binary.BigEndian, uint32(len(socketType)))
+	body.WriteString(socketType)
+	return body.Bytes()
+}
+
+// writeFrame writes a single frame.
+func (zc *zmtpConn) writeFrame(flags byte, body []byte) error {
+	var header []byte
+	if len(body) > 255 {
+		header = make([]byte, 9)
+		header[0] = flags | zmtpFlagLong
+		binary.BigEndian.PutUint64(header[1:], uint64(len(body)))
+	} else {
+		header = []byte{flags, byte(len(body))}
+	}
+	_, err := zc.conn.Write(append(header, body...))
+	return err
+}
+
+// readFrame reads a single frame.
+func (zc *zmtpConn) readFrame() (flags byte, body []byte, err error) {
+	flags, err = zc.r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var size uint64
+	if flags&zmtpFlagLong != 0 {
+		var long [8]byte
+		_, err = io.ReadFull(zc.r, long[:])
+		size = binary.BigEndian.Uint64(long[:])
+	} else {
+		var short byte
+		short, err = zc.r.ReadByte()
+		size = uint64(short)
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	if size > zmtpMaxFrameSize {
+		return 0, nil, fmt.Errorf("%w: frame of %d bytes exceeds the maximum size", ErrZMTPProtocol, size)
+	}
+
+	body = make([]byte, size)
+	_, err = io.ReadFull(zc.r, body)
+	if err != nil {
+		return 0, nil, err
+	}
+	return flags, body, nil
+}
+
+// writeMessage writes a message made of the given parts.
+func (zc *zmtpConn) writeMessage(parts ...[]byte) error {
+	for i, part := range parts {
+		var flags byte
+		if i < len(parts)-1 {
+			flags = zmtpFlagMore
+		}
+		err := zc.writeFrame(flags, part)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readMessage reads the next message, skipping the commands.
+func (zc *zmtpConn) readMessage() ([][]byte, error) {
+	var parts [][]byte
+	for {
+		flags, body, err := zc.readFrame()
+		if err != nil {
+			return nil, err
+		}
+		if flags&zmtpFlagCommand != 0 {
+			continue
+		}
+		parts = append(parts, body)
+		if flags&zmtpFlagMore == 0 {
+			return parts, nil
+		}
+	}
+}
+
+// subscribe subscribes a SUB socket to the messages starting with prefix.
+func (zc *zmtpConn) subscribe(prefix string) error {
+	return zc.writeMessage(append([]byte{1}, prefix...))
+}
+
+// Close closes the connection.
+func (zc *zmtpConn) Close() error {
+	return zc.conn.Close()
+}